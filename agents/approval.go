@@ -0,0 +1,96 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ApprovalDecision is the decision taken on an action of the agent before it
+// is executed.
+type ApprovalDecision int
+
+const (
+	// ActionApproved lets the executor call the tool of the action.
+	ActionApproved ApprovalDecision = iota
+	// ActionRejected skips the action, giving the feedback of the approval to
+	// the agent as its observation.
+	ActionRejected
+	// ActionEdited calls the tool of the action with the input of the approval.
+	ActionEdited
+	// ActionSuspended stops the run with a SuspendedError, holding the state
+	// needed to resume it once a decision has been taken.
+	ActionSuspended
+)
+
+// _defaultRejectionFeedback is the observation of rejected actions without
+// feedback.
+const _defaultRejectionFeedback = "The action was rejected by the user."
+
+// Approval is the decision on an action of the agent.
+type Approval struct {
+	Decision ApprovalDecision `json:"decision"`
+	// Feedback is the observation given to the agent for a rejected action.
+	Feedback string `json:"feedback,omitempty"`
+	// ToolInput is the input of the tool for an edited action.
+	ToolInput string `json:"toolInput,omitempty"`
+}
+
+// Approve approves an action.
+func Approve() Approval {
+	return Approval{Decision: ActionApproved}
+}
+
+// Reject rejects an action, with feedback for the agent.
+func Reject(feedback string) Approval {
+	return Approval{Decision: ActionRejected, Feedback: feedback}
+}
+
+// EditInput approves an action with another input for its tool.
+func EditInput(toolInput string) Approval {
+	return Approval{Decision: ActionEdited, ToolInput: toolInput}
+}
+
+// Suspend suspends the run until the action is decided on.
+func Suspend() Approval {
+	return Approval{Decision: ActionSuspended}
+}
+
+// ApprovalFunc is consulted by the executor before each action needing
+// approval is executed.
+type ApprovalFunc func(ctx context.Context, action schema.AgentAction) (Approval, error)
+
+// RunState is the state of a suspended run, which can be serialized to JSON
+// and given to Executor.Resume to continue the run.
+type RunState struct {
+	// Inputs are the inputs of the run.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the steps taken so far.
+	Steps []schema.AgentStep `json:"steps"`
+	// Pending are the actions planned by the agent and not executed yet.
+	Pending []schema.AgentAction `json:"pending,omitempty"`
+	// Approvals are the decisions on the pending actions, in the same order.
+	// When the run is resumed, the actions needing approval without a
+	// decision are submitted to the approval function again.
+	Approvals []*Approval `json:"approvals,omitempty"`
+	// Iterations is the number of iterations done so far.
+	Iterations int `json:"iterations"`
+}
+
+// SuspendedError is returned by the executor when the approval function
+// suspends the run.
+type SuspendedError struct {
+	// State is the state needed to resume the run.
+	State *RunState
+	// Action is the action waiting for a decision.
+	Action schema.AgentAction
+}
+
+func (e *SuspendedError) Error() string {
+	return fmt.Sprintf("%s: action %s waiting for approval", ErrSuspended, e.Action.Tool)
+}
+
+func (e *SuspendedError) Unwrap() error {
+	return ErrSuspended
+}
@@ -0,0 +1,171 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const (
+	// DelegatedStepsOutputKey is the output key of the intermediate steps of
+	// the sub-agents called by an executor returning its intermediate steps,
+	// as a []DelegatedSteps.
+	DelegatedStepsOutputKey = "delegatedSteps"
+
+	_defaultMaxDelegationDepth = 3
+)
+
+// DelegatedSteps are the intermediate steps of a sub-agent called through a
+// ChainTool.
+type DelegatedSteps struct {
+	// Tool is the name of the ChainTool.
+	Tool string `json:"tool"`
+	// Input is the input given to the sub-agent.
+	Input string `json:"input"`
+	// Depth is the delegation depth of the sub-agent, starting at 1 for the
+	// sub-agents of the top-level executor.
+	Depth int `json:"depth"`
+	// Steps are the intermediate steps of the sub-agent.
+	Steps []schema.AgentStep `json:"steps"`
+}
+
+// ChainTool is a tool delegating to a chain, usually the executor of a
+// specialist agent, so that a supervisor agent can hand over tasks to it.
+//
+// The chain keeps its own memory and callbacks handler, and does not share
+// the checkpoints of the run of the supervisor. Delegations are limited in
+// depth to stop agents from delegating to each other endlessly.
+type ChainTool struct {
+	// Chain is the chain called with the input of the tool.
+	Chain chains.Chain
+	// ToolName is the name of the tool.
+	ToolName string
+	// ToolDescription is the description of the tool, telling the supervisor
+	// what the chain is good at.
+	ToolDescription string
+	// InputKey is the input key of the chain receiving the input of the tool.
+	InputKey string
+	// OutputKey is the output key of the chain holding the output of the tool.
+	OutputKey string
+	// MaxDepth is the maximum number of nested delegations, including this
+	// one, when the chain is called.
+	MaxDepth int
+}
+
+var _ tools.Tool = &ChainTool{}
+
+// NewChainTool creates a new ChainTool. The input and output keys default to
+// the single input key and the first output key of the chain, and can be set
+// with WithInputKey and WithOutputKey. The depth of the delegations is set
+// with WithMaxDelegationDepth.
+func NewChainTool(chain chains.Chain, name, description string, opts ...Option) *ChainTool {
+	options := chainToolDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	inputKey := options.inputKey
+	if inputKey == "" {
+		inputKey = "input"
+		if keys := chain.GetInputKeys(); len(keys) == 1 {
+			inputKey = keys[0]
+		}
+	}
+	outputKey := options.outputKey
+	if outputKey == "" {
+		outputKey = _defaultOutputKey
+		if keys := chain.GetOutputKeys(); len(keys) > 0 {
+			outputKey = keys[0]
+		}
+	}
+
+	return &ChainTool{
+		Chain:           chain,
+		ToolName:        name,
+		ToolDescription: description,
+		InputKey:        inputKey,
+		OutputKey:       outputKey,
+		MaxDepth:        options.maxDelegationDepth,
+	}
+}
+
+func (t *ChainTool) Name() string {
+	return t.ToolName
+}
+
+func (t *ChainTool) Description() string {
+	return t.ToolDescription
+}
+
+// Call calls the chain with the input. It fails with ErrMaxDelegationDepth if
+// the call would nest more delegations than MaxDepth.
+func (t *ChainTool) Call(ctx context.Context, input string) (string, error) {
+	depth := delegationDepth(ctx) + 1
+	if depth > t.MaxDepth {
+		return "", fmt.Errorf("%w: %s at depth %d", ErrMaxDelegationDepth, t.ToolName, depth)
+	}
+	ctx = context.WithValue(ctx, delegationDepthKey{}, depth)
+	// The chain is not part of the run of the supervisor, whose checkpoints
+	// it must not overwrite.
+	ctx = ContextWithRunID(ctx, "")
+
+	// The steps of an executor are recorded from the context rather than
+	// from its outputs, which must not change with those of the supervisor.
+	recorder := delegationRecorderFromContext(ctx)
+	var stepsRecorder *StepsRecorder
+	if recorder != nil {
+		ctx, stepsRecorder = ContextWithStepsRecorder(ctx)
+	}
+
+	outputs, err := chains.Call(ctx, t.Chain, map[string]any{t.InputKey: input})
+	if steps := stepsRecorder.Steps(); steps != nil {
+		recorder.add(DelegatedSteps{Tool: t.ToolName, Input: input, Depth: depth, Steps: steps})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	output, ok := outputs[t.OutputKey].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidChainReturnType, t.ToolName)
+	}
+	return output, nil
+}
+
+type delegationDepthKey struct{}
+
+// delegationDepth returns the number of nested delegations of the context.
+func delegationDepth(ctx context.Context) int {
+	depth, _ := ctx.Value(delegationDepthKey{}).(int)
+	return depth
+}
+
+type delegationRecorderKey struct{}
+
+// delegationRecorder collects the steps of the sub-agents of a run, which can
+// be called concurrently.
+type delegationRecorder struct {
+	mu    sync.Mutex
+	steps []DelegatedSteps
+}
+
+func (r *delegationRecorder) add(steps DelegatedSteps) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, steps)
+}
+
+func (r *delegationRecorder) delegatedSteps() []DelegatedSteps {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]DelegatedSteps(nil), r.steps...)
+}
+
+func delegationRecorderFromContext(ctx context.Context) *delegationRecorder {
+	recorder, _ := ctx.Value(delegationRecorderKey{}).(*delegationRecorder)
+	return recorder
+}
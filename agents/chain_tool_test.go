@@ -0,0 +1,95 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func TestChainToolSupervisor(t *testing.T) {
+	t.Parallel()
+
+	researcher := agents.NewExecutor(&toolsAgent{
+		testAgent: testAgent{
+			actions:    []schema.AgentAction{{Tool: "search", ToolInput: "0"}},
+			inputKeys:  []string{"input"},
+			outputKeys: []string{"output"},
+		},
+		tools: []tools.Tool{&concurrencyTool{name: "search"}},
+	})
+	supervisor := agents.NewExecutor(&toolsAgent{
+		testAgent: testAgent{actions: []schema.AgentAction{{Tool: "researcher", ToolInput: "find it"}}},
+		tools:     []tools.Tool{agents.NewChainTool(researcher, "researcher", "Searches the web.")},
+	}, agents.WithReturnIntermediateSteps())
+
+	result, err := chains.Call(context.Background(), supervisor, map[string]any{"input": "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result["output"])
+	assert.Equal(t, []schema.AgentStep{{
+		Action:      schema.AgentAction{Tool: "researcher", ToolInput: "find it"},
+		Observation: "done",
+	}}, result["intermediateSteps"])
+	assert.Equal(t, []agents.DelegatedSteps{{
+		Tool:  "researcher",
+		Input: "find it",
+		Depth: 1,
+		Steps: []schema.AgentStep{{
+			Action:      schema.AgentAction{Tool: "search", ToolInput: "0"},
+			Observation: "search 0",
+		}},
+	}}, result[agents.DelegatedStepsOutputKey])
+	assert.False(t, researcher.ReturnIntermediateSteps)
+}
+
+func TestChainToolSubAgentWithMemory(t *testing.T) {
+	t.Parallel()
+
+	researcher := agents.NewExecutor(&toolsAgent{
+		testAgent: testAgent{
+			actions:    []schema.AgentAction{{Tool: "search", ToolInput: "0"}},
+			inputKeys:  []string{"input"},
+			outputKeys: []string{"output"},
+		},
+		tools: []tools.Tool{&concurrencyTool{name: "search"}},
+	}, agents.WithMemory(memory.NewConversationBuffer()))
+	supervisor := agents.NewExecutor(&toolsAgent{
+		testAgent: testAgent{actions: []schema.AgentAction{{Tool: "researcher", ToolInput: "find it"}}},
+		tools:     []tools.Tool{agents.NewChainTool(researcher, "researcher", "Searches the web.")},
+	}, agents.WithReturnIntermediateSteps())
+
+	result, err := chains.Call(context.Background(), supervisor, map[string]any{"input": "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result["output"])
+	delegated, ok := result[agents.DelegatedStepsOutputKey].([]agents.DelegatedSteps)
+	require.True(t, ok)
+	require.Len(t, delegated, 1)
+	assert.Len(t, delegated[0].Steps, 1)
+
+	history, err := researcher.Memory.LoadMemoryVariables(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Human: find it\nAI: done", history["history"])
+}
+
+func TestChainToolMaxDelegationDepth(t *testing.T) {
+	t.Parallel()
+
+	a := &toolsAgent{testAgent: testAgent{
+		actions:    []schema.AgentAction{{Tool: "self", ToolInput: "again"}},
+		inputKeys:  []string{"input"},
+		outputKeys: []string{"output"},
+	}}
+	executor := agents.NewExecutor(a)
+	a.tools = []tools.Tool{agents.NewChainTool(executor, "self", "Delegates to itself.",
+		agents.WithMaxDelegationDepth(2))}
+
+	_, err := chains.Call(context.Background(), executor, map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, agents.ErrMaxDelegationDepth)
+	require.ErrorContains(t, err, "self at depth 3")
+}
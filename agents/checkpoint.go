@@ -0,0 +1,158 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpointer persists the state of agent runs, keyed by their run ID, so
+// that a run interrupted by a crash or a deploy can be resumed with
+// Executor.ResumeRun.
+type Checkpointer interface {
+	// Save stores the state of the run, replacing the previous one.
+	Save(ctx context.Context, runID string, state *RunState) error
+	// Load returns the state of the run, or ErrCheckpointNotFound.
+	Load(ctx context.Context, runID string) (*RunState, error)
+	// Delete removes the state of the run, if any.
+	Delete(ctx context.Context, runID string) error
+}
+
+type runIDKey struct{}
+
+// ContextWithRunID returns a context identifying the run of the executor
+// called with it. The executor saves the state of the run to its
+// checkpointer after every iteration under this ID.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunIDFromContext returns the run ID set by ContextWithRunID, if any.
+func RunIDFromContext(ctx context.Context) (string, bool) {
+	runID, ok := ctx.Value(runIDKey{}).(string)
+	return runID, ok && runID != ""
+}
+
+// InMemoryCheckpointer is a Checkpointer keeping the states in memory, which
+// is mostly useful to suspend runs waiting for approval.
+type InMemoryCheckpointer struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+var _ Checkpointer = &InMemoryCheckpointer{}
+
+// NewInMemoryCheckpointer creates a new InMemoryCheckpointer.
+func NewInMemoryCheckpointer() *InMemoryCheckpointer {
+	return &InMemoryCheckpointer{states: make(map[string][]byte)}
+}
+
+// Save stores a copy of the state of the run.
+func (c *InMemoryCheckpointer) Save(_ context.Context, runID string, state *RunState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.states[runID] = data
+	return nil
+}
+
+// Load returns a copy of the state of the run.
+func (c *InMemoryCheckpointer) Load(_ context.Context, runID string) (*RunState, error) {
+	c.mu.Lock()
+	data, ok := c.states[runID]
+	c.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointNotFound, runID)
+	}
+	return decodeRunState(data)
+}
+
+// Delete removes the state of the run.
+func (c *InMemoryCheckpointer) Delete(_ context.Context, runID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.states, runID)
+	return nil
+}
+
+// FileCheckpointer is a Checkpointer storing each state as a JSON file in a
+// directory.
+type FileCheckpointer struct {
+	// Dir is the directory of the files, created if needed.
+	Dir string
+}
+
+var _ Checkpointer = FileCheckpointer{}
+
+// NewFileCheckpointer creates a new FileCheckpointer storing the states in dir.
+func NewFileCheckpointer(dir string) FileCheckpointer {
+	return FileCheckpointer{Dir: dir}
+}
+
+// Save writes the state of the run to its file. The file is replaced
+// atomically, so that a crash while saving keeps the previous state.
+func (c FileCheckpointer) Save(_ context.Context, runID string, state *RunState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil { //nolint:gomnd
+		return err
+	}
+
+	f, err := os.CreateTemp(c.Dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path(runID))
+}
+
+// Load reads the state of the run from its file.
+func (c FileCheckpointer) Load(_ context.Context, runID string) (*RunState, error) {
+	data, err := os.ReadFile(c.path(runID))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointNotFound, runID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeRunState(data)
+}
+
+// Delete removes the file of the run.
+func (c FileCheckpointer) Delete(_ context.Context, runID string) error {
+	err := os.Remove(c.path(runID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// path returns the path of the file of the run. The run ID is escaped so
+// that it cannot point outside of the directory.
+func (c FileCheckpointer) path(runID string) string {
+	return filepath.Join(c.Dir, url.PathEscape(runID)+".json")
+}
+
+func decodeRunState(data []byte) (*RunState, error) {
+	state := &RunState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	return state, nil
+}
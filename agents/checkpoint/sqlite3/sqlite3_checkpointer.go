@@ -0,0 +1,118 @@
+// Package sqlite3 adds support for
+// checkpointing agent runs using sqlite3.
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+	"github.com/tmc/langchaingo/agents"
+)
+
+// DefaultTableName sets a default table name.
+const DefaultTableName = "langchaingo_checkpoints"
+
+// DefaultSchema sets a default schema to be run after connecting.
+const DefaultSchema = `CREATE TABLE IF NOT EXISTS %s (
+		run_id TEXT PRIMARY KEY,
+		state TEXT NOT NULL,
+		updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`
+
+// Checkpointer is a checkpointer storing the states of agent runs in a
+// sqlite3 table.
+type Checkpointer struct {
+	// DB is the database connection.
+	DB *sql.DB
+	// TableName is the name of the checkpoints table.
+	TableName string
+}
+
+// Statically assert that Checkpointer implements the checkpointer interface.
+var _ agents.Checkpointer = &Checkpointer{}
+
+// Option is a function for creating a new checkpointer with other than the
+// default values.
+type Option func(c *Checkpointer)
+
+// WithTableName is an option for New for setting the name of the checkpoints
+// table.
+func WithTableName(name string) Option {
+	return func(c *Checkpointer) {
+		c.TableName = name
+	}
+}
+
+// New creates a new Checkpointer using the database, creating the checkpoints
+// table if it does not exist.
+func New(ctx context.Context, db *sql.DB, options ...Option) (*Checkpointer, error) {
+	c := &Checkpointer{DB: db}
+	for _, option := range options {
+		option(c)
+	}
+	if c.TableName == "" {
+		c.TableName = DefaultTableName
+	}
+
+	if _, err := c.DB.ExecContext(ctx, fmt.Sprintf(DefaultSchema, c.TableName)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save stores the state of the run, replacing the previous one.
+func (c *Checkpointer) Save(ctx context.Context, runID string, state *agents.RunState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	querytpl := []string{
+		"INSERT INTO ",
+		" (run_id, state) VALUES (?, ?)" +
+			" ON CONFLICT(run_id) DO UPDATE SET state = excluded.state, updated = CURRENT_TIMESTAMP;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+	_, err = c.DB.ExecContext(ctx, query, runID, string(data))
+	return err
+}
+
+// Load returns the state of the run.
+func (c *Checkpointer) Load(ctx context.Context, runID string) (*agents.RunState, error) {
+	querytpl := []string{
+		"SELECT state FROM ",
+		" WHERE run_id = ?;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+
+	var data string
+	err := c.DB.QueryRowContext(ctx, query, runID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", agents.ErrCheckpointNotFound, runID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &agents.RunState{}
+	if err := json.Unmarshal([]byte(data), state); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	return state, nil
+}
+
+// Delete removes the state of the run.
+func (c *Checkpointer) Delete(ctx context.Context, runID string) error {
+	querytpl := []string{
+		"DELETE FROM ",
+		" WHERE run_id = ?;",
+	}
+	query := strings.Join(querytpl, c.TableName)
+	_, err := c.DB.ExecContext(ctx, query, runID)
+	return err
+}
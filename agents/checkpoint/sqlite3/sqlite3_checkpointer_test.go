@@ -0,0 +1,45 @@
+package sqlite3_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/agents/checkpoint/sqlite3"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestCheckpointer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	c, err := sqlite3.New(ctx, db, sqlite3.WithTableName("checkpoints"))
+	require.NoError(t, err)
+
+	_, err = c.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+
+	state := &agents.RunState{
+		Inputs:     map[string]string{"input": "foo"},
+		Steps:      []schema.AgentStep{{Action: schema.AgentAction{Tool: "search", ToolInput: "foo"}, Observation: "bar"}},
+		Iterations: 1,
+	}
+	require.NoError(t, c.Save(ctx, "run", state))
+	state.Iterations = 2
+	require.NoError(t, c.Save(ctx, "run", state))
+
+	loaded, err := c.Load(ctx, "run")
+	require.NoError(t, err)
+	assert.Equal(t, state, loaded)
+
+	require.NoError(t, c.Delete(ctx, "run"))
+	_, err = c.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
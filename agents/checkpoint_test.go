@@ -0,0 +1,242 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func TestCheckpointers(t *testing.T) {
+	t.Parallel()
+
+	checkpointers := map[string]agents.Checkpointer{
+		"memory": agents.NewInMemoryCheckpointer(),
+		"file":   agents.NewFileCheckpointer(t.TempDir()),
+	}
+	for name, c := range checkpointers {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			_, err := c.Load(ctx, "run/1")
+			require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+
+			state := &agents.RunState{
+				Inputs:     map[string]string{"input": "foo"},
+				Steps:      []schema.AgentStep{{Action: schema.AgentAction{Tool: "search", ToolInput: "foo"}, Observation: "bar"}},
+				Iterations: 1,
+			}
+			require.NoError(t, c.Save(ctx, "run/1", state))
+			state.Iterations = 2
+			require.NoError(t, c.Save(ctx, "run/1", state))
+
+			loaded, err := c.Load(ctx, "run/1")
+			require.NoError(t, err)
+			assert.Equal(t, state, loaded)
+
+			require.NoError(t, c.Delete(ctx, "run/1"))
+			require.NoError(t, c.Delete(ctx, "run/1"))
+			_, err = c.Load(ctx, "run/1")
+			require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+		})
+	}
+}
+
+// stepsAgent plans one action per iteration until it has taken three steps,
+// and fails once when it has taken crashAt steps.
+// not synchronized, don't use concurrently!
+type stepsAgent struct {
+	testAgent
+	tool    tools.Tool
+	crashAt int
+	crashed bool
+}
+
+func (a *stepsAgent) Plan(
+	_ context.Context,
+	intermediateSteps []schema.AgentStep,
+	_ map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	n := len(intermediateSteps)
+	if n == a.crashAt && !a.crashed {
+		a.crashed = true
+		return nil, nil, errors.New("crash")
+	}
+	if n == 3 {
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done"}}, nil
+	}
+	return []schema.AgentAction{{Tool: a.tool.Name(), ToolInput: strconv.Itoa(n)}}, nil, nil
+}
+
+func (a *stepsAgent) GetTools() []tools.Tool {
+	return []tools.Tool{a.tool}
+}
+
+func TestExecutorResumeRun(t *testing.T) {
+	t.Parallel()
+
+	tool := &flakyTool{}
+	checkpointer := agents.NewInMemoryCheckpointer()
+	executor := agents.NewExecutor(&stepsAgent{tool: tool, crashAt: 2},
+		agents.WithCheckpointer(checkpointer),
+		agents.WithReturnIntermediateSteps(),
+	)
+	ctx := agents.ContextWithRunID(context.Background(), "run")
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "foo"})
+	require.EqualError(t, err, "crash")
+	require.Equal(t, 2, tool.calls)
+
+	state, err := checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, 2, state.Iterations)
+	require.Len(t, state.Steps, 2)
+
+	result, err := executor.ResumeRun(context.Background(), "run")
+	require.NoError(t, err)
+	require.Equal(t, "done", result["output"])
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 3)
+	require.Equal(t, "flaky 2", steps[2].Observation)
+	require.Equal(t, 3, tool.calls)
+
+	_, err = checkpointer.Load(ctx, "run")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
+
+func TestExecutorResumeSuspendedRun(t *testing.T) {
+	t.Parallel()
+
+	tool := &flakyTool{}
+	checkpointer := agents.NewInMemoryCheckpointer()
+	suspend := func(context.Context, schema.AgentAction) (agents.Approval, error) {
+		return agents.Suspend(), nil
+	}
+	executor := agents.NewExecutor(&stepsAgent{tool: tool, crashAt: -1},
+		agents.WithCheckpointer(checkpointer),
+		agents.WithApproval(suspend),
+	)
+	ctx := agents.ContextWithRunID(context.Background(), "run")
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "foo"})
+	require.ErrorIs(t, err, agents.ErrSuspended)
+
+	state, err := checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Len(t, state.Pending, 1)
+	approve := agents.Approve()
+	state.Approvals = []*agents.Approval{&approve}
+	require.NoError(t, checkpointer.Save(ctx, "run", state))
+
+	// The next action is suspended again after the approved one is executed.
+	_, err = executor.ResumeRun(context.Background(), "run")
+	require.ErrorIs(t, err, agents.ErrSuspended)
+	require.Equal(t, 1, tool.calls)
+
+	state, err = checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, 1, state.Iterations)
+	require.Len(t, state.Steps, 1)
+	require.Equal(t, "1", state.Pending[0].ToolInput)
+}
+
+// actionsAgent plans the actions calling its tool with the inputs that have no
+// step yet, all at once, and finishes once they all have one.
+type actionsAgent struct {
+	testAgent
+	tool   tools.Tool
+	inputs []string
+}
+
+func (a *actionsAgent) Plan(
+	_ context.Context,
+	intermediateSteps []schema.AgentStep,
+	_ map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	done := make(map[string]bool, len(intermediateSteps))
+	for _, step := range intermediateSteps {
+		done[step.Action.ToolInput] = true
+	}
+	var actions []schema.AgentAction
+	for _, input := range a.inputs {
+		if !done[input] {
+			actions = append(actions, schema.AgentAction{Tool: a.tool.Name(), ToolInput: input})
+		}
+	}
+	if len(actions) == 0 {
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done"}}, nil
+	}
+	return actions, nil, nil
+}
+
+func (a *actionsAgent) GetTools() []tools.Tool {
+	return []tools.Tool{a.tool}
+}
+
+// sideEffectTool counts its calls with "effect". Its first call with another
+// input fails once a call with "effect" has returned.
+type sideEffectTool struct {
+	effects  atomic.Int32
+	failed   atomic.Bool
+	effected chan struct{}
+}
+
+func (t *sideEffectTool) Name() string        { return "side_effect" }
+func (t *sideEffectTool) Description() string { return "has side effects" }
+
+func (t *sideEffectTool) Call(_ context.Context, input string) (string, error) {
+	if input == "effect" {
+		if t.effects.Add(1) == 1 {
+			close(t.effected)
+		}
+		return "effect done", nil
+	}
+	<-t.effected
+	if t.failed.CompareAndSwap(false, true) {
+		return "", errors.New("tool failed")
+	}
+	return input + " done", nil
+}
+
+func TestExecutorResumeRunAfterParallelActionsError(t *testing.T) {
+	t.Parallel()
+
+	tool := &sideEffectTool{effected: make(chan struct{})}
+	checkpointer := agents.NewInMemoryCheckpointer()
+	executor := agents.NewExecutor(&actionsAgent{tool: tool, inputs: []string{"effect", "other"}},
+		agents.WithCheckpointer(checkpointer),
+		agents.WithMaxConcurrency(2),
+		agents.WithReturnIntermediateSteps(),
+	)
+	ctx := agents.ContextWithRunID(context.Background(), "run")
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "foo"})
+	require.EqualError(t, err, "tool failed")
+
+	// The step completed by the failed iteration is saved.
+	state, err := checkpointer.Load(ctx, "run")
+	require.NoError(t, err)
+	require.Equal(t, 0, state.Iterations)
+	require.Len(t, state.Steps, 1)
+	require.Equal(t, "effect done", state.Steps[0].Observation)
+
+	result, err := executor.ResumeRun(context.Background(), "run")
+	require.NoError(t, err)
+	require.Equal(t, "done", result["output"])
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 2)
+	require.Equal(t, "other done", steps[1].Observation)
+	require.Equal(t, int32(1), tool.effects.Load())
+}
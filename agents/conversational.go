@@ -9,6 +9,7 @@ import (
 
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/prompts"
 	"github.com/tmc/langchaingo/schema"
@@ -34,6 +35,9 @@ type ConversationalAgent struct {
 	Tools []tools.Tool
 	// Output key is the key where the final output is placed.
 	OutputKey string
+	// FinalAnswerSchema is the schema of the final answer, if it must be a
+	// JSON value.
+	FinalAnswerSchema *jsonschema.Definition
 	// CallbacksHandler is the handler for callbacks.
 	CallbacksHandler callbacks.Handler
 }
@@ -52,9 +56,10 @@ func NewConversationalAgent(llm llms.Model, tools []tools.Tool, opts ...Option)
 			options.getConversationalPrompt(tools),
 			chains.WithCallback(options.callbacksHandler),
 		),
-		Tools:            tools,
-		OutputKey:        options.outputKey,
-		CallbacksHandler: options.callbacksHandler,
+		Tools:             tools,
+		OutputKey:         options.outputKey,
+		FinalAnswerSchema: options.finalAnswerSchema,
+		CallbacksHandler:  options.callbacksHandler,
 	}
 }
 
@@ -113,8 +118,13 @@ func (a *ConversationalAgent) GetOutputKeys() []string {
 	return []string{a.OutputKey}
 }
 
+// GetTools returns the tools of the agent, and the tool validating its final
+// answer if it has a schema.
 func (a *ConversationalAgent) GetTools() []tools.Tool {
-	return a.Tools
+	if a.FinalAnswerSchema == nil {
+		return a.Tools
+	}
+	return append(a.Tools[:len(a.Tools):len(a.Tools)], finalAnswerTool{def: a.FinalAnswerSchema})
 }
 
 func constructScratchPad(steps []schema.AgentStep) string {
@@ -133,15 +143,8 @@ func constructScratchPad(steps []schema.AgentStep) string {
 func (a *ConversationalAgent) parseOutput(output string) ([]schema.AgentAction, *schema.AgentFinish, error) {
 	if strings.Contains(output, _conversationalFinalAnswerAction) {
 		splits := strings.Split(output, _conversationalFinalAnswerAction)
-
-		finishAction := &schema.AgentFinish{
-			ReturnValues: map[string]any{
-				a.OutputKey: splits[len(splits)-1],
-			},
-			Log: output,
-		}
-
-		return nil, finishAction, nil
+		actions, finish := reactFinalAnswer(a.FinalAnswerSchema, a.OutputKey, splits[len(splits)-1], output)
+		return actions, finish, nil
 	}
 
 	r := regexp.MustCompile(`Action: (.*?)[\n]*Action Input: (.*)`)
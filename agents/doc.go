@@ -10,7 +10,10 @@
 // Package agents provides and implementation of the agent interface called
 // OneShotZeroAgent. This agent uses the ReAct Framework (based on the
 // descriptions of tools) to decide what action to take. This agent is
-// optimized to be used with LLMs.
+// optimized to be used with LLMs. The ToolCallingAgent instead relies on the
+// tool calling API of chat models, and can call several tools at once. The
+// PlanAndExecuteAgent first asks for a step by step plan, then executes each
+// step with an inner executor, revising the plan after every step.
 //
 // To make agents more powerful we need to make them iterative, i.e. call the
 // model multiple times until they arrive at the final answer. That's the job of
@@ -18,5 +21,13 @@
 // responsible for calling the agent, getting back and action and action input,
 // calling the tool that the action references with the corresponding input,
 // getting the output of the tool, and then passing all that information back
-// into the Agent to get the next action it should take.
+// into the Agent to get the next action it should take. Actions can be gated
+// behind human approval with WithApproval, which can also suspend the run until
+// Executor.Resume is called. With a Checkpointer, the executor saves the state
+// of the runs identified with ContextWithRunID after every iteration, and
+// Executor.ResumeRun continues an interrupted run from its last checkpoint.
+//
+// Agents can be composed hierarchically: a ChainTool wraps the executor of a
+// specialist agent, or any chain, as a tool that a supervisor agent can
+// delegate tasks to.
 package agents
@@ -1,6 +1,9 @@
 package agents
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrExecutorInputNotString is returned if an input to the executor call function is not a string.
@@ -20,6 +23,14 @@ var (
 	// ErrInvalidChainReturnType is returned if the internal chain of the agent returns a value in the
 	// "text" filed that is not a string.
 	ErrInvalidChainReturnType = errors.New("agent chain did not return a string")
+	// ErrToolTimeout is returned if a tool call takes longer than the timeout of the tool.
+	ErrToolTimeout = errors.New("tool call timed out")
+	// ErrSuspended is returned, wrapped in a SuspendedError, if an action waits for approval.
+	ErrSuspended = errors.New("agent run suspended")
+	// ErrCheckpointNotFound is returned by checkpointers if a run has no saved state.
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+	// ErrMaxDelegationDepth is returned by a ChainTool if a call would nest too many delegations.
+	ErrMaxDelegationDepth = errors.New("max delegation depth exceeded")
 )
 
 // ParserErrorHandler is the struct used to handle parse errors from the agent in the executor. If
@@ -38,3 +49,42 @@ func NewParserErrorHandler(formatFunc func(string) string) *ParserErrorHandler {
 		Formatter: formatFunc,
 	}
 }
+
+// ToolErrorPolicy decides what the executor does when a tool call fails.
+type ToolErrorPolicy int
+
+const (
+	// ToolErrorAbort stops the run and returns the error of the tool.
+	ToolErrorAbort ToolErrorPolicy = iota
+	// ToolErrorObserve gives the error to the agent as the observation of the action, so that
+	// the agent can try something else.
+	ToolErrorObserve
+)
+
+// ToolErrorHandler is the struct used to handle errors returned by tools in the executor. Failed
+// tool calls are retried up to MaxRetries times, then handled according to the Policy. Without a
+// ToolErrorHandler, the executor aborts on the first tool error.
+type ToolErrorHandler struct {
+	// Policy decides what happens when a tool call still fails after the retries.
+	Policy ToolErrorPolicy
+	// MaxRetries is the number of times a failed tool call is retried.
+	MaxRetries int
+	// The formatter function can be used to format the error given as an observation with the
+	// ToolErrorObserve policy. If nil the observation is "error calling tool <tool>: <err>".
+	Formatter func(tool string, err error) string
+}
+
+// NewToolErrorHandler creates a new tool error handler.
+func NewToolErrorHandler(policy ToolErrorPolicy, maxRetries int) *ToolErrorHandler {
+	return &ToolErrorHandler{
+		Policy:     policy,
+		MaxRetries: maxRetries,
+	}
+}
+
+func (h *ToolErrorHandler) format(tool string, err error) string {
+	if h.Formatter != nil {
+		return h.Formatter(tool, err)
+	}
+	return fmt.Sprintf("error calling tool %s: %s", tool, err)
+}
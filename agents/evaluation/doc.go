@@ -0,0 +1,12 @@
+// Package evaluation runs an agent executor over a dataset of examples and scores its runs, so
+// that changes to prompts, tools or models can be regression-tested.
+//
+// Each example gives the input of the agent, and the tools the agent is expected to call and/or
+// the answer it is expected to give. The trajectory of the run, the tools called in order, is
+// matched exactly, as a subset or as an ordered subsequence of the expected one, and the answer
+// exactly, with a regular expression or by asking a model to judge it. The report also holds the
+// number of steps and the tokens used by each run, and can be written as JSON or JUnit XML.
+//
+// Combined with the models of package `llms/fake`, which replay scripted responses, datasets can
+// be evaluated offline.
+package evaluation
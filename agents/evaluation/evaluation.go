@@ -0,0 +1,184 @@
+package evaluation
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+)
+
+var (
+	// ErrNoJudge is returned when answers are judged without a judge model.
+	ErrNoJudge = errors.New("evaluation: no judge model")
+	// ErrInvalidVerdict is returned when the reply of the judge model is
+	// neither CORRECT nor INCORRECT.
+	ErrInvalidVerdict = errors.New("evaluation: invalid verdict")
+)
+
+// Example is an input of the agent with the expected run.
+type Example struct {
+	// Name identifies the example in the report. Defaults to its position in
+	// the dataset.
+	Name string `json:"name,omitempty"`
+	// Input is the "input" value of the executor.
+	Input string `json:"input"`
+	// Inputs are the other input values of the executor, if any.
+	Inputs map[string]any `json:"inputs,omitempty"`
+	// ExpectedTools are the tools the agent is expected to call. The
+	// trajectory is not scored if nil; an empty list expects no tool calls.
+	ExpectedTools []string `json:"expected_tools,omitempty"`
+	// ExpectedAnswer is the answer the agent is expected to give. The answer
+	// is not checked if empty.
+	ExpectedAnswer string `json:"expected_answer,omitempty"`
+}
+
+// LoadExamples reads a dataset in the JSON Lines format, with one Example per
+// line.
+func LoadExamples(r io.Reader) ([]Example, error) {
+	var examples []Example
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) //nolint:gomnd
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var example Example
+		if err := json.Unmarshal(scanner.Bytes(), &example); err != nil {
+			return nil, fmt.Errorf("evaluation: line %d: %w", line, err)
+		}
+		examples = append(examples, example)
+	}
+	return examples, scanner.Err()
+}
+
+// Evaluator runs an executor over examples and scores the runs.
+type Evaluator struct {
+	executor *agents.Executor
+	opts     options
+}
+
+// New creates an evaluator of the executor. The executor is not modified:
+// the examples are run by a copy with its own memory, see WithMemory, so that
+// they do not depend on each other.
+func New(executor *agents.Executor, opts ...Option) *Evaluator {
+	return &Evaluator{
+		executor: executor,
+		opts:     applyOptions(opts...),
+	}
+}
+
+// Run runs the executor over the examples, one after the other, and returns
+// the report. Failed runs are reported as such; an error is only returned if
+// the context is done.
+func (e *Evaluator) Run(ctx context.Context, examples []Example) (*Report, error) {
+	report := &Report{Name: e.opts.name}
+	start := time.Now()
+	for i, example := range examples {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if example.Name == "" {
+			example.Name = fmt.Sprintf("example %d", i+1)
+		}
+		report.Results = append(report.Results, e.runExample(ctx, example))
+	}
+	report.summarize(time.Since(start))
+	return report, nil
+}
+
+func (e *Evaluator) runExample(ctx context.Context, example Example) Result {
+	result := Result{Name: example.Name, Input: example.Input, Tools: []string{}}
+
+	inputs := make(map[string]any, len(example.Inputs)+1)
+	for key, value := range example.Inputs {
+		inputs[key] = value
+	}
+	inputs["input"] = example.Input
+
+	executor := *e.executor
+	executor.Memory = e.opts.newMemory()
+	if e.opts.usage != nil {
+		e.opts.usage.Reset()
+	}
+
+	ctx, recorder := agents.ContextWithStepsRecorder(ctx)
+	start := time.Now()
+	outputs, err := chains.Call(ctx, &executor, inputs)
+	result.Duration = time.Since(start)
+	if e.opts.usage != nil {
+		result.Usage = e.opts.usage.Usage()
+		result.Cost = float64(result.Usage.PromptTokens)*e.opts.promptPrice +
+			float64(result.Usage.CompletionTokens)*e.opts.completionPrice
+	}
+
+	steps := recorder.Steps()
+	for _, step := range steps {
+		if step.Action.Tool != "" {
+			result.Tools = append(result.Tools, step.Action.Tool)
+		}
+	}
+	result.Steps = len(steps)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Answer = answerText(outputs[e.opts.outputKey])
+
+	e.score(ctx, example, &result)
+	return result
+}
+
+// score scores the run and decides whether the example passed.
+func (e *Evaluator) score(ctx context.Context, example Example, result *Result) {
+	if example.ExpectedTools != nil {
+		score, err := scoreTrajectory(e.opts.trajectoryMatch, example.ExpectedTools, result.Tools)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.TrajectoryScore = &score
+		if score < 1 {
+			result.Failures = append(result.Failures, fmt.Sprintf("%s trajectory match: expected tools %v, called %v",
+				e.opts.trajectoryMatch, example.ExpectedTools, result.Tools))
+		}
+	}
+
+	if example.ExpectedAnswer != "" {
+		correct, reasoning, err := e.checkAnswer(ctx, example.Input, example.ExpectedAnswer, result.Answer)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.AnswerCorrect = &correct
+		result.JudgeReasoning = reasoning
+		if !correct {
+			result.Failures = append(result.Failures, fmt.Sprintf("%s answer match: expected %q, got %q",
+				e.opts.answerMatch, example.ExpectedAnswer, result.Answer))
+		}
+	}
+
+	if e.opts.maxSteps > 0 && result.Steps > e.opts.maxSteps {
+		result.Failures = append(result.Failures, fmt.Sprintf("took %d steps, more than %d",
+			result.Steps, e.opts.maxSteps))
+	}
+	result.Passed = len(result.Failures) == 0
+}
+
+// answerText returns the answer as text, encoding structured answers as JSON.
+func answerText(value any) string {
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
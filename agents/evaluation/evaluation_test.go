@@ -0,0 +1,192 @@
+package evaluation_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/agents/evaluation"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/fake"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func withUsage(resp *llms.ContentResponse, prompt, completion int) *llms.ContentResponse {
+	resp.Usage = &llms.Usage{PromptTokens: prompt, CompletionTokens: completion, TotalTokens: prompt + completion}
+	return resp
+}
+
+const dataset = `{"name": "add", "input": "What is 1 + 2?", "expected_tools": ["calculator"], "expected_answer": "3"}
+
+{"input": "What is 2 * 3?", "expected_tools": ["calculator"], "expected_answer": "6"}
+{"input": "What is 2 - 1?", "expected_answer": "1"}
+`
+
+func TestEvaluator(t *testing.T) {
+	t.Parallel()
+
+	examples, err := evaluation.LoadExamples(strings.NewReader(dataset))
+	require.NoError(t, err)
+	require.Len(t, examples, 3)
+
+	llm := evaluation.NewUsageTracker(fake.New(
+		withUsage(fake.ToolCalls(fake.ToolCall("call_1", "calculator", `{"input":"1 + 2"}`)), 100, 10),
+		withUsage(fake.Text("3"), 120, 5),
+		withUsage(fake.Text("6"), 100, 5),
+	))
+	executor, err := agents.Initialize(llm, []tools.Tool{tools.Calculator{}}, agents.ToolCalling)
+	require.NoError(t, err)
+
+	report, err := evaluation.New(executor,
+		evaluation.WithName("maths"),
+		evaluation.WithUsageTracker(llm),
+		evaluation.WithTokenPrices(0.001, 0.002),
+	).Run(context.Background(), examples)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 3)
+	assert.False(t, executor.ReturnIntermediateSteps)
+
+	add := report.Results[0]
+	assert.True(t, add.Passed, add.Failures)
+	assert.Equal(t, []string{"calculator"}, add.Tools)
+	assert.Equal(t, "3", add.Answer)
+	assert.Equal(t, 1, add.Steps)
+	assert.Equal(t, 235, add.Usage.TotalTokens)
+	assert.InDelta(t, 0.25, add.Cost, 1e-9)
+
+	mul := report.Results[1]
+	assert.Equal(t, "example 2", mul.Name)
+	assert.False(t, mul.Passed)
+	assert.Equal(t, 0.0, *mul.TrajectoryScore)
+	assert.True(t, *mul.AnswerCorrect)
+	require.Len(t, mul.Failures, 1)
+	assert.Contains(t, mul.Failures[0], "exact trajectory match")
+
+	assert.Contains(t, report.Results[2].Error, fake.ErrNoMoreResponses.Error())
+
+	assert.Equal(t, evaluation.Summary{
+		Examples:        3,
+		Passed:          1,
+		Failed:          2,
+		Errors:          1,
+		PassRate:        1.0 / 3,
+		TrajectoryScore: 0.5,
+		AnswerAccuracy:  1,
+		AverageSteps:    1.0 / 3,
+		Usage:           llms.Usage{PromptTokens: 320, CompletionTokens: 20, TotalTokens: 340},
+		Cost:            0.36,
+		Duration:        report.Summary.Duration,
+	}, report.Summary)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteJSON(&buf))
+	var decoded evaluation.Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report.Summary.Passed, decoded.Summary.Passed)
+	assert.Equal(t, "add", decoded.Results[0].Name)
+
+	buf.Reset()
+	require.NoError(t, report.WriteJUnit(&buf))
+	junit := buf.String()
+	assert.Contains(t, junit, `<testsuite name="maths" tests="3" failures="1" errors="1"`)
+	assert.Contains(t, junit, `<failure message="exact trajectory match: expected tools [calculator], called []">`)
+	assert.Contains(t, junit, `<error message="fake: no more responses">`)
+}
+
+func TestEvaluatorWithMemory(t *testing.T) {
+	t.Parallel()
+
+	examples := []evaluation.Example{
+		{Input: "What is 1 + 2?", ExpectedTools: []string{"calculator"}, ExpectedAnswer: "3"},
+		{Input: "What is 2 * 3?", ExpectedTools: []string{"calculator"}, ExpectedAnswer: "6"},
+	}
+	newLLM := func() *fake.LLM {
+		return fake.New(
+			fake.ToolCalls(fake.ToolCall("call_1", "calculator", `{"input":"1 + 2"}`)),
+			fake.Text("3"),
+			fake.ToolCalls(fake.ToolCall("call_2", "calculator", `{"input":"2 * 3"}`)),
+			fake.Text("6"),
+		)
+	}
+
+	for _, opts := range [][]evaluation.Option{
+		nil,
+		{evaluation.WithMemory(func() schema.Memory { return memory.NewConversationBuffer() })},
+	} {
+		history := memory.NewConversationBuffer()
+		executor, err := agents.Initialize(newLLM(), []tools.Tool{tools.Calculator{}}, agents.ToolCalling,
+			agents.WithMemory(history))
+		require.NoError(t, err)
+
+		report, err := evaluation.New(executor, opts...).Run(context.Background(), examples)
+		require.NoError(t, err)
+		for _, result := range report.Results {
+			assert.True(t, result.Passed, result.Error, result.Failures)
+			assert.Equal(t, []string{"calculator"}, result.Tools)
+			assert.Equal(t, 1, result.Steps)
+		}
+
+		messages, err := history.ChatHistory.Messages(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	}
+}
+
+func TestEvaluatorMatches(t *testing.T) {
+	t.Parallel()
+
+	run := func(t *testing.T, responses []*llms.ContentResponse, example evaluation.Example, opts ...evaluation.Option) evaluation.Result {
+		t.Helper()
+		executor, err := agents.Initialize(fake.New(responses...), []tools.Tool{tools.Calculator{}}, agents.ToolCalling,
+			agents.WithMaxIterations(10))
+		require.NoError(t, err)
+		report, err := evaluation.New(executor, opts...).Run(context.Background(), []evaluation.Example{example})
+		require.NoError(t, err)
+		return report.Results[0]
+	}
+	// The agent calls the calculator twice, then answers.
+	responses := func() []*llms.ContentResponse {
+		return []*llms.ContentResponse{
+			fake.ToolCalls(fake.ToolCall("call_1", "calculator", `{"input":"1 + 1"}`)),
+			fake.ToolCalls(fake.ToolCall("call_2", "calculator", `{"input":"2 + 2"}`)),
+			fake.Text("The answer is 4."),
+		}
+	}
+	example := evaluation.Example{Input: "foo", ExpectedTools: []string{"calculator", "search"}}
+
+	result := run(t, responses(), example, evaluation.WithTrajectoryMatch(evaluation.TrajectoryOrdered))
+	assert.Equal(t, 0.5, *result.TrajectoryScore)
+	result = run(t, responses(), example, evaluation.WithTrajectoryMatch(evaluation.TrajectorySubset))
+	assert.Equal(t, 0.5, *result.TrajectoryScore)
+
+	example = evaluation.Example{Input: "foo", ExpectedTools: []string{"calculator"}, ExpectedAnswer: `\b4\b`}
+	result = run(t, responses(), example,
+		evaluation.WithTrajectoryMatch(evaluation.TrajectorySubset),
+		evaluation.WithAnswerMatch(evaluation.AnswerRegex))
+	assert.True(t, result.Passed, result.Failures)
+
+	result = run(t, responses(), example,
+		evaluation.WithTrajectoryMatch(evaluation.TrajectorySubset),
+		evaluation.WithAnswerMatch(evaluation.AnswerRegex),
+		evaluation.WithMaxSteps(1))
+	assert.Equal(t, []string{"took 2 steps, more than 1"}, result.Failures)
+
+	example.ExpectedAnswer = "4"
+	judge := fake.New(fake.Text("CORRECT\nBoth answers are 4."))
+	result = run(t, responses(), example,
+		evaluation.WithTrajectoryMatch(evaluation.TrajectorySubset),
+		evaluation.WithJudge(judge))
+	assert.True(t, result.Passed, result.Failures)
+	assert.Equal(t, "Both answers are 4.", result.JudgeReasoning)
+	assert.Contains(t, judge.Calls()[0][0].Parts[0].(llms.TextContent).Text, "Answer: The answer is 4.")
+
+	result = run(t, responses(), example, evaluation.WithJudge(fake.New(fake.Text("Maybe"))))
+	assert.Contains(t, result.Error, evaluation.ErrInvalidVerdict.Error())
+}
@@ -0,0 +1,108 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// scoreTrajectory returns the share of the expected tools matched by the
+// tools called, from 0 to 1.
+func scoreTrajectory(match TrajectoryMatch, expected, called []string) (float64, error) {
+	switch match {
+	case TrajectoryExact:
+		if len(expected) != len(called) {
+			return 0, nil
+		}
+		for i := range expected {
+			if !strings.EqualFold(expected[i], called[i]) {
+				return 0, nil
+			}
+		}
+		return 1, nil
+	case TrajectorySubset:
+		if len(expected) == 0 {
+			return 1, nil
+		}
+		remaining := make(map[string]int, len(called))
+		for _, tool := range called {
+			remaining[strings.ToLower(tool)]++
+		}
+		matched := 0
+		for _, tool := range expected {
+			if remaining[strings.ToLower(tool)] > 0 {
+				remaining[strings.ToLower(tool)]--
+				matched++
+			}
+		}
+		return float64(matched) / float64(len(expected)), nil
+	case TrajectoryOrdered:
+		if len(expected) == 0 {
+			return 1, nil
+		}
+		matched := 0
+		for _, tool := range called {
+			if matched < len(expected) && strings.EqualFold(tool, expected[matched]) {
+				matched++
+			}
+		}
+		return float64(matched) / float64(len(expected)), nil
+	default:
+		return 0, fmt.Errorf("evaluation: unknown trajectory match %q", match)
+	}
+}
+
+const _judgePrompt = `You are grading the answer of an assistant to a question, given the expected answer.
+
+Question: %s
+
+Expected answer: %s
+
+Answer: %s
+
+The answer does not need to be worded like the expected answer, but it must give the same information. Reply with CORRECT or INCORRECT on the first line, followed by a short explanation.`
+
+// checkAnswer reports whether the answer matches the expected one, with the
+// reasoning of the judge if there is one.
+func (e *Evaluator) checkAnswer(ctx context.Context, input, expected, answer string) (bool, string, error) {
+	switch e.opts.answerMatch {
+	case AnswerExact:
+		return strings.TrimSpace(answer) == strings.TrimSpace(expected), "", nil
+	case AnswerRegex:
+		re, err := regexp.Compile(expected)
+		if err != nil {
+			return false, "", fmt.Errorf("evaluation: invalid expected answer: %w", err)
+		}
+		return re.MatchString(answer), "", nil
+	case AnswerJudge:
+		if e.opts.judge == nil {
+			return false, "", ErrNoJudge
+		}
+		verdict, err := llms.GenerateFromSinglePrompt(ctx, e.opts.judge,
+			fmt.Sprintf(_judgePrompt, input, expected, answer), llms.WithTemperature(0))
+		if err != nil {
+			return false, "", fmt.Errorf("evaluation: judge: %w", err)
+		}
+		return parseVerdict(verdict)
+	default:
+		return false, "", fmt.Errorf("evaluation: unknown answer match %q", e.opts.answerMatch)
+	}
+}
+
+// parseVerdict parses the reply of the judge.
+func parseVerdict(verdict string) (bool, string, error) {
+	verdict = strings.TrimSpace(verdict)
+	first, reasoning, _ := strings.Cut(verdict, "\n")
+	first = strings.ToUpper(strings.Trim(first, " *.:"))
+	switch {
+	case strings.HasPrefix(first, "INCORRECT"):
+		return false, strings.TrimSpace(reasoning), nil
+	case strings.HasPrefix(first, "CORRECT"):
+		return true, strings.TrimSpace(reasoning), nil
+	default:
+		return false, "", fmt.Errorf("%w: %q", ErrInvalidVerdict, verdict)
+	}
+}
@@ -0,0 +1,143 @@
+package evaluation
+
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// TrajectoryMatch is the way the tools called by the agent are compared with
+// the expected ones.
+type TrajectoryMatch string
+
+const (
+	// TrajectoryExact requires the agent to call the expected tools, in the
+	// same order, and no others.
+	TrajectoryExact TrajectoryMatch = "exact"
+	// TrajectorySubset requires the agent to call all the expected tools, in
+	// any order, possibly with others.
+	TrajectorySubset TrajectoryMatch = "subset"
+	// TrajectoryOrdered requires the agent to call all the expected tools in
+	// the same order, possibly with others in between.
+	TrajectoryOrdered TrajectoryMatch = "ordered"
+)
+
+// AnswerMatch is the way the answer of the agent is compared with the
+// expected one.
+type AnswerMatch string
+
+const (
+	// AnswerExact requires the answer to equal the expected one, ignoring
+	// leading and trailing white space.
+	AnswerExact AnswerMatch = "exact"
+	// AnswerRegex requires the answer to match the expected answer as a
+	// regular expression.
+	AnswerRegex AnswerMatch = "regex"
+	// AnswerJudge asks a model whether the answer is correct given the
+	// expected one. See WithJudge.
+	AnswerJudge AnswerMatch = "judge"
+)
+
+// Option is a functional argument that configures the Evaluator.
+type Option func(*options)
+
+type options struct {
+	name            string
+	outputKey       string
+	trajectoryMatch TrajectoryMatch
+	answerMatch     AnswerMatch
+	judge           llms.Model
+	maxSteps        int
+	usage           *UsageTracker
+	promptPrice     float64
+	completionPrice float64
+	newMemory       func() schema.Memory
+}
+
+// WithName sets the name of the report. Defaults to "agent evaluation".
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithOutputKey sets the output key of the executor holding the answer.
+// Defaults to "output".
+func WithOutputKey(key string) Option {
+	return func(o *options) {
+		o.outputKey = key
+	}
+}
+
+// WithTrajectoryMatch sets the way the tools called by the agent are
+// compared with the expected ones. Defaults to TrajectoryExact.
+func WithTrajectoryMatch(match TrajectoryMatch) Option {
+	return func(o *options) {
+		o.trajectoryMatch = match
+	}
+}
+
+// WithAnswerMatch sets the way the answer of the agent is compared with the
+// expected one. Defaults to AnswerExact.
+func WithAnswerMatch(match AnswerMatch) Option {
+	return func(o *options) {
+		o.answerMatch = match
+	}
+}
+
+// WithJudge sets the model judging the answers, and compares the answers
+// with AnswerJudge.
+func WithJudge(llm llms.Model) Option {
+	return func(o *options) {
+		o.judge = llm
+		o.answerMatch = AnswerJudge
+	}
+}
+
+// WithMaxSteps fails the runs taking more steps than n. Steps are not
+// limited by default.
+func WithMaxSteps(n int) Option {
+	return func(o *options) {
+		o.maxSteps = n
+	}
+}
+
+// WithUsageTracker reports the tokens used by each run, as tracked by the
+// tracker wrapping the model of the agent.
+func WithUsageTracker(tracker *UsageTracker) Option {
+	return func(o *options) {
+		o.usage = tracker
+	}
+}
+
+// WithTokenPrices sets the prices of a prompt token and of a completion token,
+// used to report the cost of each run. Requires WithUsageTracker.
+func WithTokenPrices(prompt, completion float64) Option {
+	return func(o *options) {
+		o.promptPrice = prompt
+		o.completionPrice = completion
+	}
+}
+
+// WithMemory sets the function creating the memory of each example, for
+// instance a function returning memory.NewConversationBuffer() for an agent
+// whose prompt needs a history. The examples are run without memory by default.
+func WithMemory(newMemory func() schema.Memory) Option {
+	return func(o *options) {
+		o.newMemory = newMemory
+	}
+}
+
+func applyOptions(opts ...Option) options {
+	o := options{
+		name:            "agent evaluation",
+		outputKey:       "output",
+		trajectoryMatch: TrajectoryExact,
+		answerMatch:     AnswerExact,
+		newMemory:       func() schema.Memory { return memory.NewSimple() },
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,203 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Result is the result of the run of an example.
+type Result struct {
+	Name  string `json:"name"`
+	Input string `json:"input"`
+	// Passed is true if the run did not fail and matched the expectations.
+	Passed bool `json:"passed"`
+	// Failures describe the expectations the run did not match.
+	Failures []string `json:"failures,omitempty"`
+	// Error is the error of the run, or of its scoring.
+	Error string `json:"error,omitempty"`
+	// Tools are the tools called by the agent, in order.
+	Tools []string `json:"tools"`
+	// TrajectoryScore is the share of the expected tools matched by the
+	// tools called, from 0 to 1, if the trajectory is scored.
+	TrajectoryScore *float64 `json:"trajectory_score,omitempty"`
+	// Answer is the answer of the agent.
+	Answer string `json:"answer"`
+	// AnswerCorrect reports whether the answer matches the expected one, if
+	// it is checked.
+	AnswerCorrect *bool `json:"answer_correct,omitempty"`
+	// JudgeReasoning is the explanation of the judge model, if any.
+	JudgeReasoning string `json:"judge_reasoning,omitempty"`
+	// Steps is the number of intermediate steps of the run.
+	Steps int `json:"steps"`
+	// Usage is the token usage of the run, if tracked.
+	Usage llms.Usage `json:"usage"`
+	// Cost is the cost of the tokens used, if prices are given.
+	Cost     float64       `json:"cost,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Summary aggregates the results of a report.
+type Summary struct {
+	Examples int `json:"examples"`
+	Passed   int `json:"passed"`
+	Failed   int `json:"failed"`
+	// Errors is the number of runs that failed with an error.
+	Errors   int     `json:"errors"`
+	PassRate float64 `json:"pass_rate"`
+	// TrajectoryScore is the average score of the scored trajectories.
+	TrajectoryScore float64 `json:"trajectory_score"`
+	// AnswerAccuracy is the share of correct answers among the checked ones.
+	AnswerAccuracy float64       `json:"answer_accuracy"`
+	AverageSteps   float64       `json:"average_steps"`
+	Usage          llms.Usage    `json:"usage"`
+	Cost           float64       `json:"cost,omitempty"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// Report is the result of an evaluation.
+type Report struct {
+	Name    string   `json:"name"`
+	Summary Summary  `json:"summary"`
+	Results []Result `json:"results"`
+}
+
+func (r *Report) summarize(duration time.Duration) {
+	s := Summary{Examples: len(r.Results), Duration: duration}
+	var trajectories, answers, correct, steps int
+	for _, result := range r.Results {
+		switch {
+		case result.Error != "":
+			s.Errors++
+			s.Failed++
+		case result.Passed:
+			s.Passed++
+		default:
+			s.Failed++
+		}
+		if result.TrajectoryScore != nil {
+			trajectories++
+			s.TrajectoryScore += *result.TrajectoryScore
+		}
+		if result.AnswerCorrect != nil {
+			answers++
+			if *result.AnswerCorrect {
+				correct++
+			}
+		}
+		steps += result.Steps
+		s.Usage.PromptTokens += result.Usage.PromptTokens
+		s.Usage.CompletionTokens += result.Usage.CompletionTokens
+		s.Usage.TotalTokens += result.Usage.TotalTokens
+		s.Usage.CachedTokens += result.Usage.CachedTokens
+		s.Usage.ReasoningTokens += result.Usage.ReasoningTokens
+		s.Cost += result.Cost
+	}
+	if s.Examples > 0 {
+		s.PassRate = float64(s.Passed) / float64(s.Examples)
+		s.AverageSteps = float64(steps) / float64(s.Examples)
+	}
+	if trajectories > 0 {
+		s.TrajectoryScore /= float64(trajectories)
+	}
+	if answers > 0 {
+		s.AnswerAccuracy = float64(correct) / float64(answers)
+	}
+	r.Summary = s
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report in the JUnit XML format understood by CI
+// systems, with a test case per example.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:     r.Name,
+		Tests:    r.Summary.Examples,
+		Failures: r.Summary.Failed - r.Summary.Errors,
+		Errors:   r.Summary.Errors,
+		Time:     seconds(r.Summary.Duration),
+	}
+	for _, result := range r.Results {
+		tc := junitTestCase{
+			Name:      result.Name,
+			ClassName: r.Name,
+			Time:      seconds(result.Duration),
+			SystemOut: fmt.Sprintf("tools: %s\nanswer: %s\nsteps: %d\ntokens: %d",
+				strings.Join(result.Tools, ", "), result.Answer, result.Steps, result.Usage.TotalTokens),
+		}
+		switch {
+		case result.Error != "":
+			tc.Error = &junitProblem{Message: result.Error, Text: result.Error}
+		case !result.Passed:
+			tc.Failure = &junitProblem{Message: result.Failures[0], Text: strings.Join(result.Failures, "\n")}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	suites := junitTestSuites{
+		Name:     r.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,65 @@
+package evaluation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// UsageTracker is an LLM wrapper adding up the token usage of the responses
+// of the model.
+type UsageTracker struct {
+	llm llms.Model
+
+	mu    sync.Mutex
+	usage llms.Usage
+}
+
+// assert that `UsageTracker` implements the `llms.Model` interface.
+var _ llms.Model = (*UsageTracker)(nil)
+
+// NewUsageTracker wraps a model and tracks its token usage.
+func NewUsageTracker(llm llms.Model) *UsageTracker {
+	return &UsageTracker{llm: llm}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (t *UsageTracker) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, t, prompt, options...)
+}
+
+// GenerateContent calls the model and adds the usage of the response.
+func (t *UsageTracker) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	resp, err := t.llm.GenerateContent(ctx, messages, options...)
+	if resp != nil && resp.Usage != nil {
+		t.mu.Lock()
+		t.usage.PromptTokens += resp.Usage.PromptTokens
+		t.usage.CompletionTokens += resp.Usage.CompletionTokens
+		t.usage.TotalTokens += resp.Usage.TotalTokens
+		t.usage.CachedTokens += resp.Usage.CachedTokens
+		t.usage.ReasoningTokens += resp.Usage.ReasoningTokens
+		t.mu.Unlock()
+	}
+	return resp, err
+}
+
+// Usage returns the usage added up since the tracker was created or reset.
+func (t *UsageTracker) Usage() llms.Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.usage
+}
+
+// Reset sets the usage back to zero.
+func (t *UsageTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.usage = llms.Usage{}
+}
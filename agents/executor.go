@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
@@ -23,6 +25,35 @@ type Executor struct {
 
 	MaxIterations           int
 	ReturnIntermediateSteps bool
+
+	// MaxConcurrency is the maximum number of tools called at the same time
+	// when the agent plans several actions at once. Actions are run one after
+	// the other if it is less than 2. Otherwise, the CallbacksHandler is
+	// called from the goroutines of the tool calls, and must be safe for
+	// concurrent use.
+	MaxConcurrency int
+	// ToolConcurrency limits the number of concurrent calls of the tools
+	// named by its keys, regardless of MaxConcurrency.
+	ToolConcurrency map[string]int
+
+	// ToolErrorHandler decides how failed tool calls are handled. If nil, the
+	// first tool error stops the run.
+	ToolErrorHandler *ToolErrorHandler
+	// ToolTimeout is the maximum duration of a tool call, unless the tool has
+	// its own timeout in ToolTimeouts. Tool calls are not limited if zero.
+	ToolTimeout time.Duration
+	// ToolTimeouts holds the timeouts of the tools named by its keys.
+	ToolTimeouts map[string]time.Duration
+
+	// Approval is consulted before the actions needing approval are executed.
+	Approval ApprovalFunc
+	// ApprovalTools are the names of the tools whose actions need approval.
+	// All the actions need approval if it is empty.
+	ApprovalTools []string
+
+	// Checkpointer saves the state of the runs with a run ID, see
+	// ContextWithRunID, after every iteration and when they fail.
+	Checkpointer Checkpointer
 }
 
 var (
@@ -44,6 +75,14 @@ func NewExecutor(agent Agent, opts ...Option) *Executor {
 		ReturnIntermediateSteps: options.returnIntermediateSteps,
 		CallbacksHandler:        options.callbacksHandler,
 		ErrorHandler:            options.errorHandler,
+		MaxConcurrency:          options.maxConcurrency,
+		ToolConcurrency:         options.toolConcurrency,
+		ToolErrorHandler:        options.toolErrorHandler,
+		ToolTimeout:             options.toolTimeout,
+		ToolTimeouts:            options.toolTimeouts,
+		Approval:                options.approval,
+		ApprovalTools:           options.approvalTools,
+		Checkpointer:            options.checkpointer,
 	}
 }
 
@@ -52,14 +91,86 @@ func (e *Executor) Call(ctx context.Context, inputValues map[string]any, _ ...ch
 	if err != nil {
 		return nil, err
 	}
+	return e.run(ctx, &RunState{Inputs: inputs})
+}
+
+// Resume continues a run suspended by the approval function, from the state
+// of the SuspendedError. The pending actions are executed first, using the
+// decisions in the approvals of the state. Unlike chains.Call, Resume does not
+// use the memory of the executor.
+func (e *Executor) Resume(ctx context.Context, state *RunState) (map[string]any, error) {
+	return e.run(ctx, state)
+}
+
+// ResumeRun continues the run from the state saved by the checkpointer of the
+// executor. The steps taken before the checkpoint are replayed to the agent
+// without calling their tools again. Checkpoints are deleted once their run
+// finishes, so only interrupted, failed and suspended runs can be resumed.
+func (e *Executor) ResumeRun(ctx context.Context, runID string) (map[string]any, error) {
+	if e.Checkpointer == nil {
+		return nil, fmt.Errorf("%w: no checkpointer", ErrInvalidOptions)
+	}
+	state, err := e.Checkpointer.Load(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return e.run(ContextWithRunID(ctx, runID), state)
+}
+
+// run runs the agent from the state. An executor returning its intermediate
+// steps also returns those of the sub-agents it calls through ChainTools,
+// unless it is itself a sub-agent.
+func (e *Executor) run(ctx context.Context, state *RunState) (map[string]any, error) {
+	if !e.ReturnIntermediateSteps || delegationRecorderFromContext(ctx) != nil {
+		return e.runSteps(ctx, state)
+	}
+
+	recorder := &delegationRecorder{}
+	outputs, err := e.runSteps(context.WithValue(ctx, delegationRecorderKey{}, recorder), state)
+	if delegated := recorder.delegatedSteps(); len(delegated) > 0 && outputs != nil {
+		outputs[DelegatedStepsOutputKey] = delegated
+	}
+	return outputs, err
+}
+
+func (e *Executor) runSteps(ctx context.Context, state *RunState) (map[string]any, error) {
+	// The steps recorder of the context is for this run only, not for the
+	// executors called by its tools.
+	recorder := stepsRecorderFromContext(ctx)
+	if recorder != nil {
+		ctx = context.WithValue(ctx, stepsRecorderKey{}, (*StepsRecorder)(nil))
+	}
+
 	nameToTool := getNameToTool(e.Agent.GetTools())
+	steps := append(make([]schema.AgentStep, 0, len(state.Steps)), state.Steps...)
+	recorder.record(steps)
+
+	i := state.Iterations
+	var err error
+	if len(state.Pending) > 0 {
+		steps, err = e.doActions(ctx, steps, nameToTool, state.Pending, state.Approvals)
+		recorder.record(steps)
+		if err != nil {
+			return e.fail(ctx, state, steps, i, err)
+		}
+		i++
+		if err = e.checkpoint(ctx, state.Inputs, steps, i); err != nil {
+			return e.getReturn(&schema.AgentFinish{ReturnValues: make(map[string]any)}, steps), err
+		}
+	}
 
-	steps := make([]schema.AgentStep, 0)
-	for i := 0; i < e.MaxIterations; i++ {
+	for ; i < e.MaxIterations; i++ {
 		var finish map[string]any
-		steps, finish, err = e.doIteration(ctx, steps, nameToTool, inputs)
-		if finish != nil || err != nil {
-			return finish, err
+		steps, finish, err = e.doIteration(ctx, steps, nameToTool, state.Inputs)
+		recorder.record(steps)
+		if err != nil {
+			return e.fail(ctx, state, steps, i, err)
+		}
+		if finish != nil {
+			return finish, e.deleteCheckpoint(ctx)
+		}
+		if err = e.checkpoint(ctx, state.Inputs, steps, i+1); err != nil {
+			return e.getReturn(&schema.AgentFinish{ReturnValues: make(map[string]any)}, steps), err
 		}
 	}
 
@@ -74,6 +185,103 @@ func (e *Executor) Call(ctx context.Context, inputValues map[string]any, _ ...ch
 	), ErrNotFinished
 }
 
+type stepsRecorderKey struct{}
+
+// StepsRecorder collects the intermediate steps of an executor run, whether
+// or not the executor returns them. See ContextWithStepsRecorder.
+type StepsRecorder struct {
+	steps []schema.AgentStep
+}
+
+// ContextWithStepsRecorder returns a context recording the intermediate steps
+// of the executor run with it, without adding them to the outputs of the
+// executor. The steps of the executors called by its tools are not recorded.
+func ContextWithStepsRecorder(ctx context.Context) (context.Context, *StepsRecorder) {
+	recorder := &StepsRecorder{}
+	return context.WithValue(ctx, stepsRecorderKey{}, recorder), recorder
+}
+
+// Steps returns the steps recorded so far, or nil if no executor was run.
+func (r *StepsRecorder) Steps() []schema.AgentStep {
+	if r == nil {
+		return nil
+	}
+	return r.steps
+}
+
+func (r *StepsRecorder) record(steps []schema.AgentStep) {
+	if r != nil {
+		r.steps = steps
+	}
+}
+
+func stepsRecorderFromContext(ctx context.Context) *StepsRecorder {
+	recorder, _ := ctx.Value(stepsRecorderKey{}).(*StepsRecorder)
+	return recorder
+}
+
+// checkpoint saves the state of the run after the iterations, if the run has
+// an ID and the executor a checkpointer.
+func (e *Executor) checkpoint(
+	ctx context.Context,
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	iterations int,
+) error {
+	runID, ok := RunIDFromContext(ctx)
+	if !ok || e.Checkpointer == nil {
+		return nil
+	}
+	state := &RunState{Inputs: inputs, Steps: steps, Iterations: iterations}
+	if err := e.Checkpointer.Save(ctx, runID, state); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (e *Executor) deleteCheckpoint(ctx context.Context) error {
+	runID, ok := RunIDFromContext(ctx)
+	if !ok || e.Checkpointer == nil {
+		return nil
+	}
+	if err := e.Checkpointer.Delete(ctx, runID); err != nil {
+		return fmt.Errorf("delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+// fail returns the error of the iteration, with the steps taken so far so
+// that a failed run can be inspected. The state of suspended runs is saved to
+// the checkpointer. For other failures, the steps taken so far are saved,
+// including those completed by the failed iteration, so that resuming the run
+// does not call their tools again.
+func (e *Executor) fail(
+	ctx context.Context,
+	state *RunState,
+	steps []schema.AgentStep,
+	iteration int,
+	err error,
+) (map[string]any, error) {
+	var suspended *SuspendedError
+	if errors.As(err, &suspended) {
+		suspended.State.Inputs = state.Inputs
+		suspended.State.Iterations = iteration
+		if runID, ok := RunIDFromContext(ctx); ok && e.Checkpointer != nil {
+			if saveErr := e.Checkpointer.Save(ctx, runID, suspended.State); saveErr != nil {
+				err = fmt.Errorf("save checkpoint: %w: %w", saveErr, err)
+			}
+		}
+	} else {
+		// The run may have failed because its context was canceled, which
+		// must not prevent saving its state.
+		saveErr := e.checkpoint(context.WithoutCancel(ctx), state.Inputs, steps, iteration)
+		if saveErr != nil {
+			err = fmt.Errorf("%w: %w", saveErr, err)
+		}
+	}
+	return e.getReturn(&schema.AgentFinish{ReturnValues: make(map[string]any)}, steps), err
+}
+
 func (e *Executor) doIteration( // nolint
 	ctx context.Context,
 	steps []schema.AgentStep,
@@ -106,43 +314,320 @@ func (e *Executor) doIteration( // nolint
 		return steps, e.getReturn(finish, steps), nil
 	}
 
-	for _, action := range actions {
-		steps, err = e.doAction(ctx, steps, nameToTool, action)
+	steps, err = e.doActions(ctx, steps, nameToTool, actions, nil)
+	return steps, nil, err
+}
+
+// doActions executes the approved actions and appends the steps, in the order
+// of the actions. The decisions in approvals are used for the actions needing
+// approval; the approval function is consulted for the others.
+func (e *Executor) doActions(
+	ctx context.Context,
+	steps []schema.AgentStep,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+	approvals []*Approval,
+) ([]schema.AgentStep, error) {
+	actions = append([]schema.AgentAction(nil), actions...)
+	run, results, err := e.reviewActions(ctx, steps, actions, approvals)
+	if err != nil {
+		return steps, err
+	}
+
+	if e.MaxConcurrency > 1 && len(run) > 1 {
+		done, err := e.callToolsConcurrently(ctx, nameToTool, actions, run, results)
 		if err != nil {
-			return steps, nil, err
+			// Keep the steps of the rejected actions and of the completed
+			// calls.
+			for i, step := range results {
+				if done[i] {
+					steps = append(steps, step)
+				}
+			}
+			return steps, err
 		}
+		return append(steps, results...), nil
 	}
 
-	return steps, nil, nil
+	for _, i := range run {
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleAgentAction(ctx, actions[i])
+		}
+		step, err := e.callTool(ctx, nameToTool, actions[i])
+		if err != nil {
+			// Keep the steps of the actions before the failed one.
+			return append(steps, results[:i]...), err
+		}
+		results[i] = step
+	}
+	return append(steps, results...), nil
 }
 
-func (e *Executor) doAction(
+// reviewActions submits the actions needing approval to the approval function,
+// unless approvals holds their decision. It edits the actions in place, and
+// returns the indexes of the actions to run and the steps of the rejected ones.
+func (e *Executor) reviewActions(
 	ctx context.Context,
 	steps []schema.AgentStep,
+	actions []schema.AgentAction,
+	approvals []*Approval,
+) ([]int, []schema.AgentStep, error) {
+	results := make([]schema.AgentStep, len(actions))
+	decisions := make([]*Approval, len(actions))
+	run := make([]int, 0, len(actions))
+	for i, action := range actions {
+		if !e.needsApproval(action.Tool) {
+			run = append(run, i)
+			continue
+		}
+
+		var approval Approval
+		if i < len(approvals) && approvals[i] != nil {
+			approval = *approvals[i]
+		} else {
+			var err error
+			approval, err = e.Approval(ctx, action)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		switch approval.Decision {
+		case ActionApproved:
+			run = append(run, i)
+		case ActionRejected:
+			feedback := approval.Feedback
+			if feedback == "" {
+				feedback = _defaultRejectionFeedback
+			}
+			results[i] = schema.AgentStep{Action: action, Observation: feedback}
+		case ActionEdited:
+			actions[i].ToolInput = approval.ToolInput
+			run = append(run, i)
+		case ActionSuspended:
+			return nil, nil, &SuspendedError{
+				State: &RunState{
+					Steps:     steps,
+					Pending:   actions,
+					Approvals: decisions[:i],
+				},
+				Action: action,
+			}
+		default:
+			return nil, nil, fmt.Errorf("invalid approval decision %d for action %s", approval.Decision, action.Tool)
+		}
+		decisions[i] = &approval
+	}
+	return run, results, nil
+}
+
+func (e *Executor) needsApproval(toolName string) bool {
+	if e.Approval == nil {
+		return false
+	}
+	if len(e.ApprovalTools) == 0 {
+		return true
+	}
+	for _, name := range e.ApprovalTools {
+		if strings.EqualFold(name, toolName) {
+			return true
+		}
+	}
+	return false
+}
+
+// callToolsConcurrently calls the tools of the actions at the indexes in run
+// concurrently, within the limits of MaxConcurrency and ToolConcurrency, and
+// stores the steps in results at the same indexes, so that each observation
+// follows its action whatever the order in which the tools return. The first
+// error cancels the calls in progress. It returns which of the results are
+// set: those of the completed calls, and those of the actions not in run.
+func (e *Executor) callToolsConcurrently(
+	ctx context.Context,
 	nameToTool map[string]tools.Tool,
-	action schema.AgentAction,
-) ([]schema.AgentStep, error) {
-	if e.CallbacksHandler != nil {
-		e.CallbacksHandler.HandleAgentAction(ctx, action)
+	actions []schema.AgentAction,
+	run []int,
+	results []schema.AgentStep,
+) ([]bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make([]bool, len(actions))
+	for i := range done {
+		done[i] = true
+	}
+	for _, i := range run {
+		done[i] = false
 	}
 
+	workers := make(chan struct{}, e.MaxConcurrency)
+	toolSlots := make(map[string]chan struct{}, len(e.ToolConcurrency))
+	for name, limit := range e.ToolConcurrency {
+		if limit > 0 {
+			toolSlots[strings.ToUpper(name)] = make(chan struct{}, limit)
+		}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for _, i := range run {
+		action := actions[i]
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleAgentAction(ctx, action)
+		}
+
+		wg.Add(1)
+		go func(i int, action schema.AgentAction) {
+			defer wg.Done()
+
+			release, err := acquire(ctx, toolSlots[strings.ToUpper(action.Tool)], workers)
+			if err == nil {
+				var step schema.AgentStep
+				step, err = e.callTool(ctx, nameToTool, action)
+				release()
+				if err == nil {
+					results[i], done[i] = step, true
+				}
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(i, action)
+	}
+	wg.Wait()
+
+	return done, firstErr
+}
+
+// acquire takes a slot in each of the semaphores, in order, skipping nil
+// ones. It returns a function releasing them.
+func acquire(ctx context.Context, semaphores ...chan struct{}) (func(), error) {
+	var taken []chan struct{}
+	release := func() {
+		for _, sem := range taken {
+			<-sem
+		}
+	}
+	for _, sem := range semaphores {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			taken = append(taken, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// callTool calls the tool of the action and returns the resulting step. Tool
+// errors are handled according to the ToolErrorHandler.
+func (e *Executor) callTool(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	action schema.AgentAction,
+) (schema.AgentStep, error) {
 	tool, ok := nameToTool[strings.ToUpper(action.Tool)]
 	if !ok {
-		return append(steps, schema.AgentStep{
+		return schema.AgentStep{
 			Action:      action,
 			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-		}), nil
+		}, nil
 	}
 
-	observation, err := tool.Call(ctx, action.ToolInput)
+	observation, err := e.callToolWithRetries(ctx, tool, action.ToolInput)
 	if err != nil {
-		return nil, err
+		h := e.ToolErrorHandler
+		if h == nil || h.Policy != ToolErrorObserve || ctx.Err() != nil {
+			return schema.AgentStep{}, err
+		}
+		observation = h.format(action.Tool, err)
 	}
 
-	return append(steps, schema.AgentStep{
+	return schema.AgentStep{
 		Action:      action,
 		Observation: observation,
-	}), nil
+	}, nil
+}
+
+// callToolWithRetries calls the tool, retrying failed calls as allowed by the
+// ToolErrorHandler.
+func (e *Executor) callToolWithRetries(ctx context.Context, tool tools.Tool, input string) (string, error) {
+	retries := 0
+	if e.ToolErrorHandler != nil {
+		retries = e.ToolErrorHandler.MaxRetries
+	}
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		var observation string
+		observation, err = e.callToolWithTimeout(ctx, tool, input)
+		if err == nil {
+			return observation, nil
+		}
+		// Calls interrupted by the cancellation of the run did not fail.
+		if ctx.Err() != nil {
+			break
+		}
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleToolError(ctx, err)
+		}
+	}
+	return "", err
+}
+
+// callToolWithTimeout calls the tool within its timeout. The call is abandoned
+// when the timeout expires, even if the tool ignores the cancellation of its
+// context.
+func (e *Executor) callToolWithTimeout(ctx context.Context, tool tools.Tool, input string) (string, error) {
+	timeout := e.toolTimeout(tool.Name())
+	if timeout <= 0 {
+		return tool.Call(ctx, input)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		observation string
+		err         error
+	}
+	done := make(chan result, 1)
+	go func() {
+		observation, err := tool.Call(callCtx, input)
+		done <- result{observation: observation, err: err}
+	}()
+
+	var r result
+	select {
+	case r = <-done:
+	case <-callCtx.Done():
+		r.err = callCtx.Err()
+	}
+	if r.err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%w: %s after %s", ErrToolTimeout, tool.Name(), timeout)
+	}
+	return r.observation, r.err
+}
+
+func (e *Executor) toolTimeout(name string) time.Duration {
+	for toolName, timeout := range e.ToolTimeouts {
+		if strings.EqualFold(toolName, name) {
+			return timeout
+		}
+	}
+	return e.ToolTimeout
 }
 
 func (e *Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep) map[string]any {
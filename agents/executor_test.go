@@ -2,12 +2,19 @@ package agents_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/llms/openai"
 	"github.com/tmc/langchaingo/prompts"
@@ -141,3 +148,357 @@ func TestExecutorWithOpenAIFunctionAgent(t *testing.T) {
 	require.True(t, strings.Contains(result, "47") || strings.Contains(result, "49"),
 		"correct answer 47 or 49 not in response")
 }
+
+// concurrencyCounter records the maximum number of concurrent calls.
+type concurrencyCounter struct {
+	mu      sync.Mutex
+	running int
+	max     int
+}
+
+func (c *concurrencyCounter) enter() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running++
+	c.max = max(c.max, c.running)
+}
+
+func (c *concurrencyCounter) exit() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running--
+}
+
+// concurrencyTool records the maximum number of its concurrent calls, and of
+// the concurrent calls of all the tools sharing its total counter. Calls with
+// a lower input take longer, so that they finish in reverse order.
+type concurrencyTool struct {
+	concurrencyCounter
+	name  string
+	total *concurrencyCounter
+}
+
+func (t *concurrencyTool) Name() string        { return t.name }
+func (t *concurrencyTool) Description() string { return "records its concurrent calls" }
+
+func (t *concurrencyTool) Call(ctx context.Context, input string) (string, error) {
+	t.enter()
+	defer t.exit()
+	if t.total != nil {
+		t.total.enter()
+		defer t.total.exit()
+	}
+
+	if input == "fail" {
+		return "", errors.New("tool failed")
+	}
+	n, _ := strconv.Atoi(input)
+	select {
+	case <-time.After(time.Duration(10-n) * 5 * time.Millisecond):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	return t.name + " " + input, nil
+}
+
+// toolsAgent plans the actions once, then finishes.
+type toolsAgent struct {
+	testAgent
+	tools []tools.Tool
+}
+
+func (a *toolsAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(intermediateSteps) > 0 {
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done"}}, nil
+	}
+	return a.testAgent.Plan(ctx, intermediateSteps, inputs)
+}
+
+func (a *toolsAgent) GetTools() []tools.Tool {
+	return a.tools
+}
+
+func TestExecutorParallelActions(t *testing.T) {
+	t.Parallel()
+
+	total := &concurrencyCounter{}
+	search := &concurrencyTool{name: "search", total: total}
+	fetch := &concurrencyTool{name: "fetch", total: total}
+	var actions []schema.AgentAction
+	for i := 0; i < 6; i++ {
+		actions = append(actions,
+			schema.AgentAction{Tool: "search", ToolInput: strconv.Itoa(i), ToolID: fmt.Sprintf("search_%d", i)},
+			schema.AgentAction{Tool: "fetch", ToolInput: strconv.Itoa(i), ToolID: fmt.Sprintf("fetch_%d", i)},
+		)
+	}
+	a := &toolsAgent{testAgent: testAgent{actions: actions}, tools: []tools.Tool{search, fetch}}
+
+	executor := agents.NewExecutor(a,
+		agents.WithMaxConcurrency(4),
+		agents.WithToolConcurrency("fetch", 1),
+		agents.WithReturnIntermediateSteps(),
+	)
+	result, err := chains.Call(context.Background(), executor, nil)
+	require.NoError(t, err)
+
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, len(actions))
+	for i, step := range steps {
+		require.Equal(t, actions[i], step.Action)
+		require.Equal(t, step.Action.Tool+" "+step.Action.ToolInput, step.Observation)
+	}
+	require.Equal(t, 1, fetch.max)
+	require.Equal(t, 4, total.max)
+	require.Greater(t, search.max, 1)
+}
+
+func TestExecutorParallelActionsError(t *testing.T) {
+	t.Parallel()
+
+	search := &concurrencyTool{name: "search"}
+	a := &toolsAgent{
+		testAgent: testAgent{actions: []schema.AgentAction{
+			{Tool: "search", ToolInput: "0"},
+			{Tool: "search", ToolInput: "fail"},
+			{Tool: "search", ToolInput: "1"},
+		}},
+		tools: []tools.Tool{search},
+	}
+
+	executor := agents.NewExecutor(a, agents.WithMaxConcurrency(3))
+	_, err := chains.Call(context.Background(), executor, nil)
+	require.EqualError(t, err, "tool failed")
+}
+
+// orderedTool returns at once when called with "first". Its call with "fail"
+// fails once the call with "first" has returned, and its calls with other
+// inputs wait for the cancellation of the run.
+type orderedTool struct {
+	first chan struct{}
+}
+
+func (t *orderedTool) Name() string        { return "ordered" }
+func (t *orderedTool) Description() string { return "returns in order" }
+
+func (t *orderedTool) Call(ctx context.Context, input string) (string, error) {
+	switch input {
+	case "first":
+		defer close(t.first)
+		return "ordered first", nil
+	case "fail":
+		<-t.first
+		return "", errors.New("tool failed")
+	default:
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+}
+
+func TestExecutorParallelActionsErrorKeepsCompletedSteps(t *testing.T) {
+	t.Parallel()
+
+	a := &toolsAgent{
+		testAgent: testAgent{actions: []schema.AgentAction{
+			{Tool: "ordered", ToolInput: "block"},
+			{Tool: "ordered", ToolInput: "fail"},
+			{Tool: "ordered", ToolInput: "first"},
+		}},
+		tools: []tools.Tool{&orderedTool{first: make(chan struct{})}},
+	}
+
+	executor := agents.NewExecutor(a, agents.WithMaxConcurrency(3), agents.WithReturnIntermediateSteps())
+	result, err := chains.Call(context.Background(), executor, nil)
+	require.EqualError(t, err, "tool failed")
+
+	// the steps of the completed calls are kept, as when the actions are run
+	// one after the other.
+	steps, _ := result["intermediateSteps"].([]schema.AgentStep)
+	require.Len(t, steps, 1)
+	require.Equal(t, "first", steps[0].Action.ToolInput)
+	require.Equal(t, "ordered first", steps[0].Observation)
+}
+
+// flakyTool fails its first calls, and sleeps for the given duration on every
+// call, ignoring the cancellation of its context.
+// not synchronized, don't use concurrently!
+type flakyTool struct {
+	failures int
+	sleep    time.Duration
+	calls    int
+}
+
+func (t *flakyTool) Name() string        { return "flaky" }
+func (t *flakyTool) Description() string { return "fails its first calls" }
+
+func (t *flakyTool) Call(_ context.Context, input string) (string, error) {
+	t.calls++
+	time.Sleep(t.sleep)
+	if t.calls <= t.failures {
+		return "", fmt.Errorf("call %d failed", t.calls)
+	}
+	return "flaky " + input, nil
+}
+
+// toolErrorRecorder records the tool errors it handles.
+type toolErrorRecorder struct {
+	callbacks.SimpleHandler
+	mu   sync.Mutex
+	errs []error
+}
+
+func (h *toolErrorRecorder) HandleToolError(_ context.Context, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.errs = append(h.errs, err)
+}
+
+func TestExecutorToolErrors(t *testing.T) {
+	t.Parallel()
+
+	run := func(tool *flakyTool, opts ...agents.Option) ([]schema.AgentStep, []error, error) {
+		a := &toolsAgent{
+			testAgent: testAgent{actions: []schema.AgentAction{
+				{Tool: "search", ToolInput: "0"},
+				{Tool: "flaky", ToolInput: "1"},
+			}},
+			tools: []tools.Tool{&concurrencyTool{name: "search"}, tool},
+		}
+		handler := &toolErrorRecorder{}
+		opts = append(opts, agents.WithReturnIntermediateSteps(), agents.WithCallbacksHandler(handler))
+		result, err := chains.Call(context.Background(), agents.NewExecutor(a, opts...), nil)
+		steps, _ := result["intermediateSteps"].([]schema.AgentStep)
+		return steps, handler.errs, err
+	}
+
+	t.Run("abort", func(t *testing.T) {
+		t.Parallel()
+		steps, errs, err := run(&flakyTool{failures: 1})
+		require.EqualError(t, err, "call 1 failed")
+		require.Len(t, steps, 1)
+		require.Equal(t, "search 0", steps[0].Observation)
+		require.Len(t, errs, 1)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		t.Parallel()
+		steps, errs, err := run(&flakyTool{failures: 2},
+			agents.WithToolErrorHandler(agents.NewToolErrorHandler(agents.ToolErrorAbort, 2)))
+		require.NoError(t, err)
+		require.Len(t, steps, 2)
+		require.Equal(t, "flaky 1", steps[1].Observation)
+		require.Len(t, errs, 2)
+	})
+
+	t.Run("observe", func(t *testing.T) {
+		t.Parallel()
+		steps, errs, err := run(&flakyTool{failures: 2},
+			agents.WithToolErrorHandler(agents.NewToolErrorHandler(agents.ToolErrorObserve, 1)))
+		require.NoError(t, err)
+		require.Len(t, steps, 2)
+		require.Equal(t, "error calling tool flaky: call 2 failed", steps[1].Observation)
+		require.Len(t, errs, 2)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+		steps, errs, err := run(&flakyTool{sleep: time.Second},
+			agents.WithToolTimeout(time.Minute),
+			agents.WithToolTimeoutFor("flaky", 10*time.Millisecond),
+			agents.WithMaxConcurrency(2))
+		require.ErrorIs(t, err, agents.ErrToolTimeout)
+		require.Empty(t, steps)
+		require.Len(t, errs, 1)
+	})
+}
+
+func TestExecutorApproval(t *testing.T) {
+	t.Parallel()
+
+	a := &toolsAgent{
+		testAgent: testAgent{actions: []schema.AgentAction{
+			{Tool: "search", ToolInput: "0"},
+			{Tool: "delete", ToolInput: "1"},
+			{Tool: "delete", ToolInput: "2"},
+			{Tool: "delete", ToolInput: "3"},
+		}},
+		tools: []tools.Tool{&concurrencyTool{name: "search"}, &concurrencyTool{name: "delete"}},
+	}
+	var reviewed []string
+	approval := func(_ context.Context, action schema.AgentAction) (agents.Approval, error) {
+		reviewed = append(reviewed, action.Tool+" "+action.ToolInput)
+		switch action.ToolInput {
+		case "1":
+			return agents.Reject("not this one"), nil
+		case "2":
+			return agents.EditInput("5"), nil
+		default:
+			return agents.Approve(), nil
+		}
+	}
+
+	executor := agents.NewExecutor(a,
+		agents.WithApproval(approval, "delete"),
+		agents.WithReturnIntermediateSteps(),
+	)
+	result, err := chains.Call(context.Background(), executor, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"delete 1", "delete 2", "delete 3"}, reviewed)
+
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	observations := make([]string, 0, len(steps))
+	for _, step := range steps {
+		observations = append(observations, step.Observation)
+	}
+	require.Equal(t, []string{"search 0", "not this one", "delete 5", "delete 3"}, observations)
+	require.Equal(t, "5", steps[2].Action.ToolInput)
+}
+
+func TestExecutorSuspendAndResume(t *testing.T) {
+	t.Parallel()
+
+	del := &concurrencyTool{name: "delete"}
+	a := &toolsAgent{
+		testAgent: testAgent{actions: []schema.AgentAction{
+			{Tool: "delete", ToolInput: "1"},
+			{Tool: "delete", ToolInput: "2"},
+		}},
+		tools: []tools.Tool{del},
+	}
+	suspend := func(context.Context, schema.AgentAction) (agents.Approval, error) {
+		return agents.Suspend(), nil
+	}
+
+	executor := agents.NewExecutor(a, agents.WithApproval(suspend), agents.WithReturnIntermediateSteps())
+	_, err := chains.Call(context.Background(), executor, map[string]any{"input": "clean up"})
+	require.ErrorIs(t, err, agents.ErrSuspended)
+	var suspended *agents.SuspendedError
+	require.ErrorAs(t, err, &suspended)
+	require.Equal(t, "1", suspended.Action.ToolInput)
+	require.Equal(t, map[string]string{"input": "clean up"}, suspended.State.Inputs)
+	require.Len(t, suspended.State.Pending, 2)
+	require.Zero(t, del.max)
+
+	data, err := json.Marshal(suspended.State)
+	require.NoError(t, err)
+	var state agents.RunState
+	require.NoError(t, json.Unmarshal(data, &state))
+
+	reject, approve := agents.Reject("keep it"), agents.Approve()
+	state.Approvals = []*agents.Approval{&reject, &approve}
+	result, err := executor.Resume(context.Background(), &state)
+	require.NoError(t, err)
+	require.Equal(t, "done", result["output"])
+
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 2)
+	require.Equal(t, "keep it", steps[0].Observation)
+	require.Equal(t, "delete 2", steps[1].Observation)
+	require.Equal(t, 1, del.max)
+}
@@ -0,0 +1,147 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const (
+	_finalAnswerToolName = "final_answer"
+	// _finalAnswerWrapKey is the argument of the final answer tool holding
+	// answers that are not objects, since tool arguments must be objects.
+	_finalAnswerWrapKey = "answer"
+
+	_finalAnswerFormat = "The final answer must be a JSON value matching the following JSON schema, " +
+		"without any other text:\n```json\n%s\n```"
+	_invalidFinalAnswer = "The final answer does not match the JSON schema: %s\n" +
+		"Give the final answer again with the corrected JSON value."
+)
+
+// DecodeOutput decodes the output value of an agent finishing with a
+// structured final answer, see WithFinalAnswerSchema, into a value of type T.
+func DecodeOutput[T any](outputs map[string]any, outputKey string) (T, error) {
+	var result T
+	value, ok := outputs[outputKey]
+	if !ok {
+		return result, fmt.Errorf("no output value %q", outputKey)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("decode output value %q: %w", outputKey, err)
+	}
+	return result, nil
+}
+
+// parseFinalAnswer returns the JSON value in the text, if it matches the
+// schema.
+func parseFinalAnswer(def *jsonschema.Definition, text string) (any, error) {
+	var value any
+	if err := json.Unmarshal([]byte(llms.ExtractJSON(text)), &value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := jsonschema.Validate(def, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// reactFinalAnswer returns the finish of a ReAct agent giving the final
+// answer, or the action calling the final answer tool if the answer does not
+// match the schema, whose observation tells the agent what is wrong.
+func reactFinalAnswer(
+	def *jsonschema.Definition,
+	outputKey, answer, log string,
+) ([]schema.AgentAction, *schema.AgentFinish) {
+	if def == nil {
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{outputKey: answer}, Log: log}
+	}
+	value, err := parseFinalAnswer(def, answer)
+	if err != nil {
+		return []schema.AgentAction{{Tool: _finalAnswerToolName, ToolInput: strings.TrimSpace(answer), Log: log}}, nil
+	}
+	return nil, &schema.AgentFinish{ReturnValues: map[string]any{outputKey: value}, Log: log}
+}
+
+// finalAnswerTool is the tool agents call with an invalid structured final
+// answer, which tells them what is wrong with it. Valid final answers finish
+// the run without calling it.
+type finalAnswerTool struct {
+	def *jsonschema.Definition
+	// wrapped is true if the answer is the "answer" property of def.
+	wrapped bool
+}
+
+var _ tools.SchemaTool = finalAnswerTool{}
+
+// newFinalAnswerTool returns the final answer tool of the schema. Since the
+// arguments of tool calls are objects, other schemas are wrapped in an object
+// with a single "answer" property.
+func newFinalAnswerTool(def *jsonschema.Definition) finalAnswerTool {
+	if def.Type == jsonschema.Object {
+		return finalAnswerTool{def: def}
+	}
+	inner := *def
+	inner.Defs = nil
+	return finalAnswerTool{
+		def: &jsonschema.Definition{
+			Type:                 jsonschema.Object,
+			Properties:           map[string]jsonschema.Definition{_finalAnswerWrapKey: inner},
+			Required:             []string{_finalAnswerWrapKey},
+			AdditionalProperties: false,
+			Defs:                 def.Defs,
+		},
+		wrapped: true,
+	}
+}
+
+func (t finalAnswerTool) Name() string {
+	return _finalAnswerToolName
+}
+
+func (t finalAnswerTool) Description() string {
+	return "Gives the final answer to the user. Call it once you know the answer, instead of answering directly."
+}
+
+func (t finalAnswerTool) Schema() *jsonschema.Definition {
+	return t.def
+}
+
+func (t finalAnswerTool) Call(ctx context.Context, input string) (string, error) {
+	return t.CallArgs(ctx, json.RawMessage(input))
+}
+
+func (t finalAnswerTool) CallArgs(_ context.Context, args json.RawMessage) (string, error) {
+	if _, err := t.parse(string(args)); err != nil {
+		return fmt.Sprintf(_invalidFinalAnswer, err), nil
+	}
+	return "The final answer is valid.", nil
+}
+
+// parse returns the final answer in the arguments, unwrapping it if needed.
+func (t finalAnswerTool) parse(args string) (any, error) {
+	value, err := parseFinalAnswer(t.def, args)
+	if err != nil || !t.wrapped {
+		return value, err
+	}
+	return value.(map[string]any)[_finalAnswerWrapKey], nil //nolint:forcetypeassert
+}
+
+// finalAnswerFormat returns the instructions given to ReAct agents for a
+// structured final answer.
+func finalAnswerFormat(def *jsonschema.Definition) string {
+	schema, err := json.MarshalIndent(def, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(_finalAnswerFormat, schema)
+}
@@ -17,6 +17,12 @@ const (
 	// ConversationalReactDescription is an AgentType constant that represents
 	// the "conversationalReactDescription" agent type.
 	ConversationalReactDescription AgentType = "conversationalReactDescription"
+	// ToolCalling is an AgentType constant that represents the "toolCalling"
+	// agent type, which uses the tool calling API of the LLM.
+	ToolCalling AgentType = "toolCalling"
+	// PlanAndExecute is an AgentType constant that represents the
+	// "planAndExecute" agent type, which plans the steps before executing them.
+	PlanAndExecute AgentType = "planAndExecute"
 )
 
 // Deprecated: This may be removed in the future; please use NewExecutor instead.
@@ -35,6 +41,10 @@ func Initialize(
 		agent = NewOneShotAgent(llm, tools, opts...)
 	case ConversationalReactDescription:
 		agent = NewConversationalAgent(llm, tools, opts...)
+	case ToolCalling:
+		agent = NewToolCallingAgent(llm, tools, opts...)
+	case PlanAndExecute:
+		agent = NewPlanAndExecuteAgent(llm, tools, opts...)
 	default:
 		return &Executor{}, ErrUnknownAgentType
 	}
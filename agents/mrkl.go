@@ -9,6 +9,7 @@ import (
 
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/tools"
@@ -32,6 +33,9 @@ type OneShotZeroAgent struct {
 	Tools []tools.Tool
 	// Output key is the key where the final output is placed.
 	OutputKey string
+	// FinalAnswerSchema is the schema of the final answer, if it must be a
+	// JSON value.
+	FinalAnswerSchema *jsonschema.Definition
 	// CallbacksHandler is the handler for callbacks.
 	CallbacksHandler callbacks.Handler
 }
@@ -53,9 +57,10 @@ func NewOneShotAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *OneSho
 			options.getMrklPrompt(tools),
 			chains.WithCallback(options.callbacksHandler),
 		),
-		Tools:            tools,
-		OutputKey:        options.outputKey,
-		CallbacksHandler: options.callbacksHandler,
+		Tools:             tools,
+		OutputKey:         options.outputKey,
+		FinalAnswerSchema: options.finalAnswerSchema,
+		CallbacksHandler:  options.callbacksHandler,
 	}
 }
 
@@ -115,20 +120,20 @@ func (a *OneShotZeroAgent) GetOutputKeys() []string {
 	return []string{a.OutputKey}
 }
 
+// GetTools returns the tools of the agent, and the tool validating its final
+// answer if it has a schema.
 func (a *OneShotZeroAgent) GetTools() []tools.Tool {
-	return a.Tools
+	if a.FinalAnswerSchema == nil {
+		return a.Tools
+	}
+	return append(a.Tools[:len(a.Tools):len(a.Tools)], finalAnswerTool{def: a.FinalAnswerSchema})
 }
 
 func (a *OneShotZeroAgent) parseOutput(output string) ([]schema.AgentAction, *schema.AgentFinish, error) {
 	if strings.Contains(output, _finalAnswerAction) {
 		splits := strings.Split(output, _finalAnswerAction)
-
-		return nil, &schema.AgentFinish{
-			ReturnValues: map[string]any{
-				a.OutputKey: splits[len(splits)-1],
-			},
-			Log: output,
-		}, nil
+		actions, finish := reactFinalAnswer(a.FinalAnswerSchema, a.OutputKey, splits[len(splits)-1], output)
+		return actions, finish, nil
 	}
 
 	r := regexp.MustCompile(`Action:\s*(.+)\s*Action Input:\s(?s)*(.+)`)
@@ -54,13 +54,7 @@ func (o *OpenAIFunctionsAgent) functions() []llms.FunctionDefinition {
 		res = append(res, llms.FunctionDefinition{
 			Name:        tool.Name(),
 			Description: tool.Description(),
-			Parameters: map[string]any{
-				"properties": map[string]any{
-					"__arg1": map[string]string{"title": "__arg1", "type": "string"},
-				},
-				"required": []string{"__arg1"},
-				"type":     "object",
-			},
+			Parameters:  toolParameters(tool),
 		})
 	}
 	return res
@@ -208,20 +202,11 @@ func (o *OpenAIFunctionsAgent) ParseOutput(contentResp *llms.ContentResponse) (
 	functionCall := choice.FuncCall
 	functionName := functionCall.Name
 	toolInputStr := functionCall.Arguments
-	toolInputMap := make(map[string]any, 0)
-	err := json.Unmarshal([]byte(toolInputStr), &toolInputMap)
+	toolInput, err := o.toolInput(functionName, toolInputStr)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	toolInput := toolInputStr
-	if arg1, ok := toolInputMap["__arg1"]; ok {
-		toolInputCheck, ok := arg1.(string)
-		if ok {
-			toolInput = toolInputCheck
-		}
-	}
-
 	contentMsg := "\n"
 	if choice.Content != "" {
 		contentMsg = fmt.Sprintf("responded: %s\n", choice.Content)
@@ -236,3 +221,37 @@ func (o *OpenAIFunctionsAgent) ParseOutput(contentResp *llms.ContentResponse) (
 		},
 	}, nil, nil
 }
+
+// toolInput returns the input of the tool called with the JSON arguments: the
+// arguments themselves for schema tools, and the "input" argument for string
+// tools.
+func (o *OpenAIFunctionsAgent) toolInput(name, arguments string) (string, error) {
+	for _, tool := range o.Tools {
+		if _, ok := tool.(tools.SchemaTool); ok && tool.Name() == name {
+			return arguments, nil
+		}
+	}
+
+	var args struct {
+		Input *string `json:"input"`
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", err
+	}
+	if args.Input == nil {
+		return arguments, nil
+	}
+	return *args.Input, nil
+}
+
+// toolParameters returns the JSON schema of the arguments of tool as a map,
+// the form expected by all the backends.
+func toolParameters(tool tools.Tool) map[string]any {
+	data, err := json.Marshal(tools.AsSchemaTool(tool).Schema())
+	if err != nil {
+		return nil
+	}
+	var params map[string]any
+	_ = json.Unmarshal(data, &params)
+	return params
+}
@@ -0,0 +1,94 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// functionCallLLM replies with its responses in order and records the
+// functions it was given.
+// not synchronized, don't use concurrently!
+type functionCallLLM struct {
+	responses []*llms.ContentChoice
+	functions []llms.FunctionDefinition
+}
+
+func (l *functionCallLLM) GenerateContent(_ context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	l.functions = opts.Functions
+	choice := l.responses[0]
+	l.responses = l.responses[1:]
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{choice}}, nil
+}
+
+func (l *functionCallLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+func functionCallChoice(id, name, arguments string) *llms.ContentChoice {
+	call := &llms.FunctionCall{Name: name, Arguments: arguments}
+	return &llms.ContentChoice{
+		FuncCall:  call,
+		ToolCalls: []llms.ToolCall{{ID: id, Type: "function", FunctionCall: call}},
+	}
+}
+
+type addArgs struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+func TestOpenAIFunctionsAgentSchemaTools(t *testing.T) {
+	t.Parallel()
+
+	var got addArgs
+	add, err := tools.NewFunc("add", "Adds two integers.", func(_ context.Context, args addArgs) (int, error) {
+		got = args
+		return args.A + args.B, nil
+	})
+	require.NoError(t, err)
+
+	llm := &functionCallLLM{responses: []*llms.ContentChoice{
+		functionCallChoice("call_1", "add", `{"a": 1, "b": 2}`),
+		functionCallChoice("call_2", "calculator", `{"input": "3 * 2"}`),
+		{Content: "The result is 6."},
+	}}
+	a := agents.NewOpenAIFunctionsAgent(llm, []tools.Tool{add, tools.Calculator{}})
+	e := agents.NewExecutor(a, agents.WithReturnIntermediateSteps())
+
+	result, err := chains.Call(context.Background(), e, map[string]any{"input": "What is (1 + 2) * 2?"})
+	require.NoError(t, err)
+	assert.Equal(t, "The result is 6.", result["output"])
+	assert.Equal(t, addArgs{A: 1, B: 2}, got)
+
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 2)
+	assert.Equal(t, `{"a": 1, "b": 2}`, steps[0].Action.ToolInput)
+	assert.Equal(t, "3", steps[0].Observation)
+	assert.Equal(t, "3 * 2", steps[1].Action.ToolInput)
+	assert.Equal(t, "6", steps[1].Observation)
+
+	require.Len(t, llm.functions, 2)
+	assert.Equal(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"a": map[string]any{"type": "integer", "properties": map[string]any{}},
+			"b": map[string]any{"type": "integer", "properties": map[string]any{}},
+		},
+		"required":             []any{"a", "b"},
+		"additionalProperties": false,
+	}, llm.functions[0].Parameters)
+	assert.Equal(t, []any{"input"}, llm.functions[1].Parameters.(map[string]any)["required"])
+}
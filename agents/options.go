@@ -1,7 +1,11 @@
 package agents
 
 import (
+	"time"
+
 	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
 	"github.com/tmc/langchaingo/memory"
 	"github.com/tmc/langchaingo/prompts"
 	"github.com/tmc/langchaingo/schema"
@@ -15,14 +19,32 @@ type Options struct {
 	errorHandler            *ParserErrorHandler
 	maxIterations           int
 	returnIntermediateSteps bool
+	maxConcurrency          int
+	toolConcurrency         map[string]int
+	toolErrorHandler        *ToolErrorHandler
+	toolTimeout             time.Duration
+	toolTimeouts            map[string]time.Duration
+	approval                ApprovalFunc
+	approvalTools           []string
+	checkpointer            Checkpointer
 	outputKey               string
+	finalAnswerSchema       *jsonschema.Definition
 	promptPrefix            string
 	formatInstructions      string
 	promptSuffix            string
 
-	// openai
+	// plan and execute
+	replannerPrompt prompts.PromptTemplate
+	stepExecutor    chains.Chain
+
+	// chain tool
+	inputKey           string
+	maxDelegationDepth int
+
+	// openai and tool calling
 	systemMessage string
 	extraMessages []prompts.MessageFormatter
+	toolChoice    any
 }
 
 // Option is a function type that can be used to modify the creation of the agents
@@ -62,17 +84,36 @@ func openAIFunctionsDefaultOptions() Options {
 	}
 }
 
+func toolCallingDefaultOptions() Options {
+	return Options{
+		systemMessage: "You are a helpful AI assistant.",
+		outputKey:     _defaultOutputKey,
+	}
+}
+
+func planAndExecuteDefaultOptions() Options {
+	return Options{
+		outputKey: _defaultOutputKey,
+	}
+}
+
+func chainToolDefaultOptions() Options {
+	return Options{
+		maxDelegationDepth: _defaultMaxDelegationDepth,
+	}
+}
+
 func (co Options) getMrklPrompt(tools []tools.Tool) prompts.PromptTemplate {
 	if co.prompt.Template != "" {
 		return co.prompt
 	}
 
-	return createMRKLPrompt(
+	return co.withFinalAnswerFormat(createMRKLPrompt(
 		tools,
 		co.promptPrefix,
-		co.formatInstructions,
+		co.withFinalAnswerInstructions(co.formatInstructions),
 		co.promptSuffix,
-	)
+	))
 }
 
 func (co Options) getConversationalPrompt(tools []tools.Tool) prompts.PromptTemplate {
@@ -80,12 +121,46 @@ func (co Options) getConversationalPrompt(tools []tools.Tool) prompts.PromptTemp
 		return co.prompt
 	}
 
-	return createConversationalPrompt(
+	return co.withFinalAnswerFormat(createConversationalPrompt(
 		tools,
 		co.promptPrefix,
-		co.formatInstructions,
+		co.withFinalAnswerInstructions(co.formatInstructions),
 		co.promptSuffix,
-	)
+	))
+}
+
+// withFinalAnswerInstructions adds the placeholder of the instructions for a
+// structured final answer to the format instructions, if there is a schema.
+func (co Options) withFinalAnswerInstructions(instructions string) string {
+	if co.finalAnswerSchema == nil {
+		return instructions
+	}
+	return instructions + "\n\n{{.final_answer_format}}"
+}
+
+// withFinalAnswerFormat sets the instructions for a structured final answer as
+// a partial variable, so that the schema is not parsed as a template.
+func (co Options) withFinalAnswerFormat(prompt prompts.PromptTemplate) prompts.PromptTemplate {
+	if co.finalAnswerSchema != nil {
+		prompt.PartialVariables["final_answer_format"] = finalAnswerFormat(co.finalAnswerSchema)
+	}
+	return prompt
+}
+
+func (co Options) getPlannerPrompt(tools []tools.Tool) prompts.PromptTemplate {
+	if co.prompt.Template != "" {
+		return co.prompt
+	}
+
+	return createPlannerPrompt(tools)
+}
+
+func (co Options) getReplannerPrompt(tools []tools.Tool) prompts.PromptTemplate {
+	if co.replannerPrompt.Template != "" {
+		return co.replannerPrompt
+	}
+
+	return createReplannerPrompt(tools)
 }
 
 // WithMaxIterations is an option for setting the max number of iterations the executor
@@ -96,7 +171,8 @@ func WithMaxIterations(iterations int) Option {
 	}
 }
 
-// WithOutputKey is an option for setting the output key of the agent.
+// WithOutputKey is an option for setting the output key of the agent, or of the chain of a chain
+// tool.
 func WithOutputKey(outputKey string) Option {
 	return func(co *Options) {
 		co.outputKey = outputKey
@@ -161,6 +237,137 @@ func WithParserErrorHandler(errorHandler *ParserErrorHandler) Option {
 	}
 }
 
+// WithMaxConcurrency is an option for setting the maximum number of tools the executor calls at
+// the same time when the agent plans several actions at once. By default, actions are run one
+// after the other. With concurrent calls, the callbacks handler must be safe for concurrent use.
+func WithMaxConcurrency(n int) Option {
+	return func(co *Options) {
+		co.maxConcurrency = n
+	}
+}
+
+// WithToolConcurrency is an option for limiting the number of concurrent calls of a tool by the
+// executor, for tools backed by rate limited services. It only matters with WithMaxConcurrency.
+func WithToolConcurrency(toolName string, limit int) Option {
+	return func(co *Options) {
+		if co.toolConcurrency == nil {
+			co.toolConcurrency = make(map[string]int)
+		}
+		co.toolConcurrency[toolName] = limit
+	}
+}
+
+// WithToolErrorHandler is an option for setting a tool error handler to an executor, deciding
+// whether failed tool calls are retried and whether they stop the run.
+func WithToolErrorHandler(errorHandler *ToolErrorHandler) Option {
+	return func(co *Options) {
+		co.toolErrorHandler = errorHandler
+	}
+}
+
+// WithToolTimeout is an option for setting the maximum duration of the tool calls of an
+// executor. Tools with their own timeout, see WithToolTimeoutFor, are not affected.
+func WithToolTimeout(timeout time.Duration) Option {
+	return func(co *Options) {
+		co.toolTimeout = timeout
+	}
+}
+
+// WithToolTimeoutFor is an option for setting the maximum duration of the calls of a tool by the
+// executor.
+func WithToolTimeoutFor(toolName string, timeout time.Duration) Option {
+	return func(co *Options) {
+		if co.toolTimeouts == nil {
+			co.toolTimeouts = make(map[string]time.Duration)
+		}
+		co.toolTimeouts[toolName] = timeout
+	}
+}
+
+// WithApproval is an option for setting a function consulted by an executor before executing the
+// actions of the named tools, or of all the tools if no name is given. The function can approve
+// an action, reject it, edit the input of its tool or suspend the run.
+func WithApproval(approval ApprovalFunc, toolNames ...string) Option {
+	return func(co *Options) {
+		co.approval = approval
+		co.approvalTools = toolNames
+	}
+}
+
+// WithCheckpointer is an option for setting a checkpointer to an executor, saving the state of the
+// runs with a run ID after every iteration so that they can be resumed.
+func WithCheckpointer(checkpointer Checkpointer) Option {
+	return func(co *Options) {
+		co.checkpointer = checkpointer
+	}
+}
+
+// WithReplannerPrompt is an option for setting the prompt of a plan-and-execute agent asking for
+// the final answer or the remaining plan. The prompt of the initial plan is set with WithPrompt.
+func WithReplannerPrompt(prompt prompts.PromptTemplate) Option {
+	return func(co *Options) {
+		co.replannerPrompt = prompt
+	}
+}
+
+// WithStepExecutor is an option for setting the chain executing the steps of the plan of a
+// plan-and-execute agent.
+func WithStepExecutor(executor chains.Chain) Option {
+	return func(co *Options) {
+		co.stepExecutor = executor
+	}
+}
+
+// WithInputKey is an option for setting the input key of the chain of a chain tool receiving the
+// input of the tool.
+func WithInputKey(inputKey string) Option {
+	return func(co *Options) {
+		co.inputKey = inputKey
+	}
+}
+
+// WithMaxDelegationDepth is an option for setting the maximum number of nested delegations when a
+// chain tool calls its chain.
+func WithMaxDelegationDepth(depth int) Option {
+	return func(co *Options) {
+		co.maxDelegationDepth = depth
+	}
+}
+
+// WithFinalAnswerSchema is an option for making an agent finish with a JSON value matching the
+// schema instead of free text. Tool calling agents give it by calling a "final_answer" tool, and
+// ReAct agents as their final answer. Invalid values are shown to the agent with the validation
+// errors so that it can correct them. The value is the output of the agent, which can be decoded
+// with DecodeOutput.
+func WithFinalAnswerSchema(def *jsonschema.Definition) Option {
+	return func(co *Options) {
+		co.finalAnswerSchema = def
+	}
+}
+
+// WithSystemMessage is an option for setting the system message of the tool calling agent.
+func WithSystemMessage(msg string) Option {
+	return func(co *Options) {
+		co.systemMessage = msg
+	}
+}
+
+// WithExtraMessages is an option for adding messages between the system message and the input
+// in the prompt of the tool calling agent.
+func WithExtraMessages(extraMessages []prompts.MessageFormatter) Option {
+	return func(co *Options) {
+		co.extraMessages = extraMessages
+	}
+}
+
+// WithToolChoice is an option for setting the tool choice of the tool calling agent, see
+// llms.WithToolChoice.
+func WithToolChoice(choice any) Option {
+	return func(co *Options) {
+		co.toolChoice = choice
+	}
+}
+
 type OpenAIOption struct{}
 
 func NewOpenAIOption() OpenAIOption {
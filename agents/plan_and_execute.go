@@ -0,0 +1,277 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const (
+	// PlanOutputKey is the output key of the initial plan of a
+	// PlanAndExecuteAgent, as a []string. It is only returned if the agent
+	// returns its intermediate steps.
+	PlanOutputKey = "plan"
+	// StepResultsOutputKey is the output key of the results of the executed
+	// steps of a PlanAndExecuteAgent, as a []PlanStepResult. It is only
+	// returned if the agent returns its intermediate steps.
+	StepResultsOutputKey = "step_results"
+
+	_planStepToolName = "execute_plan_step"
+	_planLogPrefix    = "Plan:\n"
+)
+
+// PlanStepResult is the result of a step of the plan of a PlanAndExecuteAgent.
+type PlanStepResult struct {
+	Step   string `json:"step"`
+	Result string `json:"result"`
+}
+
+// PlanAndExecuteAgent is an agent that first asks the LLM for a step by step
+// plan, then executes each step with an inner executor using the tools. After
+// every step the LLM revises the remaining plan given the results so far, or
+// gives the final answer. Failed steps are reported to the LLM, which can
+// plan around them, unless the run was canceled or suspended.
+//
+// To the executor, the execution of each step is a call of a single tool
+// wrapping the inner executor.
+type PlanAndExecuteAgent struct {
+	// Planner is the chain asking for the initial plan. It should have an
+	// input called "input".
+	Planner chains.Chain
+	// Replanner is the chain asking for the final answer or the remaining
+	// plan. It should have inputs called "input", "plan" and "past_steps".
+	Replanner chains.Chain
+	// StepExecutor is the chain executing each step, usually an Executor
+	// with the tools. It is called with a single input describing the step.
+	StepExecutor chains.Chain
+	// Output key is the key where the final output is placed.
+	OutputKey string
+	// ReturnIntermediateSteps makes the agent also return the initial plan
+	// and the results of the executed steps, under PlanOutputKey and
+	// StepResultsOutputKey.
+	ReturnIntermediateSteps bool
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ Agent = (*PlanAndExecuteAgent)(nil)
+
+// NewPlanAndExecuteAgent creates a new PlanAndExecuteAgent with the given LLM
+// model, tools, and options. Unless set with WithStepExecutor, the steps are
+// executed by an executor running a OneShotZeroAgent with the tools. With
+// WithReturnIntermediateSteps, the agent returns its plan and step results.
+func NewPlanAndExecuteAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *PlanAndExecuteAgent {
+	options := planAndExecuteDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	stepExecutor := options.stepExecutor
+	if stepExecutor == nil {
+		stepExecutor = NewExecutor(
+			NewOneShotAgent(llm, tools, WithCallbacksHandler(options.callbacksHandler)),
+			WithCallbacksHandler(options.callbacksHandler),
+		)
+	}
+
+	return &PlanAndExecuteAgent{
+		Planner: chains.NewLLMChain(
+			llm,
+			options.getPlannerPrompt(tools),
+			chains.WithCallback(options.callbacksHandler),
+		),
+		Replanner: chains.NewLLMChain(
+			llm,
+			options.getReplannerPrompt(tools),
+			chains.WithCallback(options.callbacksHandler),
+		),
+		StepExecutor:            stepExecutor,
+		OutputKey:               options.outputKey,
+		ReturnIntermediateSteps: options.returnIntermediateSteps,
+		CallbacksHandler:        options.callbacksHandler,
+	}
+}
+
+// Plan asks for the initial plan, or for the final answer or the remaining
+// plan once steps were executed, and returns the action executing the next
+// step.
+func (a *PlanAndExecuteAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs)+2)
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+
+	results := planStepResults(intermediateSteps)
+	chain := a.Planner
+	if len(results) > 0 {
+		chain = a.Replanner
+		fullInputs["plan"] = formatPlan(currentPlan(intermediateSteps))
+		fullInputs["past_steps"] = formatStepResults(results)
+	}
+
+	var stream func(ctx context.Context, chunk []byte) error
+	if a.CallbacksHandler != nil {
+		stream = func(ctx context.Context, chunk []byte) error {
+			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+			return nil
+		}
+	}
+
+	output, err := chains.Predict(ctx, chain, fullInputs, chains.WithStreamingFunc(stream))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(results) > 0 && strings.Contains(output, _finalAnswerAction) {
+		splits := strings.Split(output, _finalAnswerAction)
+		returnValues := map[string]any{a.OutputKey: strings.TrimSpace(splits[len(splits)-1])}
+		if a.ReturnIntermediateSteps {
+			returnValues[PlanOutputKey] = initialPlan(intermediateSteps)
+			returnValues[StepResultsOutputKey] = results
+		}
+		return nil, &schema.AgentFinish{ReturnValues: returnValues, Log: output}, nil
+	}
+
+	plan := parsePlan(output)
+	if len(plan) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, output)
+	}
+	return []schema.AgentAction{{
+		Tool:      _planStepToolName,
+		ToolInput: formatStepTask(inputs["input"], results, plan[0]),
+		Log:       _planLogPrefix + formatPlan(plan),
+	}}, nil, nil
+}
+
+func (a *PlanAndExecuteAgent) GetInputKeys() []string {
+	return a.Planner.GetInputKeys()
+}
+
+func (a *PlanAndExecuteAgent) GetOutputKeys() []string {
+	if a.ReturnIntermediateSteps {
+		return []string{a.OutputKey, PlanOutputKey, StepResultsOutputKey}
+	}
+	return []string{a.OutputKey}
+}
+
+// GetTools returns the tool executing the steps of the plan with the step
+// executor.
+func (a *PlanAndExecuteAgent) GetTools() []tools.Tool {
+	return []tools.Tool{planStepTool{executor: a.StepExecutor}}
+}
+
+// planStepTool executes a step of the plan. The failures of the step are
+// returned as its output, so that the replanner can work around them, but the
+// cancellation or suspension of the run are returned as errors.
+type planStepTool struct {
+	executor chains.Chain
+}
+
+func (t planStepTool) Name() string {
+	return _planStepToolName
+}
+
+func (t planStepTool) Description() string {
+	return "Executes a step of the plan."
+}
+
+func (t planStepTool) Call(ctx context.Context, input string) (string, error) {
+	result, err := chains.Run(ctx, t.executor, input)
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, ErrSuspended) {
+			return "", err
+		}
+		return fmt.Sprintf("The step failed: %s", err), nil
+	}
+	return strings.TrimSpace(result), nil
+}
+
+var planStepRe = regexp.MustCompile(`^\s*(?:Step\s*)?\d+[.):]\s*(.+)$`)
+
+// parsePlan returns the steps of the numbered list in output.
+func parsePlan(output string) []string {
+	var plan []string
+	for _, line := range strings.Split(output, "\n") {
+		if m := planStepRe.FindStringSubmatch(line); m != nil {
+			plan = append(plan, strings.TrimSpace(m[1]))
+		}
+	}
+	return plan
+}
+
+func formatPlan(plan []string) string {
+	var b strings.Builder
+	for i, step := range plan {
+		b.WriteString(strconv.Itoa(i+1) + ". " + step + "\n")
+	}
+	return b.String()
+}
+
+// currentPlan returns the plan of the last executed step, starting with it.
+func currentPlan(steps []schema.AgentStep) []string {
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Action.Tool == _planStepToolName {
+			return parsePlan(strings.TrimPrefix(steps[i].Action.Log, _planLogPrefix))
+		}
+	}
+	return nil
+}
+
+// initialPlan returns the plan of the first executed step.
+func initialPlan(steps []schema.AgentStep) []string {
+	for _, step := range steps {
+		if step.Action.Tool == _planStepToolName {
+			return parsePlan(strings.TrimPrefix(step.Action.Log, _planLogPrefix))
+		}
+	}
+	return nil
+}
+
+// planStepResults returns the executed steps, whose plan starts with them,
+// and their results.
+func planStepResults(steps []schema.AgentStep) []PlanStepResult {
+	var results []PlanStepResult
+	for _, step := range steps {
+		if step.Action.Tool != _planStepToolName {
+			continue
+		}
+		plan := parsePlan(strings.TrimPrefix(step.Action.Log, _planLogPrefix))
+		if len(plan) == 0 {
+			continue
+		}
+		results = append(results, PlanStepResult{Step: plan[0], Result: step.Observation})
+	}
+	return results
+}
+
+func formatStepResults(results []PlanStepResult) string {
+	var b strings.Builder
+	for i, result := range results {
+		fmt.Fprintf(&b, "%d. %s\nResult: %s\n", i+1, result.Step, result.Result)
+	}
+	return b.String()
+}
+
+// formatStepTask returns the input of the step executor for the step.
+func formatStepTask(objective string, results []PlanStepResult, step string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Objective: %s\n\n", objective)
+	if len(results) > 0 {
+		fmt.Fprintf(&b, "Completed steps:\n%s\n", formatStepResults(results))
+	}
+	fmt.Fprintf(&b, "Current step: %s", step)
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package agents
+
+import (
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const (
+	_defaultPlannerPrompt = `For the given objective, come up with a simple step by step plan. This plan should involve individual tasks, that if executed correctly will yield the correct answer. Do not add any superfluous steps. The result of the final step should be the final answer. Make sure that each step has all the information needed - do not skip steps.
+
+The steps will be carried out by an assistant with access to the following tools:
+
+{{.tool_descriptions}}
+Write the plan as a numbered list, with one step per line, and nothing else.
+
+Objective: {{.input}}`
+
+	_defaultReplannerPrompt = `For the given objective, come up with a simple step by step plan. This plan should involve individual tasks, that if executed correctly will yield the correct answer. Do not add any superfluous steps. The result of the final step should be the final answer. Make sure that each step has all the information needed - do not skip steps.
+
+The steps are carried out by an assistant with access to the following tools:
+
+{{.tool_descriptions}}
+Your objective was this:
+{{.input}}
+
+Your plan was this:
+{{.plan}}
+You have currently done the following steps:
+{{.past_steps}}
+Update your plan accordingly. If no more steps are needed and you can answer the objective, respond with "Final Answer:" followed by the answer. Otherwise, write the steps that still need to be done as a numbered list, with one step per line, and nothing else. Do not return previously done steps as part of the plan. If a step failed, plan another way to reach the objective.`
+)
+
+func createPlannerPrompt(tools []tools.Tool) prompts.PromptTemplate {
+	return prompts.PromptTemplate{
+		Template:       _defaultPlannerPrompt,
+		TemplateFormat: prompts.TemplateFormatGoTemplate,
+		InputVariables: []string{"input"},
+		PartialVariables: map[string]any{
+			"tool_descriptions": toolDescriptions(tools),
+		},
+	}
+}
+
+func createReplannerPrompt(tools []tools.Tool) prompts.PromptTemplate {
+	return prompts.PromptTemplate{
+		Template:       _defaultReplannerPrompt,
+		TemplateFormat: prompts.TemplateFormatGoTemplate,
+		InputVariables: []string{"input", "plan", "past_steps"},
+		PartialVariables: map[string]any{
+			"tool_descriptions": toolDescriptions(tools),
+		},
+	}
+}
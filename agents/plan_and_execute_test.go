@@ -0,0 +1,159 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func textResponses(texts ...string) []*llms.ContentChoice {
+	choices := make([]*llms.ContentChoice, 0, len(texts))
+	for _, text := range texts {
+		choices = append(choices, &llms.ContentChoice{Content: text})
+	}
+	return choices
+}
+
+func lastText(messages []llms.MessageContent) string {
+	parts := messages[len(messages)-1].Parts
+	text, _ := parts[len(parts)-1].(llms.TextContent)
+	return text.Text
+}
+
+func TestPlanAndExecuteAgent(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallLLM{responses: textResponses(
+		// Planner.
+		"Here is the plan:\n1. Compute 1 + 2\n2. Multiply the result by 3",
+		// Step executor.
+		"Thought: I need the calculator\nAction: calculator\nAction Input: 1 + 2",
+		"Thought: I now know the final answer\nFinal Answer: 3",
+		// Replanner.
+		"1. Multiply 3 by 3",
+		// Step executor.
+		"Action: calculator\nAction Input: 3 * 3",
+		"Final Answer: 9",
+		// Replanner.
+		"Final Answer: 9",
+	)}
+	executor, err := agents.Initialize(llm, []tools.Tool{tools.Calculator{}}, agents.PlanAndExecute,
+		agents.WithMaxIterations(5), agents.WithReturnIntermediateSteps())
+	require.NoError(t, err)
+
+	result, err := chains.Call(context.Background(), executor, map[string]any{"input": "What is (1 + 2) * 3?"})
+	require.NoError(t, err)
+	assert.Equal(t, "9", result["output"])
+	assert.Equal(t, []string{"Compute 1 + 2", "Multiply the result by 3"}, result[agents.PlanOutputKey])
+	assert.Equal(t, []agents.PlanStepResult{
+		{Step: "Compute 1 + 2", Result: "3"},
+		{Step: "Multiply 3 by 3", Result: "9"},
+	}, result[agents.StepResultsOutputKey])
+
+	require.Len(t, llm.messages, 7)
+	assert.Contains(t, lastText(llm.messages[0]), "- calculator:")
+	assert.Contains(t, lastText(llm.messages[1]), "Current step: Compute 1 + 2")
+	assert.Contains(t, lastText(llm.messages[3]), "1. Compute 1 + 2\nResult: 3\n")
+	assert.Contains(t, lastText(llm.messages[4]), "Completed steps:\n1. Compute 1 + 2\nResult: 3\n")
+	assert.Contains(t, lastText(llm.messages[4]), "Current step: Multiply 3 by 3")
+}
+
+func TestPlanAndExecuteAgentFailedStep(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallLLM{responses: textResponses(
+		"1. Look up the answer",
+		"Final Answer: I could not find the answer.",
+		"I cannot make a plan.",
+	)}
+	stepExecutor := agents.NewExecutor(&testAgent{
+		err:        errors.New("search is down"),
+		inputKeys:  []string{"input"},
+		outputKeys: []string{"output"},
+	})
+	a := agents.NewPlanAndExecuteAgent(llm, nil,
+		agents.WithStepExecutor(stepExecutor), agents.WithReturnIntermediateSteps())
+
+	result, err := chains.Call(context.Background(), agents.NewExecutor(a), map[string]any{"input": "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, "I could not find the answer.", result["output"])
+	assert.Equal(t, []agents.PlanStepResult{
+		{Step: "Look up the answer", Result: "The step failed: search is down"},
+	}, result[agents.StepResultsOutputKey])
+	assert.Contains(t, lastText(llm.messages[1]), "Result: The step failed: search is down")
+
+	_, _, err = a.Plan(context.Background(), nil, map[string]string{"input": "foo"})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
+
+func TestPlanAndExecuteAgentRun(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallLLM{responses: textResponses(
+		"1. Compute 1 + 2",
+		"Action: calculator\nAction Input: 1 + 2",
+		"Final Answer: 3",
+		"Final Answer: 3",
+	)}
+	executor, err := agents.Initialize(llm, []tools.Tool{tools.Calculator{}}, agents.PlanAndExecute)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"output"}, executor.GetOutputKeys())
+
+	result, err := chains.Run(context.Background(), executor, "What is 1 + 2?")
+	require.NoError(t, err)
+	assert.Equal(t, "3", result)
+}
+
+func TestPlanAndExecuteAgentSuspendedStep(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallLLM{responses: textResponses("1. Delete the files")}
+	suspended := &agents.SuspendedError{State: &agents.RunState{}, Action: schema.AgentAction{Tool: "delete"}}
+	stepExecutor := agents.NewExecutor(&testAgent{
+		err:        suspended,
+		inputKeys:  []string{"input"},
+		outputKeys: []string{"output"},
+	})
+	a := agents.NewPlanAndExecuteAgent(llm, nil, agents.WithStepExecutor(stepExecutor))
+
+	_, err := chains.Run(context.Background(), agents.NewExecutor(a), "Clean up")
+	require.ErrorIs(t, err, agents.ErrSuspended)
+	assert.Len(t, llm.messages, 1)
+}
+
+func TestPlanAndExecuteAgentCanceledStep(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	llm := &toolCallLLM{responses: textResponses("1. Look up the answer")}
+	stepExecutor := agents.NewExecutor(&testAgent{
+		err:        errors.New("request canceled"),
+		inputKeys:  []string{"input"},
+		outputKeys: []string{"output"},
+	})
+	a := agents.NewPlanAndExecuteAgent(llm, nil, agents.WithStepExecutor(cancelingChain{stepExecutor, cancel}))
+
+	_, err := chains.Run(ctx, agents.NewExecutor(a), "foo")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "The step failed")
+	assert.Len(t, llm.messages, 1)
+}
+
+// cancelingChain cancels the context of the run when called.
+type cancelingChain struct {
+	chains.Chain
+	cancel context.CancelFunc
+}
+
+func (c cancelingChain) Call(ctx context.Context, inputs map[string]any, opts ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+	c.cancel()
+	return c.Chain.Call(ctx, inputs, opts...)
+}
@@ -0,0 +1,365 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// ToolCallingAgent is an Agent driven by the tool calling API of the LLM. It
+// works with every backend that supports llms.WithTools, and runs all the
+// tool calls of a response, which the executor can do concurrently.
+type ToolCallingAgent struct {
+	// LLM is the llm used to call with the values.
+	LLM llms.Model
+	// Prompt is the prompt of the first messages sent to the LLM, followed by
+	// the tool calls and their results.
+	Prompt prompts.FormatPrompter
+	// Tools is a list of the tools the agent can use.
+	Tools []tools.Tool
+	// ToolChoice is passed to the LLM with llms.WithToolChoice, if not nil.
+	// Forcing the LLM to call a tool keeps the agent from ever finishing.
+	ToolChoice any
+	// Output key is the key where the final output is placed.
+	OutputKey string
+	// FinalAnswerSchema is the schema of the final answer, if it must be a
+	// JSON value. The LLM then gives it by calling a "final_answer" tool.
+	FinalAnswerSchema *jsonschema.Definition
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ Agent = (*ToolCallingAgent)(nil)
+
+// NewToolCallingAgent creates a new ToolCallingAgent.
+func NewToolCallingAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ToolCallingAgent {
+	options := toolCallingDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &ToolCallingAgent{
+		LLM:               llm,
+		Prompt:            createToolCallingPrompt(options),
+		Tools:             tools,
+		ToolChoice:        options.toolChoice,
+		OutputKey:         options.outputKey,
+		FinalAnswerSchema: options.finalAnswerSchema,
+		CallbacksHandler:  options.callbacksHandler,
+	}
+}
+
+// Plan decides what action to take or returns the final result of the input.
+func (a *ToolCallingAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	prompt, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := make([]llms.MessageContent, 0, len(prompt.Messages())+2*len(intermediateSteps))
+	for _, msg := range prompt.Messages() {
+		messages = append(messages, llms.TextParts(msg.GetType(), msg.GetContent()))
+	}
+	messages = append(messages, a.constructScratchPad(intermediateSteps)...)
+
+	options := []llms.CallOption{llms.WithTools(a.tools())}
+	if a.ToolChoice != nil {
+		options = append(options, llms.WithToolChoice(a.ToolChoice))
+	}
+	if a.CallbacksHandler != nil {
+		options = append(options, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
+			return nil
+		}))
+	}
+
+	result, err := a.LLM.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.ParseOutput(result)
+}
+
+// ParseOutput turns the tool calls of the response into actions, or the
+// response into the final result if it has no tool calls.
+func (a *ToolCallingAgent) ParseOutput(resp *llms.ContentResponse) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(resp.Choices) == 0 {
+		return nil, nil, ErrUnableToParseOutput
+	}
+	choice := resp.Choices[0]
+
+	if len(choice.ToolCalls) == 0 {
+		return a.finish(choice.Content)
+	}
+	if finish := a.finalAnswer(choice); finish != nil {
+		return nil, finish, nil
+	}
+
+	for _, call := range choice.ToolCalls {
+		if call.FunctionCall == nil {
+			return nil, nil, fmt.Errorf("%w: tool call %s has no function", ErrUnableToParseOutput, call.ID)
+		}
+	}
+	// All the actions of a response share its log, from which the scratchpad
+	// replays the response.
+	log := toolCallsLog(choice)
+
+	actions := make([]schema.AgentAction, 0, len(choice.ToolCalls))
+	for _, call := range choice.ToolCalls {
+		input, err := a.toolInput(call.FunctionCall.Name, call.FunctionCall.Arguments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrUnableToParseOutput, err)
+		}
+		actions = append(actions, schema.AgentAction{
+			Tool:      call.FunctionCall.Name,
+			ToolInput: input,
+			Log:       log,
+			ToolID:    call.ID,
+		})
+	}
+	return actions, nil, nil
+}
+
+func (a *ToolCallingAgent) GetInputKeys() []string {
+	return a.Prompt.GetInputVariables()
+}
+
+func (a *ToolCallingAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+// GetTools returns the tools of the agent, and the final answer tool if it
+// has a final answer schema.
+func (a *ToolCallingAgent) GetTools() []tools.Tool {
+	if a.FinalAnswerSchema == nil {
+		return a.Tools
+	}
+	return append(a.Tools[:len(a.Tools):len(a.Tools)], newFinalAnswerTool(a.FinalAnswerSchema))
+}
+
+// finish returns the finish of a response without tool calls. With a final
+// answer schema, the response must be a matching JSON value.
+func (a *ToolCallingAgent) finish(content string) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if a.FinalAnswerSchema == nil {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{a.OutputKey: content},
+			Log:          content,
+		}, nil
+	}
+
+	value, err := parseFinalAnswer(a.FinalAnswerSchema, content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: give the final answer by calling the %s tool: %w",
+			ErrUnableToParseOutput, _finalAnswerToolName, err)
+	}
+	return nil, &schema.AgentFinish{
+		ReturnValues: map[string]any{a.OutputKey: value},
+		Log:          content,
+	}, nil
+}
+
+// finalAnswer returns the finish of a response calling the final answer tool
+// with a valid answer, if any. Invalid answers are left as actions, whose
+// observation tells the LLM what is wrong.
+func (a *ToolCallingAgent) finalAnswer(choice *llms.ContentChoice) *schema.AgentFinish {
+	if a.FinalAnswerSchema == nil {
+		return nil
+	}
+	tool := newFinalAnswerTool(a.FinalAnswerSchema)
+	for _, call := range choice.ToolCalls {
+		if call.FunctionCall == nil || call.FunctionCall.Name != _finalAnswerToolName {
+			continue
+		}
+		if value, err := tool.parse(call.FunctionCall.Arguments); err == nil {
+			return &schema.AgentFinish{
+				ReturnValues: map[string]any{a.OutputKey: value},
+				Log:          choice.Content,
+			}
+		}
+	}
+	return nil
+}
+
+func (a *ToolCallingAgent) tools() []llms.Tool {
+	agentTools := a.GetTools()
+	res := make([]llms.Tool, 0, len(agentTools))
+	for _, tool := range agentTools {
+		res = append(res, llms.Tool{
+			Type: "function",
+			Function: &llms.FunctionDefinition{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Parameters:  toolParameters(tool),
+			},
+		})
+	}
+	return res
+}
+
+// stringTool returns the tool named name if it is a string tool, whose input
+// is the "input" argument of its calls.
+func (a *ToolCallingAgent) stringTool(name string) (tools.Tool, bool) {
+	for _, tool := range a.GetTools() {
+		if tool.Name() == name {
+			_, isSchemaTool := tool.(tools.SchemaTool)
+			return tool, !isSchemaTool
+		}
+	}
+	return nil, false
+}
+
+// toolInput returns the input of the tool called with the JSON arguments: the
+// "input" argument for string tools, and the arguments themselves otherwise.
+func (a *ToolCallingAgent) toolInput(name, arguments string) (string, error) {
+	if _, ok := a.stringTool(name); !ok {
+		return arguments, nil
+	}
+	var args struct {
+		Input string `json:"input"`
+	}
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return "", err
+	}
+	return args.Input, nil
+}
+
+// toolArguments is the inverse of toolInput.
+func (a *ToolCallingAgent) toolArguments(name, input string) string {
+	if _, ok := a.stringTool(name); !ok {
+		return input
+	}
+	data, _ := json.Marshal(map[string]string{"input": input})
+	return string(data)
+}
+
+const (
+	_toolCallIDsPrefix = "Tool call IDs: "
+	_invokingPrefix    = "Invoking: "
+	_respondedPrefix   = "responded: "
+)
+
+// toolCallsLog returns the log of the actions of a response calling tools. Its
+// first line holds the ordered IDs of the tool calls, which tell apart the
+// responses making the same calls, and the text content of the response, if
+// any, is at its end.
+func toolCallsLog(choice *llms.ContentChoice) string {
+	ids := make([]string, 0, len(choice.ToolCalls))
+	calls := make([]string, 0, len(choice.ToolCalls))
+	for _, call := range choice.ToolCalls {
+		ids = append(ids, call.ID)
+		calls = append(calls, fmt.Sprintf("%s with %s", call.FunctionCall.Name, call.FunctionCall.Arguments))
+	}
+	data, _ := json.Marshal(ids)
+
+	log := fmt.Sprintf("%s%s\n%s%s\n", _toolCallIDsPrefix, data, _invokingPrefix, strings.Join(calls, ", "))
+	if choice.Content != "" {
+		log += fmt.Sprintf("%s%s\n", _respondedPrefix, choice.Content)
+	}
+	return log
+}
+
+// parseToolCallsLog returns the tool call IDs and the text content of the
+// response logged by toolCallsLog. The IDs are nil if the log was not written
+// by toolCallsLog.
+func parseToolCallsLog(log string) ([]string, string) {
+	first, rest, _ := strings.Cut(log, "\n")
+	data, ok := strings.CutPrefix(first, _toolCallIDsPrefix)
+	if !ok {
+		return nil, ""
+	}
+	var ids []string
+	if err := json.Unmarshal([]byte(data), &ids); err != nil {
+		return nil, ""
+	}
+
+	var content string
+	if i := strings.Index(rest, "\n"+_respondedPrefix); i >= 0 {
+		content = strings.TrimSuffix(rest[i+1+len(_respondedPrefix):], "\n")
+	}
+	return ids, content
+}
+
+// constructScratchPad replays the steps as the responses of the LLM calling
+// tools, each followed by the results of its tool calls.
+func (a *ToolCallingAgent) constructScratchPad(steps []schema.AgentStep) []llms.MessageContent {
+	var messages []llms.MessageContent
+	for i := 0; i < len(steps); {
+		if steps[i].Action.Tool == "" {
+			// A step without action holds an error for the LLM to correct.
+			messages = append(messages, llms.TextParts(llms.ChatMessageTypeHuman, steps[i].Observation))
+			i++
+			continue
+		}
+
+		ids, content := parseToolCallsLog(steps[i].Action.Log)
+		j := i + 1
+		for j < len(steps) && sameResponse(steps[i].Action, steps[j].Action, ids, j-i) {
+			j++
+		}
+
+		call := llms.MessageContent{Role: llms.ChatMessageTypeAI}
+		if content != "" {
+			call.Parts = append(call.Parts, llms.TextContent{Text: content})
+		}
+		results := make([]llms.MessageContent, 0, j-i)
+		for _, step := range steps[i:j] {
+			call.Parts = append(call.Parts, llms.ToolCall{
+				ID:   step.Action.ToolID,
+				Type: "function",
+				FunctionCall: &llms.FunctionCall{
+					Name:      step.Action.Tool,
+					Arguments: a.toolArguments(step.Action.Tool, step.Action.ToolInput),
+				},
+			})
+			results = append(results, llms.MessageContent{
+				Role: llms.ChatMessageTypeTool,
+				Parts: []llms.ContentPart{llms.ToolCallResponse{
+					ToolCallID: step.Action.ToolID,
+					Name:       step.Action.Tool,
+					Content:    step.Observation,
+				}},
+			})
+		}
+		messages = append(messages, call)
+		messages = append(messages, results...)
+		i = j
+	}
+	return messages
+}
+
+// sameResponse reports whether action, the n-th of its response, comes from
+// the same response as first, whose tool call IDs are ids. Without ids, the
+// actions of a response are those with the same log.
+func sameResponse(first, action schema.AgentAction, ids []string, n int) bool {
+	if action.Tool == "" || action.Log != first.Log {
+		return false
+	}
+	if ids == nil {
+		return true
+	}
+	return n < len(ids) && action.ToolID == ids[n]
+}
+
+func createToolCallingPrompt(opts Options) prompts.ChatPromptTemplate {
+	messageFormatters := []prompts.MessageFormatter{prompts.NewSystemMessagePromptTemplate(opts.systemMessage, nil)}
+	messageFormatters = append(messageFormatters, opts.extraMessages...)
+	messageFormatters = append(messageFormatters, prompts.NewHumanMessagePromptTemplate("{{.input}}", []string{"input"}))
+	return prompts.NewChatPromptTemplate(messageFormatters)
+}
@@ -0,0 +1,259 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// toolCallLLM replies with its responses in order and records the messages
+// and options of the calls.
+// not synchronized, don't use concurrently!
+type toolCallLLM struct {
+	responses []*llms.ContentChoice
+	messages  [][]llms.MessageContent
+	opts      []llms.CallOptions
+}
+
+func (l *toolCallLLM) GenerateContent(_ context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	l.messages = append(l.messages, messages)
+	l.opts = append(l.opts, opts)
+	choice := l.responses[0]
+	l.responses = l.responses[1:]
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{choice}}, nil
+}
+
+func (l *toolCallLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+func toolCall(id, name, arguments string) llms.ToolCall {
+	return llms.ToolCall{
+		ID:           id,
+		Type:         "function",
+		FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments},
+	}
+}
+
+func TestToolCallingAgent(t *testing.T) {
+	t.Parallel()
+
+	add, err := tools.NewFunc("add", "Adds two integers.", func(_ context.Context, args addArgs) (int, error) {
+		return args.A + args.B, nil
+	})
+	require.NoError(t, err)
+
+	llm := &toolCallLLM{responses: []*llms.ContentChoice{
+		{ToolCalls: []llms.ToolCall{
+			toolCall("call_1", "add", `{"a":1,"b":2}`),
+			toolCall("call_2", "calculator", `{"input":"3 * 2"}`),
+		}},
+		{ToolCalls: []llms.ToolCall{toolCall("call_3", "add", `{"a":3,"b":6}`)}},
+		{Content: "The results are 3, 6 and 9."},
+	}}
+	executor, err := agents.Initialize(llm, []tools.Tool{add, tools.Calculator{}}, agents.ToolCalling,
+		agents.WithSystemMessage("You are good at maths."),
+		agents.WithToolChoice("auto"),
+		agents.WithMaxConcurrency(2),
+	)
+	require.NoError(t, err)
+
+	result, err := chains.Run(context.Background(), executor, "Compute 1 + 2, 3 * 2 and their sum.")
+	require.NoError(t, err)
+	assert.Equal(t, "The results are 3, 6 and 9.", result)
+
+	require.Len(t, llm.opts, 3)
+	assert.Equal(t, "auto", llm.opts[0].ToolChoice)
+	require.Len(t, llm.opts[0].Tools, 2)
+	assert.Equal(t, "add", llm.opts[0].Tools[0].Function.Name)
+	assert.Equal(t, "calculator", llm.opts[0].Tools[1].Function.Name)
+
+	assert.Equal(t, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "You are good at maths."),
+		llms.TextParts(llms.ChatMessageTypeHuman, "Compute 1 + 2, 3 * 2 and their sum."),
+		{
+			Role: llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{
+				toolCall("call_1", "add", `{"a":1,"b":2}`),
+				toolCall("call_2", "calculator", `{"input":"3 * 2"}`),
+			},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_1", Name: "add", Content: "3"}},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_2", Name: "calculator", Content: "6"}},
+		},
+		{
+			Role:  llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{toolCall("call_3", "add", `{"a":3,"b":6}`)},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_3", Name: "add", Content: "9"}},
+		},
+	}, llm.messages[2])
+}
+
+func TestToolCallingAgentScratchPad(t *testing.T) {
+	t.Parallel()
+
+	add, err := tools.NewFunc("add", "Adds two integers.", func(_ context.Context, args addArgs) (int, error) {
+		return args.A + args.B, nil
+	})
+	require.NoError(t, err)
+
+	// two responses make the same call, the first one with some text.
+	llm := &toolCallLLM{responses: []*llms.ContentChoice{
+		{Content: "Let me add them.", ToolCalls: []llms.ToolCall{toolCall("call_1", "add", `{"a":1,"b":2}`)}},
+		{ToolCalls: []llms.ToolCall{toolCall("call_2", "add", `{"a":1,"b":2}`)}},
+		{Content: "It is 3."},
+	}}
+	executor, err := agents.Initialize(llm, []tools.Tool{add}, agents.ToolCalling)
+	require.NoError(t, err)
+
+	_, err = chains.Run(context.Background(), executor, "Add 1 and 2, twice.")
+	require.NoError(t, err)
+
+	require.Len(t, llm.messages, 3)
+	assert.Equal(t, []llms.MessageContent{
+		{
+			Role: llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{
+				llms.TextContent{Text: "Let me add them."},
+				toolCall("call_1", "add", `{"a":1,"b":2}`),
+			},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_1", Name: "add", Content: "3"}},
+		},
+		{
+			Role:  llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{toolCall("call_2", "add", `{"a":1,"b":2}`)},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_2", Name: "add", Content: "3"}},
+		},
+	}, llm.messages[2][len(llm.messages[2])-4:])
+}
+
+func TestToolCallingAgentParseOutput(t *testing.T) {
+	t.Parallel()
+
+	a := agents.NewToolCallingAgent(&toolCallLLM{}, []tools.Tool{tools.Calculator{}})
+
+	actions, finish, err := a.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		Content:   "Let me compute it.",
+		ToolCalls: []llms.ToolCall{toolCall("call_1", "calculator", `{"input":"1 + 1"}`)},
+	}}})
+	require.NoError(t, err)
+	assert.Nil(t, finish)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "calculator", actions[0].Tool)
+	assert.Equal(t, "1 + 1", actions[0].ToolInput)
+	assert.Equal(t, "call_1", actions[0].ToolID)
+	assert.Contains(t, actions[0].Log, "Let me compute it.")
+
+	_, _, err = a.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		ToolCalls: []llms.ToolCall{toolCall("call_1", "calculator", `1 + 1`)},
+	}}})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+
+	_, finish, err = a.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "2"}}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"output": "2"}, finish.ReturnValues)
+}
+
+type weatherReport struct {
+	City        string `json:"city"`
+	Temperature int    `json:"temperature"`
+}
+
+func TestToolCallingAgentFinalAnswerSchema(t *testing.T) {
+	t.Parallel()
+
+	def, err := jsonschema.Reflect(weatherReport{})
+	require.NoError(t, err)
+	llm := &toolCallLLM{responses: []*llms.ContentChoice{
+		{ToolCalls: []llms.ToolCall{toolCall("call_1", "final_answer", `{"city":"Paris"}`)}},
+		{ToolCalls: []llms.ToolCall{toolCall("call_2", "final_answer", `{"city":"Paris","temperature":21}`)}},
+	}}
+	executor, err := agents.Initialize(llm, []tools.Tool{tools.Calculator{}}, agents.ToolCalling,
+		agents.WithFinalAnswerSchema(def))
+	require.NoError(t, err)
+
+	result, err := chains.Call(context.Background(), executor, map[string]any{"input": "What's the weather in Paris?"})
+	require.NoError(t, err)
+	report, err := agents.DecodeOutput[weatherReport](result, "output")
+	require.NoError(t, err)
+	assert.Equal(t, weatherReport{City: "Paris", Temperature: 21}, report)
+
+	require.Len(t, llm.opts[0].Tools, 2)
+	assert.Equal(t, "final_answer", llm.opts[0].Tools[1].Function.Name)
+	assert.Equal(t, []any{"city", "temperature"},
+		llm.opts[0].Tools[1].Function.Parameters.(map[string]any)["required"])
+
+	response, ok := llm.messages[1][len(llm.messages[1])-1].Parts[0].(llms.ToolCallResponse)
+	require.True(t, ok)
+	assert.Equal(t, "call_1", response.ToolCallID)
+	assert.Contains(t, response.Content, `missing required property "temperature"`)
+}
+
+func TestToolCallingAgentFinalAnswerNotObject(t *testing.T) {
+	t.Parallel()
+
+	a := agents.NewToolCallingAgent(&toolCallLLM{}, nil, agents.WithFinalAnswerSchema(&jsonschema.Definition{
+		Type:  jsonschema.Array,
+		Items: &jsonschema.Definition{Type: jsonschema.String},
+	}))
+
+	_, finish, err := a.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		ToolCalls: []llms.ToolCall{toolCall("call_1", "final_answer", `{"answer":["a","b"]}`)},
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", "b"}, finish.ReturnValues["output"])
+
+	_, finish, err = a.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: `["c"]`}}})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"c"}, finish.ReturnValues["output"])
+
+	_, _, err = a.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "c"}}})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
+
+func TestOneShotAgentFinalAnswerSchema(t *testing.T) {
+	t.Parallel()
+
+	def, err := jsonschema.Reflect(weatherReport{})
+	require.NoError(t, err)
+	llm := &toolCallLLM{responses: []*llms.ContentChoice{
+		{Content: "Thought: I know it\nFinal Answer: It is 21 degrees in Paris."},
+		{Content: "Thought: I need JSON\nFinal Answer: ```json\n{\"city\": \"Paris\", \"temperature\": 21}\n```"},
+	}}
+	executor := agents.NewExecutor(agents.NewOneShotAgent(llm, nil, agents.WithFinalAnswerSchema(def)))
+
+	result, err := chains.Call(context.Background(), executor, map[string]any{"input": "What's the weather in Paris?"})
+	require.NoError(t, err)
+	report, err := agents.DecodeOutput[weatherReport](result, "output")
+	require.NoError(t, err)
+	assert.Equal(t, weatherReport{City: "Paris", Temperature: 21}, report)
+
+	assert.Contains(t, lastText(llm.messages[0]), `"temperature": {`)
+	assert.Contains(t, lastText(llm.messages[1]),
+		"Final Answer: It is 21 degrees in Paris.\nObservation: The final answer does not match the JSON schema")
+}
@@ -1,5 +1,7 @@
 // Package jsonschema provides very simple functionality for representing a JSON schema as a
 // (nested) struct. This struct can be used with the chat completion "function call" feature.
+// Definitions can be written by hand or reflected from Go types with Reflect, and decoded JSON
+// values can be checked against them with Validate.
 // For more complicated schemas, it is recommended to use a dedicated JSON schema library
 // and/or pass in the schema in []byte format.
 package jsonschema
@@ -34,16 +36,73 @@ type Definition struct {
 	Required []string `json:"required,omitempty"`
 	// Items specifies which data type an array contains, if the schema type is Array.
 	Items *Definition `json:"items,omitempty"`
+	// AdditionalProperties specifies whether an object allows properties that
+	// are not listed in Properties, if the schema type is Object. It is either
+	// a bool or a Definition that the additional properties must match.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+	// Ref refers to another schema that the value must match instead, such as
+	// "#/$defs/Node" for a schema in the Defs of the root schema, or "#" for the
+	// root schema itself.
+	Ref string `json:"$ref,omitempty"`
+	// Defs holds the schemas that Ref can refer to. It is only set on the root
+	// schema.
+	Defs map[string]Definition `json:"$defs,omitempty"`
+	// Nullable allows the value to also be null. It is encoded as a list of
+	// types, such as ["integer", "null"], or as an anyOf for references.
+	Nullable bool `json:"-"`
 }
 
 func (d Definition) MarshalJSON() ([]byte, error) {
+	type Alias Definition
+	if d.Nullable {
+		return d.marshalNullable()
+	}
+	if d.Ref != "" {
+		// A reference takes its properties from the schema it refers to.
+		return json.Marshal(struct {
+			Alias
+			Properties map[string]Definition `json:"properties,omitempty"`
+		}{
+			Alias:      (Alias)(d),
+			Properties: d.Properties,
+		})
+	}
 	if d.Properties == nil {
 		d.Properties = make(map[string]Definition)
 	}
-	type Alias Definition
 	return json.Marshal(struct {
 		Alias
 	}{
 		Alias: (Alias)(d),
 	})
 }
+
+// marshalNullable encodes a nullable definition.
+func (d Definition) marshalNullable() ([]byte, error) {
+	d.Nullable = false
+	switch {
+	case d.Ref != "":
+		return json.Marshal(struct {
+			AnyOf       []Definition `json:"anyOf"`
+			Description string       `json:"description,omitempty"`
+		}{
+			AnyOf:       []Definition{{Ref: d.Ref}, {Type: Null}},
+			Description: d.Description,
+		})
+	case d.Type != "":
+		type Alias Definition
+		if d.Properties == nil {
+			d.Properties = make(map[string]Definition)
+		}
+		return json.Marshal(struct {
+			Alias
+			Type []DataType `json:"type"`
+		}{
+			Alias: (Alias)(d),
+			Type:  []DataType{d.Type, Null},
+		})
+	default:
+		// A value of any type can already be null.
+		return json.Marshal(d)
+	}
+}
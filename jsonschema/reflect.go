@@ -0,0 +1,288 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnsupportedType is returned by Reflect for types that have no JSON
+	// schema, such as channels and functions.
+	ErrUnsupportedType = errors.New("jsonschema: unsupported type")
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	byteSliceType     = reflect.TypeOf([]byte{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Reflect returns the schema of the JSON encoding of v, as produced by
+// encoding/json. v is usually a struct, or a nil pointer to one:
+//
+//	def, err := jsonschema.Reflect((*Person)(nil))
+//
+// Struct fields are named after their `json` tag. A field is required unless
+// it is a pointer or its `json` tag has the omitempty option. The `description`
+// tag of a field sets its description, and the `enum` tag restricts it to a
+// comma separated list of values:
+//
+//	type Person struct {
+//		Name string `json:"name" description:"The full name of the person"`
+//		Role string `json:"role" enum:"admin,user"`
+//		Age  *int   `json:"age,omitempty"`
+//	}
+//
+// Pointers, slices and maps are nullable, since encoding/json encodes their
+// nil values as null, except for the fields with the omitempty option, which
+// are left out instead.
+//
+// Objects reflected from structs do not allow additional properties. Maps
+// are objects whose additional properties match the schema of the map values.
+//
+// Recursive types are described with references: a struct type that contains
+// itself is defined once in the Defs of the returned schema, under the name of
+// the type, and referred to as "#/$defs/<name>". The type of v itself is
+// referred to as "#".
+func Reflect(v any) (*Definition, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return &Definition{}, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	r := reflector{
+		root:      t,
+		visiting:  make(map[reflect.Type]bool),
+		recursive: make(map[reflect.Type]bool),
+		names:     make(map[reflect.Type]string),
+		taken:     make(map[string]bool),
+	}
+	def, err := r.reflect(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.defs) > 0 {
+		def.Defs = r.defs
+	}
+	return &def, nil
+}
+
+type reflector struct {
+	// root is the type passed to Reflect.
+	root reflect.Type
+	// visiting holds the struct types being reflected, to detect recursion.
+	visiting map[reflect.Type]bool
+	// recursive holds the struct types found to contain themselves.
+	recursive map[reflect.Type]bool
+	// defs holds the schemas of the recursive types, by name.
+	defs map[string]Definition
+	// names holds the names of the recursive types in defs, and taken the
+	// names in use.
+	names map[reflect.Type]string
+	taken map[string]bool
+}
+
+func (r *reflector) reflect(t reflect.Type) (Definition, error) { //nolint:cyclop
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return Definition{Type: String}, nil
+	case t == rawMessageType:
+		return Definition{}, nil
+	case t == byteSliceType:
+		// encoding/json encodes byte slices as base64 strings.
+		return Definition{Type: String}, nil
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// The encoding of the type is custom, so nothing can be said about it.
+		return Definition{}, nil
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return Definition{Type: Boolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Definition{Type: Integer}, nil
+	case reflect.Float32, reflect.Float64:
+		return Definition{Type: Number}, nil
+	case reflect.String:
+		return Definition{Type: String}, nil
+	case reflect.Interface:
+		return Definition{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := r.reflectNullable(t.Elem())
+		if err != nil {
+			return Definition{}, err
+		}
+		return Definition{Type: Array, Items: &items}, nil
+	case reflect.Map:
+		return r.reflectMap(t)
+	case reflect.Struct:
+		return r.reflectStruct(t)
+	default:
+		return Definition{}, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
+
+// reflectMap returns the schema of a map, which encoding/json encodes as an
+// object if its keys are strings, integers or encoding.TextMarshalers.
+func (r *reflector) reflectMap(t reflect.Type) (Definition, error) {
+	key := t.Key()
+	switch key.Kind() { //nolint:exhaustive
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		if !key.Implements(textMarshalerType) {
+			return Definition{}, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+		}
+	}
+
+	values, err := r.reflectNullable(t.Elem())
+	if err != nil {
+		return Definition{}, err
+	}
+	return Definition{Type: Object, AdditionalProperties: values}, nil
+}
+
+// reflectNullable returns the schema of t, which is nullable if the nil
+// values of t are encoded as null.
+func (r *reflector) reflectNullable(t reflect.Type) (Definition, error) {
+	def, err := r.reflect(t)
+	if err != nil {
+		return Definition{}, err
+	}
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		def.Nullable = true
+	}
+	return def, nil
+}
+
+func (r *reflector) reflectStruct(t reflect.Type) (Definition, error) {
+	if t == r.root && r.visiting[t] {
+		return Definition{Ref: "#"}, nil
+	}
+	if _, ok := r.defs[r.names[t]]; ok || r.visiting[t] {
+		r.recursive[t] = true
+		return Definition{Ref: r.ref(t)}, nil
+	}
+	r.visiting[t] = true
+	defer delete(r.visiting, t)
+
+	def := Definition{
+		Type:                 Object,
+		Properties:           make(map[string]Definition),
+		AdditionalProperties: false,
+	}
+	if err := r.reflectFields(t, &def); err != nil {
+		return Definition{}, err
+	}
+
+	if t != r.root && r.recursive[t] {
+		if r.defs == nil {
+			r.defs = make(map[string]Definition)
+		}
+		r.defs[r.names[t]] = def
+		return Definition{Ref: r.ref(t)}, nil
+	}
+	return def, nil
+}
+
+// ref returns the reference to the definition of the recursive type t,
+// naming it after the type.
+func (r *reflector) ref(t reflect.Type) string {
+	name, ok := r.names[t]
+	if !ok {
+		base := defNameRe.ReplaceAllString(t.Name(), "_")
+		if base == "" {
+			base = "def"
+		}
+		name = base
+		for i := 2; r.taken[name]; i++ {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+		r.names[t] = name
+		r.taken[name] = true
+	}
+	return "#/$defs/" + name
+}
+
+var defNameRe = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// reflectFields adds the fields of the struct type t to def. The fields of
+// embedded structs are promoted, as encoding/json does.
+func (r *reflector) reflectFields(t reflect.Type, def *Definition) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				if err := r.reflectFields(fieldType, def); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		omitEmpty := hasOption(opts, "omitempty")
+		reflectField := r.reflectNullable
+		if omitEmpty {
+			reflectField = r.reflect
+		}
+		prop, err := reflectField(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		prop.Description = field.Tag.Get("description")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+		def.Properties[name] = prop
+
+		optional := field.Type.Kind() == reflect.Pointer || omitEmpty
+		if !optional {
+			def.Required = append(def.Required, name)
+		}
+	}
+	return nil
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
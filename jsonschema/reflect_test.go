@@ -0,0 +1,251 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+type address struct {
+	Street string `json:"street"`
+	City   string `json:"city,omitempty"`
+}
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type person struct {
+	base
+	Name      string    `json:"name" description:"The full name"`
+	Role      string    `json:"role" enum:"admin,user"`
+	Age       *int      `json:"age"`
+	Tags      []string  `json:"tags,omitempty"`
+	Address   address   `json:"address"`
+	Born      time.Time `json:"born"`
+	Ignored   string    `json:"-"`
+	Untagged  bool
+	unexposed int
+}
+
+func TestReflect(t *testing.T) {
+	t.Parallel()
+
+	def, err := jsonschema.Reflect((*person)(nil))
+	require.NoError(t, err)
+
+	want := &jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"id":   {Type: jsonschema.String},
+			"name": {Type: jsonschema.String, Description: "The full name"},
+			"role": {Type: jsonschema.String, Enum: []string{"admin", "user"}},
+			"age":  {Type: jsonschema.Integer, Nullable: true},
+			"tags": {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+			"address": {
+				Type: jsonschema.Object,
+				Properties: map[string]jsonschema.Definition{
+					"street": {Type: jsonschema.String},
+					"city":   {Type: jsonschema.String},
+				},
+				Required:             []string{"street"},
+				AdditionalProperties: false,
+			},
+			"born":     {Type: jsonschema.String},
+			"Untagged": {Type: jsonschema.Boolean},
+		},
+		Required:             []string{"id", "name", "role", "address", "born", "Untagged"},
+		AdditionalProperties: false,
+	}
+	assert.Equal(t, want, def)
+}
+
+type node struct {
+	Value    string  `json:"value"`
+	Children []*node `json:"children,omitempty"`
+}
+
+type tree struct {
+	Root  *node             `json:"root"`
+	Index map[string]*node  `json:"index"`
+	Meta  map[string]string `json:"meta,omitempty"`
+}
+
+func TestReflectRecursive(t *testing.T) {
+	t.Parallel()
+
+	def, err := jsonschema.Reflect(node{})
+	require.NoError(t, err)
+	assert.Equal(t, &jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"value":    {Type: jsonschema.String},
+			"children": {Type: jsonschema.Array, Items: &jsonschema.Definition{Ref: "#", Nullable: true}},
+		},
+		Required:             []string{"value"},
+		AdditionalProperties: false,
+	}, def)
+
+	def, err = jsonschema.Reflect(tree{})
+	require.NoError(t, err)
+	ref := jsonschema.Definition{Ref: "#/$defs/node", Nullable: true}
+	assert.Equal(t, &jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"root":  ref,
+			"index": {Type: jsonschema.Object, AdditionalProperties: ref, Nullable: true},
+			"meta":  {Type: jsonschema.Object, AdditionalProperties: jsonschema.Definition{Type: jsonschema.String}},
+		},
+		Required:             []string{"index"},
+		AdditionalProperties: false,
+		Defs: map[string]jsonschema.Definition{
+			"node": {
+				Type: jsonschema.Object,
+				Properties: map[string]jsonschema.Definition{
+					"value":    {Type: jsonschema.String},
+					"children": {Type: jsonschema.Array, Items: &ref},
+				},
+				Required:             []string{"value"},
+				AdditionalProperties: false,
+			},
+		},
+	}, def)
+
+	data, err := json.Marshal(def.Properties["root"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"anyOf":[{"$ref":"#/$defs/node"},{"type":"null","properties":{}}]}`, string(data))
+}
+
+func TestReflectNullable(t *testing.T) {
+	t.Parallel()
+
+	type values struct {
+		Count   *int              `json:"count"`
+		Names   []string          `json:"names"`
+		Scores  map[string]*int   `json:"scores"`
+		Nested  *address          `json:"nested"`
+		Omitted []string          `json:"omitted,omitempty"`
+		Raw     json.RawMessage   `json:"raw"`
+		Labels  map[string]string `json:"labels,omitempty"`
+	}
+
+	def, err := jsonschema.Reflect(values{})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(def.Properties["count"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":["integer","null"],"properties":{}}`, string(data))
+	assert.True(t, def.Properties["names"].Nullable)
+	assert.True(t, def.Properties["scores"].Nullable)
+	assert.True(t, def.Properties["nested"].Nullable)
+	assert.False(t, def.Properties["omitted"].Nullable)
+	assert.False(t, def.Properties["labels"].Nullable)
+
+	// the zero values, encoded by encoding/json, match their schema.
+	for _, v := range []any{values{}, person{Role: "user"}, tree{}, node{}, []*node{nil}} {
+		def, err := jsonschema.Reflect(v)
+		require.NoError(t, err)
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		var value any
+		require.NoError(t, json.Unmarshal(data, &value))
+		require.NoError(t, jsonschema.Validate(def, value), "%T: %s", v, data)
+	}
+
+	// null is still rejected where encoding/json never produces it.
+	err = jsonschema.Validate(def, map[string]any{
+		"count": nil, "names": nil, "scores": map[string]any{"a": nil}, "nested": nil, "raw": nil,
+		"omitted": nil,
+	})
+	require.EqualError(t, err, "$.omitted: expected array, got null")
+}
+
+func TestReflectErrors(t *testing.T) {
+	t.Parallel()
+
+	type withFunc struct {
+		F func() `json:"f"`
+	}
+	_, err := jsonschema.Reflect(withFunc{})
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+
+	_, err = jsonschema.Reflect(map[bool]string{})
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	def, err := jsonschema.Reflect(person{})
+	require.NoError(t, err)
+
+	valid := map[string]any{
+		"id": "1", "name": "Ada", "role": "admin", "born": "1815-12-10T00:00:00Z",
+		"address": map[string]any{"street": "Main St"}, "Untagged": true,
+		"tags": []any{"math"},
+	}
+	require.NoError(t, jsonschema.Validate(def, valid))
+
+	invalid := map[string]any{
+		"id": "1", "name": "Ada", "role": "owner", "born": "1815-12-10T00:00:00Z",
+		"address": map[string]any{"city": 3.0}, "Untagged": true,
+		"tags": []any{"math", 1.0}, "extra": 1.0,
+	}
+	err = jsonschema.Validate(def, invalid)
+	var errs jsonschema.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{
+		`$.address: missing required property "street"`,
+		`$.address.city: expected string, got number`,
+		`$: unexpected property "extra"`,
+		`$.role: value "owner" is not one of ["admin" "user"]`,
+		`$.tags[1]: expected string, got number`,
+	}, errorStrings(errs))
+}
+
+func TestValidateRefs(t *testing.T) {
+	t.Parallel()
+
+	def, err := jsonschema.Reflect(tree{})
+	require.NoError(t, err)
+
+	var value any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"root": {"value": "a", "children": [{"value": "b", "children": [{"children": []}]}]},
+		"index": {"a": {"value": "a"}, "b": {"value": 1}}
+	}`), &value))
+	err = jsonschema.Validate(def, value)
+	var errs jsonschema.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{
+		`$.index.b.value: expected string, got number`,
+		`$.root.children[0].children[0]: missing required property "value"`,
+	}, errorStrings(errs))
+
+	loop := &jsonschema.Definition{Ref: "#/$defs/a", Defs: map[string]jsonschema.Definition{
+		"a": {Ref: "#/$defs/a"},
+	}}
+	require.EqualError(t, jsonschema.Validate(loop, "x"), `$: unresolvable reference "#/$defs/a"`)
+	require.EqualError(t, jsonschema.Validate(&jsonschema.Definition{Ref: "#/$defs/b"}, "x"),
+		`$: unresolvable reference "#/$defs/b"`)
+}
+
+func TestValidateInteger(t *testing.T) {
+	t.Parallel()
+
+	def := &jsonschema.Definition{Type: jsonschema.Integer}
+	require.NoError(t, jsonschema.Validate(def, 3.0))
+	require.EqualError(t, jsonschema.Validate(def, 3.5), "$: expected integer, got number")
+}
+
+func errorStrings(errs jsonschema.ValidationErrors) []string {
+	s := make([]string, len(errs))
+	for i, err := range errs {
+		s[i] = err.Error()
+	}
+	return s
+}
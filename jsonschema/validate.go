@@ -0,0 +1,218 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ValidationError describes a value that does not match its schema.
+type ValidationError struct {
+	// Path locates the value in the validated document, such as
+	// "$.items[2].name".
+	Path string
+	// Message describes the mismatch.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors are all the mismatches found by Validate.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks that value matches the schema def. value is a decoded JSON
+// document, as produced by json.Unmarshal into an `any`. If the value does not
+// match, the returned error is a ValidationErrors listing every mismatch.
+//
+// References in def are resolved against def itself: "#" refers to def, and
+// "#/$defs/<name>" to a schema in its Defs.
+func Validate(def *Definition, value any) error {
+	v := validator{root: def}
+	v.validate(def, value, "$")
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// maxRefs is the maximum number of references followed in a row, to stop on
+// references that refer to themselves.
+const maxRefs = 32
+
+type validator struct {
+	root *Definition
+	errs ValidationErrors
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(def *Definition, value any, path string) {
+	if value == nil && def.Nullable {
+		return
+	}
+	for refs := 0; def.Ref != ""; refs++ {
+		resolved, ok := v.resolve(def.Ref)
+		if !ok || refs == maxRefs {
+			v.fail(path, "unresolvable reference %q", def.Ref)
+			return
+		}
+		def = resolved
+	}
+
+	if def.Type != "" && !hasType(value, def.Type) {
+		v.fail(path, "expected %s, got %s", def.Type, typeOf(value))
+		return
+	}
+	if len(def.Enum) > 0 && !inEnum(def.Enum, value) {
+		v.fail(path, "value %s is not one of %q", formatValue(value), def.Enum)
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		v.validateObject(def, value, path)
+	case []any:
+		if def.Items != nil {
+			for i, item := range value {
+				v.validate(def.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+}
+
+func (v *validator) validateObject(def *Definition, object map[string]any, path string) {
+	for _, name := range def.Required {
+		if _, ok := object[name]; !ok {
+			v.fail(path, "missing required property %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + "." + name
+		if prop, ok := def.Properties[name]; ok {
+			v.validate(&prop, object[name], propertyPath)
+			continue
+		}
+		switch additional := def.AdditionalProperties.(type) {
+		case bool:
+			if !additional {
+				v.fail(path, "unexpected property %q", name)
+			}
+		case Definition:
+			v.validate(&additional, object[name], propertyPath)
+		case *Definition:
+			v.validate(additional, object[name], propertyPath)
+		}
+	}
+}
+
+// resolve returns the schema that ref refers to.
+func (v *validator) resolve(ref string) (*Definition, bool) {
+	if ref == "#" {
+		return v.root, true
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, false
+	}
+	def, ok := v.root.Defs[name]
+	return &def, ok
+}
+
+func hasType(value any, t DataType) bool {
+	switch t {
+	case Object:
+		_, ok := value.(map[string]any)
+		return ok
+	case Array:
+		_, ok := value.([]any)
+		return ok
+	case String:
+		_, ok := value.(string)
+		return ok
+	case Boolean:
+		_, ok := value.(bool)
+		return ok
+	case Null:
+		return value == nil
+	case Number:
+		_, ok := number(value)
+		return ok
+	case Integer:
+		n, ok := number(value)
+		return ok && n == math.Trunc(n)
+	default:
+		return true
+	}
+}
+
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return string(Null)
+	case map[string]any:
+		return string(Object)
+	case []any:
+		return string(Array)
+	case string:
+		return string(String)
+	case bool:
+		return string(Boolean)
+	case float64, json.Number:
+		return string(Number)
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func inEnum(enum []string, value any) bool {
+	s, ok := value.(string)
+	if !ok {
+		s = formatValue(value)
+	}
+	for _, e := range enum {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -2,6 +2,8 @@ package anthropic
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -17,6 +19,7 @@ var (
 	ErrMissingToken  = errors.New("missing the Anthropic API key, set it in the ANTHROPIC_API_KEY environment variable")
 
 	ErrUnexpectedResponseLength = errors.New("unexpected length of response")
+	ErrInvalidToolChoice        = errors.New("invalid tool choice")
 )
 
 const (
@@ -123,15 +126,23 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 		return nil, err
 	}
 
+	tools, toolChoice, err := toolsFromOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := o.client.CreateMessage(ctx, &anthropicclient.MessageRequest{
-		Model:         opts.Model,
-		Messages:      chatMessages,
-		System:        systemPrompt,
-		MaxTokens:     opts.MaxTokens,
-		StopWords:     opts.StopWords,
-		Temperature:   opts.Temperature,
-		TopP:          opts.TopP,
-		StreamingFunc: opts.StreamingFunc,
+		Model:              opts.Model,
+		Messages:           chatMessages,
+		System:             systemPrompt,
+		MaxTokens:          opts.MaxTokens,
+		StopWords:          opts.StopWords,
+		Temperature:        opts.Temperature,
+		TopP:               opts.TopP,
+		Tools:              tools,
+		ToolChoice:         toolChoice,
+		StreamingFunc:      opts.StreamingFunc,
+		StreamingChunkFunc: opts.StreamingChunkFunc,
 	})
 	if err != nil {
 		if o.CallbacksHandler != nil {
@@ -139,25 +150,132 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 		}
 		return nil, err
 	}
+	if result == nil {
+		return nil, ErrEmptyResponse
+	}
 
-	choices := make([]*llms.ContentChoice, len(result.Content))
-	for i, content := range result.Content {
-		choices[i] = &llms.ContentChoice{
-			Content:    content.Text,
-			StopReason: result.StopReason,
-			GenerationInfo: map[string]any{
-				"InputTokens":  result.Usage.InputTokens,
-				"OutputTokens": result.Usage.OutputTokens,
-			},
+	promptTokens := result.Usage.InputTokens + result.Usage.CacheCreationInputTokens + result.Usage.CacheReadInputTokens
+	usage := &llms.Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: result.Usage.OutputTokens,
+		TotalTokens:      promptTokens + result.Usage.OutputTokens,
+		CachedTokens:     result.Usage.CacheReadInputTokens,
+	}
+	// The usage is only complete once the message has stopped, so it is the
+	// last chunk of a stream.
+	if opts.StreamingChunkFunc != nil {
+		err := opts.StreamingChunkFunc(ctx, llms.ContentChunk{Type: llms.ContentChunkTypeUsage, Usage: usage})
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	// All content blocks of a message are folded into a single choice, so that
+	// tool calls stay attached to any text the model emitted alongside them. A
+	// message without content, such as one stopped by a stop sequence right
+	// away, is an empty choice carrying the stop reason.
+	choice := &llms.ContentChoice{
+		StopReason: result.StopReason,
+		GenerationInfo: map[string]any{
+			"InputTokens":  result.Usage.InputTokens,
+			"OutputTokens": result.Usage.OutputTokens,
+		},
+		Usage: usage,
+	}
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case *anthropicclient.TextContent:
+			choice.Content += c.Text
+		case *anthropicclient.ToolUseContent:
+			choice.ToolCalls = append(choice.ToolCalls, llms.ToolCall{
+				ID:   c.ID,
+				Type: "function",
+				FunctionCall: &llms.FunctionCall{
+					Name:      c.Name,
+					Arguments: string(c.Input),
+				},
+			})
+		default:
+			// Other blocks, such as thinking blocks, have no equivalent in
+			// the choice.
+		}
+	}
+	// populate legacy single-function call field for backwards compatibility
+	if len(choice.ToolCalls) > 0 {
+		choice.FuncCall = choice.ToolCalls[0].FunctionCall
+	}
+
 	resp := &llms.ContentResponse{
-		Choices: choices,
+		Choices: []*llms.ContentChoice{choice},
+		Usage:   usage,
+	}
+	if o.CallbacksHandler != nil {
+		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, resp)
 	}
 	return resp, nil
 }
 
+// toolsFromOptions converts the tools, legacy functions and tool choice of the
+// call options to their Anthropic equivalents.
+func toolsFromOptions(opts *llms.CallOptions) ([]anthropicclient.Tool, *anthropicclient.ToolChoice, error) {
+	tools := make([]anthropicclient.Tool, 0, len(opts.Functions)+len(opts.Tools))
+	for _, fn := range opts.Functions {
+		tools = append(tools, anthropicclient.Tool{
+			Name:        fn.Name,
+			Description: fn.Description,
+			InputSchema: fn.Parameters,
+		})
+	}
+	for _, tool := range opts.Tools {
+		if tool.Type != "function" || tool.Function == nil {
+			return nil, nil, fmt.Errorf("tool type %v not supported", tool.Type)
+		}
+		tools = append(tools, anthropicclient.Tool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: tool.Function.Parameters,
+		})
+	}
+	if len(tools) == 0 {
+		return nil, nil, nil
+	}
+
+	switch choice := opts.ToolChoice.(type) {
+	case nil:
+		return tools, nil, nil
+	case string:
+		switch choice {
+		case "", "auto":
+			return tools, &anthropicclient.ToolChoice{Type: "auto"}, nil
+		case "any", "required":
+			return tools, &anthropicclient.ToolChoice{Type: "any"}, nil
+		case "none":
+			// The tools are still sent, since the messages may refer to them
+			// with tool use and tool result blocks.
+			return tools, &anthropicclient.ToolChoice{Type: "none"}, nil
+		default:
+			return nil, nil, fmt.Errorf("%w: %q", ErrInvalidToolChoice, choice)
+		}
+	case llms.ToolChoice:
+		return tools, toolChoiceFromToolChoice(choice), nil
+	case *llms.ToolChoice:
+		return tools, toolChoiceFromToolChoice(*choice), nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %T", ErrInvalidToolChoice, opts.ToolChoice)
+	}
+}
+
+func toolChoiceFromToolChoice(choice llms.ToolChoice) *anthropicclient.ToolChoice {
+	switch choice.Type {
+	case "auto", "none":
+		return &anthropicclient.ToolChoice{Type: choice.Type}
+	}
+	if choice.Function == nil {
+		return &anthropicclient.ToolChoice{Type: "any"}
+	}
+	return &anthropicclient.ToolChoice{Type: "tool", Name: choice.Function.Name}
+}
+
 func processMessages(messages []llms.MessageContent) ([]anthropicclient.ChatMessage, string, error) {
 	chatMessages := make([]anthropicclient.ChatMessage, 0, len(messages))
 	systemPrompt := ""
@@ -181,7 +299,22 @@ func processMessages(messages []llms.MessageContent) ([]anthropicclient.ChatMess
 				return nil, "", err
 			}
 			chatMessages = append(chatMessages, chatMessage)
-		case llms.ChatMessageTypeGeneric, llms.ChatMessageTypeFunction, llms.ChatMessageTypeTool:
+		case llms.ChatMessageTypeTool:
+			chatMessage, err := handleToolMessage(msg)
+			if err != nil {
+				return nil, "", err
+			}
+			// Anthropic expects the results of parallel tool calls to be sent
+			// back in a single user message, so consecutive tool messages are
+			// merged.
+			if n := len(chatMessages); n > 0 && isToolResultMessage(chatMessages[n-1]) {
+				prev, _ := chatMessages[n-1].Content.([]anthropicclient.Content)
+				results, _ := chatMessage.Content.([]anthropicclient.Content)
+				chatMessages[n-1].Content = append(prev, results...)
+				continue
+			}
+			chatMessages = append(chatMessages, chatMessage)
+		case llms.ChatMessageTypeGeneric, llms.ChatMessageTypeFunction:
 			return nil, "", fmt.Errorf("unsupported message type: %v", msg.Role)
 		default:
 			return nil, "", fmt.Errorf("unsupported message type: %v", msg.Role)
@@ -198,21 +331,115 @@ func handleSystemMessage(msg llms.MessageContent) (string, error) {
 }
 
 func handleHumanMessage(msg llms.MessageContent) (anthropicclient.ChatMessage, error) {
-	if textContent, ok := msg.Parts[0].(llms.TextContent); ok {
-		return anthropicclient.ChatMessage{
-			Role:    RoleUser,
-			Content: textContent.Text,
-		}, nil
+	if len(msg.Parts) == 1 {
+		if textContent, ok := msg.Parts[0].(llms.TextContent); ok {
+			return anthropicclient.ChatMessage{
+				Role:    RoleUser,
+				Content: textContent.Text,
+			}, nil
+		}
+	}
+
+	contents := make([]anthropicclient.Content, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		switch p := part.(type) {
+		case llms.TextContent:
+			contents = append(contents, anthropicclient.TextContent{
+				Type: "text",
+				Text: p.Text,
+			})
+		case llms.BinaryContent:
+			contents = append(contents, anthropicclient.ImageContent{
+				Type: "image",
+				Source: anthropicclient.ImageSource{
+					Type:      "base64",
+					MediaType: p.MIMEType,
+					Data:      base64.StdEncoding.EncodeToString(p.Data),
+				},
+			})
+		default:
+			return anthropicclient.ChatMessage{}, fmt.Errorf("invalid content type for human message: %T", part)
+		}
 	}
-	return anthropicclient.ChatMessage{}, errors.New("invalid content type for human message")
+	return anthropicclient.ChatMessage{
+		Role:    RoleUser,
+		Content: contents,
+	}, nil
 }
 
 func handleAIMessage(msg llms.MessageContent) (anthropicclient.ChatMessage, error) {
-	if textContent, ok := msg.Parts[0].(llms.TextContent); ok {
-		return anthropicclient.ChatMessage{
-			Role:    RoleAssistant,
-			Content: textContent.Text,
-		}, nil
+	if len(msg.Parts) == 1 {
+		if textContent, ok := msg.Parts[0].(llms.TextContent); ok {
+			return anthropicclient.ChatMessage{
+				Role:    RoleAssistant,
+				Content: textContent.Text,
+			}, nil
+		}
+	}
+
+	contents := make([]anthropicclient.Content, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		switch p := part.(type) {
+		case llms.TextContent:
+			contents = append(contents, anthropicclient.TextContent{
+				Type: "text",
+				Text: p.Text,
+			})
+		case llms.ToolCall:
+			if p.FunctionCall == nil {
+				return anthropicclient.ChatMessage{}, errors.New("tool call without function call")
+			}
+			input := json.RawMessage(p.FunctionCall.Arguments)
+			if len(input) == 0 {
+				input = json.RawMessage("{}")
+			}
+			if !json.Valid(input) {
+				return anthropicclient.ChatMessage{}, fmt.Errorf("invalid arguments for tool call %v", p.ID)
+			}
+			contents = append(contents, anthropicclient.ToolUseContent{
+				Type:  "tool_use",
+				ID:    p.ID,
+				Name:  p.FunctionCall.Name,
+				Input: input,
+			})
+		default:
+			return anthropicclient.ChatMessage{}, fmt.Errorf("invalid content type for AI message: %T", part)
+		}
+	}
+	return anthropicclient.ChatMessage{
+		Role:    RoleAssistant,
+		Content: contents,
+	}, nil
+}
+
+func handleToolMessage(msg llms.MessageContent) (anthropicclient.ChatMessage, error) {
+	contents := make([]anthropicclient.Content, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		p, ok := part.(llms.ToolCallResponse)
+		if !ok {
+			return anthropicclient.ChatMessage{}, fmt.Errorf("invalid content type for tool message: %T", part)
+		}
+		contents = append(contents, anthropicclient.ToolResultContent{
+			Type:      "tool_result",
+			ToolUseID: p.ToolCallID,
+			Content:   p.Content,
+		})
+	}
+	return anthropicclient.ChatMessage{
+		Role:    RoleUser,
+		Content: contents,
+	}, nil
+}
+
+func isToolResultMessage(msg anthropicclient.ChatMessage) bool {
+	contents, ok := msg.Content.([]anthropicclient.Content)
+	if !ok || len(contents) == 0 {
+		return false
+	}
+	for _, c := range contents {
+		if _, ok := c.(anthropicclient.ToolResultContent); !ok {
+			return false
+		}
 	}
-	return anthropicclient.ChatMessage{}, errors.New("invalid content type for AI message")
+	return true
 }
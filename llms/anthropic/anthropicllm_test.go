@@ -0,0 +1,344 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var weatherTool = llms.Tool{
+	Type: "function",
+	Function: &llms.FunctionDefinition{
+		Name:        "getCurrentWeather",
+		Description: "Get the current weather in a given location",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"location": map[string]any{"type": "string"},
+			},
+			"required": []string{"location"},
+		},
+	},
+}
+
+func newTestServer(t *testing.T, response string, requests *[]map[string]any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var req map[string]any
+		assert.NoError(t, json.Unmarshal(body, &req))
+		*requests = append(*requests, req)
+
+		if stream, _ := req["stream"].(bool); stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+		}
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGenerateContentToolUse(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newTestServer(t, `{
+		"id": "msg_01",
+		"type": "message",
+		"role": "assistant",
+		"model": "claude-3-haiku-20240307",
+		"stop_reason": "tool_use",
+		"content": [
+			{"type": "text", "text": "Let me check."},
+			{"type": "tool_use", "id": "toolu_01", "name": "getCurrentWeather", "input": {"location": "Boston"}},
+			{"type": "tool_use", "id": "toolu_02", "name": "getCurrentWeather", "input": {"location": "Chicago"}}
+		],
+		"usage": {"input_tokens": 10, "output_tokens": 20, "cache_read_input_tokens": 100}
+	}`, &requests)
+
+	llm, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	resp, err := llm.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston and Chicago?")},
+		llms.WithTools([]llms.Tool{weatherTool}),
+		llms.WithToolChoice(llms.ToolChoice{
+			Type:     "function",
+			Function: &llms.FunctionReference{Name: "getCurrentWeather"},
+		}),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	sentTools, err := json.Marshal(requests[0]["tools"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{
+		"name": "getCurrentWeather",
+		"description": "Get the current weather in a given location",
+		"input_schema": {
+			"type": "object",
+			"properties": {"location": {"type": "string"}},
+			"required": ["location"]
+		}
+	}]`, string(sentTools))
+	assert.Equal(t, map[string]any{"type": "tool", "name": "getCurrentWeather"}, requests[0]["tool_choice"])
+
+	require.Len(t, resp.Choices, 1)
+	c := resp.Choices[0]
+	assert.Equal(t, "Let me check.", c.Content)
+	assert.Equal(t, "tool_use", c.StopReason)
+	require.Len(t, c.ToolCalls, 2)
+	assert.Equal(t, "toolu_01", c.ToolCalls[0].ID)
+	assert.Equal(t, "getCurrentWeather", c.ToolCalls[0].FunctionCall.Name)
+	assert.JSONEq(t, `{"location": "Boston"}`, c.ToolCalls[0].FunctionCall.Arguments)
+	assert.JSONEq(t, `{"location": "Chicago"}`, c.ToolCalls[1].FunctionCall.Arguments)
+	assert.Equal(t, c.ToolCalls[0].FunctionCall, c.FuncCall)
+	assert.Equal(t, &llms.Usage{PromptTokens: 110, CompletionTokens: 20, TotalTokens: 130, CachedTokens: 100}, resp.Usage)
+}
+
+func TestGenerateContentEmpty(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newTestServer(t, `{
+		"id": "msg_03",
+		"type": "message",
+		"role": "assistant",
+		"stop_reason": "stop_sequence",
+		"content": [],
+		"usage": {"input_tokens": 10, "output_tokens": 0}
+	}`, &requests)
+
+	llm, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	resp, err := llm.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Say nothing.")},
+		llms.WithStopWords([]string{"Hello"}),
+	)
+	require.NoError(t, err)
+	require.Len(t, resp.Choices, 1)
+	assert.Empty(t, resp.Choices[0].Content)
+	assert.Empty(t, resp.Choices[0].ToolCalls)
+	assert.Equal(t, "stop_sequence", resp.Choices[0].StopReason)
+}
+
+func TestGenerateContentToolChoiceNone(t *testing.T) {
+	t.Parallel()
+
+	for _, choice := range []any{"none", llms.ToolChoice{Type: "none"}} {
+		var requests []map[string]any
+		srv := newTestServer(t, `{
+			"id": "msg_04",
+			"type": "message",
+			"role": "assistant",
+			"stop_reason": "end_turn",
+			"content": [{"type": "text", "text": "Sunny."}],
+			"usage": {"input_tokens": 30, "output_tokens": 2}
+		}`, &requests)
+
+		llm, err := New(WithToken("test"), WithBaseURL(srv.URL))
+		require.NoError(t, err)
+
+		_, err = llm.GenerateContent(context.Background(),
+			[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston?")},
+			llms.WithTools([]llms.Tool{weatherTool}),
+			llms.WithToolChoice(choice),
+		)
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Len(t, requests[0]["tools"], 1)
+		assert.Equal(t, map[string]any{"type": "none"}, requests[0]["tool_choice"])
+	}
+}
+
+func TestGenerateContentToolResult(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newTestServer(t, `{
+		"id": "msg_02",
+		"type": "message",
+		"role": "assistant",
+		"stop_reason": "end_turn",
+		"content": [{"type": "text", "text": "Sunny in Boston, rainy in Chicago."}],
+		"usage": {"input_tokens": 30, "output_tokens": 8}
+	}`, &requests)
+
+	llm, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	messages := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston and Chicago?"),
+		{
+			Role: llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{
+				llms.ToolCall{ID: "toolu_01", Type: "function", FunctionCall: &llms.FunctionCall{Name: "getCurrentWeather", Arguments: `{"location":"Boston"}`}},
+				llms.ToolCall{ID: "toolu_02", Type: "function", FunctionCall: &llms.FunctionCall{Name: "getCurrentWeather", Arguments: `{"location":"Chicago"}`}},
+			},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "toolu_01", Name: "getCurrentWeather", Content: "sunny"}},
+		},
+		{
+			Role:  llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "toolu_02", Name: "getCurrentWeather", Content: "rainy"}},
+		},
+	}
+	resp, err := llm.GenerateContent(context.Background(), messages, llms.WithTools([]llms.Tool{weatherTool}))
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny in Boston, rainy in Chicago.", resp.Choices[0].Content)
+
+	require.Len(t, requests, 1)
+	sent, err := json.Marshal(requests[0]["messages"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"role": "user", "content": "Weather in Boston and Chicago?"},
+		{"role": "assistant", "content": [
+			{"type": "tool_use", "id": "toolu_01", "name": "getCurrentWeather", "input": {"location": "Boston"}},
+			{"type": "tool_use", "id": "toolu_02", "name": "getCurrentWeather", "input": {"location": "Chicago"}}
+		]},
+		{"role": "user", "content": [
+			{"type": "tool_result", "tool_use_id": "toolu_01", "content": "sunny"},
+			{"type": "tool_result", "tool_use_id": "toolu_02", "content": "rainy"}
+		]}
+	]`, string(sent))
+	assert.NotContains(t, requests[0], "tool_choice")
+}
+
+func TestGenerateContentThinking(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newTestServer(t, `{
+		"id": "msg_05",
+		"type": "message",
+		"role": "assistant",
+		"model": "claude-3-7-sonnet-20250219",
+		"stop_reason": "end_turn",
+		"content": [
+			{"type": "thinking", "thinking": "Boston is in Massachusetts.", "signature": "EqQB"},
+			{"type": "redacted_thinking", "data": "EmwK"},
+			{"type": "text", "text": "It is in Massachusetts."}
+		],
+		"usage": {"input_tokens": 10, "output_tokens": 20}
+	}`, &requests)
+
+	llm, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	resp, err := llm.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Where is Boston?")})
+	require.NoError(t, err)
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, "It is in Massachusetts.", resp.Choices[0].Content)
+	assert.Empty(t, resp.Choices[0].ToolCalls)
+}
+
+func TestGenerateContentToolUseStreaming(t *testing.T) {
+	t.Parallel()
+
+	events := []string{
+		`{"type":"message_start","message":{"id":"msg_03","type":"message","role":"assistant","model":"claude-3-haiku-20240307","usage":{"input_tokens":12,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"The user wants the weather."}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"signature_delta","signature":"EqQB"}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"Checking"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"content_block_start","index":2,"content_block":{"type":"tool_use","id":"toolu_01","name":"getCurrentWeather","input":{}}}`,
+		`{"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":"{\"location\":"}}`,
+		`{"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":" \"Boston\"}"}}`,
+		`{"type":"content_block_stop","index":2}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":25}}`,
+		`{"type":"message_stop"}`,
+	}
+	var body string
+	for _, e := range events {
+		body += "data: " + e + "\n\n"
+	}
+
+	var requests []map[string]any
+	srv := newTestServer(t, body, &requests)
+
+	llm, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	var chunks []string
+	resp, err := llm.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston?")},
+		llms.WithTools([]llms.Tool{weatherTool}),
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Checking"}, chunks)
+	c := resp.Choices[0]
+	assert.Equal(t, "Checking", c.Content)
+	assert.Equal(t, "tool_use", c.StopReason)
+	require.Len(t, c.ToolCalls, 1)
+	assert.Equal(t, "toolu_01", c.ToolCalls[0].ID)
+	assert.JSONEq(t, `{"location": "Boston"}`, c.ToolCalls[0].FunctionCall.Arguments)
+	assert.Equal(t, 25, c.GenerationInfo["OutputTokens"])
+	assert.Equal(t, &llms.Usage{PromptTokens: 12, CompletionTokens: 25, TotalTokens: 37}, resp.Usage)
+	assert.Equal(t, resp.Usage, c.Usage)
+}
+
+func TestStreamContent(t *testing.T) {
+	t.Parallel()
+
+	events := []string{
+		`{"type":"message_start","message":{"id":"msg_04","type":"message","role":"assistant","model":"claude-3-haiku-20240307","usage":{"input_tokens":12,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Checking"}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_01","name":"getCurrentWeather","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"location\": \"Boston\"}"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":25}}`,
+		`{"type":"message_stop"}`,
+	}
+	var body string
+	for _, e := range events {
+		body += "data: " + e + "\n\n"
+	}
+
+	var requests []map[string]any
+	srv := newTestServer(t, body, &requests)
+
+	llm, err := New(WithToken("test"), WithBaseURL(srv.URL))
+	require.NoError(t, err)
+
+	var chunks []llms.ContentChunk
+	for chunk := range llms.StreamContent(context.Background(), llm,
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston?")},
+		llms.WithTools([]llms.Tool{weatherTool}),
+	) {
+		chunks = append(chunks, chunk)
+	}
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, true, requests[0]["stream"])
+	assert.Equal(t, []llms.ContentChunk{
+		{Type: llms.ContentChunkTypeText, Text: "Checking"},
+		{Type: llms.ContentChunkTypeToolCall, ToolCall: &llms.ToolCallChunk{Index: 0, ID: "toolu_01", Name: "getCurrentWeather"}},
+		{Type: llms.ContentChunkTypeToolCall, ToolCall: &llms.ToolCallChunk{Index: 0, Arguments: `{"location": "Boston"}`}},
+		{Type: llms.ContentChunkTypeStop, StopReason: "tool_use"},
+		{Type: llms.ContentChunkTypeUsage, Usage: &llms.Usage{PromptTokens: 12, CompletionTokens: 25, TotalTokens: 37}},
+	}, chunks)
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 const (
@@ -122,22 +124,28 @@ type MessageRequest struct {
 	TopP        float64       `json:"top_p,omitempty"`
 	StopWords   []string      `json:"stop_sequences,omitempty"`
 	Stream      bool          `json:"stream,omitempty"`
+	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice   `json:"tool_choice,omitempty"`
 
-	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	StreamingFunc      func(ctx context.Context, chunk []byte) error            `json:"-"`
+	StreamingChunkFunc func(ctx context.Context, chunk llms.ContentChunk) error `json:"-"`
 }
 
 // CreateMessage creates message for the messages api.
 func (c *Client) CreateMessage(ctx context.Context, r *MessageRequest) (*MessageResponsePayload, error) {
 	resp, err := c.createMessage(ctx, &messagePayload{
-		Model:         r.Model,
-		Messages:      r.Messages,
-		System:        r.System,
-		Temperature:   r.Temperature,
-		MaxTokens:     r.MaxTokens,
-		StopWords:     r.StopWords,
-		TopP:          r.TopP,
-		Stream:        r.Stream,
-		StreamingFunc: r.StreamingFunc,
+		Model:              r.Model,
+		Messages:           r.Messages,
+		System:             r.System,
+		Temperature:        r.Temperature,
+		MaxTokens:          r.MaxTokens,
+		StopWords:          r.StopWords,
+		TopP:               r.TopP,
+		Stream:             r.Stream,
+		Tools:              r.Tools,
+		ToolChoice:         r.ToolChoice,
+		StreamingFunc:      r.StreamingFunc,
+		StreamingChunkFunc: r.StreamingChunkFunc,
 	})
 	if err != nil {
 		return nil, err
@@ -185,7 +193,7 @@ func (c *Client) decodeError(resp *http.Response) error {
 
 	var errResp errorMessage
 	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-		return errors.New(msg) // nolint:goerr113
+		return llms.NewProviderError(resp.StatusCode, resp.Header, errors.New(msg)) // nolint:goerr113
 	}
-	return fmt.Errorf("%s: %s", msg, errResp.Error.Message) // nolint:goerr113
+	return llms.NewProviderError(resp.StatusCode, resp.Header, fmt.Errorf("%s: %s", msg, errResp.Error.Message)) // nolint:goerr113
 }
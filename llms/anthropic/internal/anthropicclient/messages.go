@@ -10,11 +10,103 @@ import (
 	"log"
 	"net/http"
 	"strings"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
+// ChatMessage is a message in a messages API request. Content is either a
+// plain string or a slice of Content blocks.
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"`
+}
+
+// Content is a single content block of a message.
+type Content interface {
+	GetType() string
+}
+
+// TextContent is a text content block.
+type TextContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (tc TextContent) GetType() string {
+	return tc.Type
+}
+
+// ImageContent is an image content block.
+type ImageContent struct {
+	Type   string      `json:"type"`
+	Source ImageSource `json:"source"`
+}
+
+func (ic ImageContent) GetType() string {
+	return ic.Type
+}
+
+// ImageSource is the source of an image content block.
+type ImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type"`
+	Data      string `json:"data"`
+}
+
+// ToolUseContent is a tool_use content block, emitted by the model when it
+// wants to invoke a tool.
+type ToolUseContent struct {
+	Type  string          `json:"type"`
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+func (tuc ToolUseContent) GetType() string {
+	return tuc.Type
+}
+
+// ToolResultContent is a tool_result content block, sent back to the model
+// with the result of a tool invocation.
+type ToolResultContent struct {
+	Type      string `json:"type"`
+	ToolUseID string `json:"tool_use_id"`
+	Content   string `json:"content"`
+	IsError   bool   `json:"is_error,omitempty"`
+}
+
+func (trc ToolResultContent) GetType() string {
+	return trc.Type
+}
+
+// OtherContent is a content block of a type the client does not handle, such
+// as a thinking block, kept as received.
+type OtherContent struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (oc OtherContent) GetType() string {
+	return oc.Type
+}
+
+// MarshalJSON returns the block as received, so that it can be sent back.
+func (oc OtherContent) MarshalJSON() ([]byte, error) {
+	return oc.Raw, nil
+}
+
+// Tool is a tool definition the model may use.
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema"`
+}
+
+// ToolChoice controls how the model uses the provided tools. Type is one of
+// "auto", "any" or "tool"; Name is only set for "tool".
+type ToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
 }
 
 type messagePayload struct {
@@ -26,25 +118,77 @@ type messagePayload struct {
 	Stream      bool          `json:"stream,omitempty"`
 	Temperature float64       `json:"temperature"`
 	TopP        float64       `json:"top_p,omitempty"`
+	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice   `json:"tool_choice,omitempty"`
 
-	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	StreamingFunc      func(ctx context.Context, chunk []byte) error            `json:"-"`
+	StreamingChunkFunc func(ctx context.Context, chunk llms.ContentChunk) error `json:"-"`
 }
 
 type MessageResponsePayload struct {
-	Content []struct {
-		Text string `json:"text"`
+	Content      []Content `json:"content"`
+	ID           string    `json:"id"`
+	Model        string    `json:"model"`
+	Role         string    `json:"role"`
+	StopReason   string    `json:"stop_reason"`
+	StopSequence string    `json:"stop_sequence"`
+	Type         string    `json:"type"`
+	Usage        Usage     `json:"usage"`
+}
+
+// Usage is the token usage of a message. InputTokens does not include the
+// tokens written to or read from the prompt cache.
+type Usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+func (m *MessageResponsePayload) UnmarshalJSON(data []byte) error {
+	type alias MessageResponsePayload
+	aux := struct {
+		Content []json.RawMessage `json:"content"`
+		*alias
+	}{
+		alias: (*alias)(m),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	m.Content = make([]Content, 0, len(aux.Content))
+	for _, raw := range aux.Content {
+		content, err := unmarshalContent(raw)
+		if err != nil {
+			return err
+		}
+		m.Content = append(m.Content, content)
+	}
+	return nil
+}
+
+func unmarshalContent(raw json.RawMessage) (Content, error) {
+	var typed struct {
 		Type string `json:"type"`
-	} `json:"content"`
-	ID           string `json:"id"`
-	Model        string `json:"model"`
-	Role         string `json:"role"`
-	StopReason   string `json:"stop_reason"`
-	StopSequence string `json:"stop_sequence"`
-	Type         string `json:"type"`
-	Usage        struct {
-		InputTokens  int `json:"input_tokens"`
-		OutputTokens int `json:"output_tokens"`
-	} `json:"usage"`
+	}
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, err
+	}
+
+	var content Content
+	switch typed.Type {
+	case "text":
+		content = &TextContent{}
+	case "tool_use":
+		content = &ToolUseContent{}
+	default:
+		return &OtherContent{Type: typed.Type, Raw: append(json.RawMessage(nil), raw...)}, nil
+	}
+	if err := json.Unmarshal(raw, content); err != nil {
+		return nil, err
+	}
+	return content, nil
 }
 
 func (c *Client) setMessageDefaults(payload *messagePayload) {
@@ -68,7 +212,7 @@ func (c *Client) setMessageDefaults(payload *messagePayload) {
 	default:
 		payload.Model = defaultModel
 	}
-	if payload.StreamingFunc != nil {
+	if payload.StreamingFunc != nil || payload.StreamingChunkFunc != nil {
 		payload.Stream = true
 	}
 }
@@ -91,7 +235,7 @@ func (c *Client) createMessage(ctx context.Context, payload *messagePayload) (*M
 		return nil, c.decodeError(resp)
 	}
 
-	if payload.StreamingFunc != nil {
+	if payload.Stream {
 		return parseStreamingMessageResponse(ctx, resp, payload)
 	}
 
@@ -162,13 +306,13 @@ func processStreamEvent(ctx context.Context, event map[string]interface{}, paylo
 	case "message_start":
 		return handleMessageStartEvent(event, response)
 	case "content_block_start":
-		return handleContentBlockStartEvent(event, response)
+		return handleContentBlockStartEvent(ctx, event, response, payload)
 	case "content_block_delta":
 		return handleContentBlockDeltaEvent(ctx, event, response, payload)
 	case "content_block_stop":
-		// Nothing to do here
+		return handleContentBlockStopEvent(event, response)
 	case "message_delta":
-		return handleMessageDeltaEvent(event, response)
+		return handleMessageDeltaEvent(ctx, event, response, payload)
 	case "message_stop":
 		eventChan <- MessageEvent{Response: &response, Err: nil}
 	case "ping":
@@ -200,32 +344,94 @@ func handleMessageStartEvent(event map[string]interface{}, response MessageRespo
 	response.Role = getString(message, "role")
 	response.Type = getString(message, "type")
 	response.Usage.InputTokens = int(inputTokens)
+	if cacheCreationInputTokens, ok := usage["cache_creation_input_tokens"].(float64); ok {
+		response.Usage.CacheCreationInputTokens = int(cacheCreationInputTokens)
+	}
+	if cacheReadInputTokens, ok := usage["cache_read_input_tokens"].(float64); ok {
+		response.Usage.CacheReadInputTokens = int(cacheReadInputTokens)
+	}
 
 	return response, nil
 }
 
-func handleContentBlockStartEvent(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+func handleContentBlockStartEvent(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload) (MessageResponsePayload, error) {
 	indexValue, ok := event["index"].(float64)
 	if !ok {
 		return response, errors.New("invalid index field type")
 	}
 	index := int(indexValue)
 
-	if len(response.Content) <= index {
-		response.Content = append(response.Content, struct {
-			Text string `json:"text"`
-			Type string `json:"type"`
-		}{})
+	contentBlock, ok := event["content_block"].(map[string]interface{})
+	if !ok {
+		return response, errors.New("invalid content_block field type")
+	}
+
+	var content Content
+	switch blockType := getString(contentBlock, "type"); blockType {
+	case "text":
+		content = &TextContent{
+			Type: blockType,
+			Text: getString(contentBlock, "text"),
+		}
+	case "tool_use":
+		// The input is streamed as partial JSON deltas, so it starts empty here.
+		content = &ToolUseContent{
+			Type: blockType,
+			ID:   getString(contentBlock, "id"),
+			Name: getString(contentBlock, "name"),
+		}
+	default:
+		// The block is kept, as the deltas refer to the blocks by index, but
+		// its deltas are ignored.
+		raw, err := json.Marshal(contentBlock)
+		if err != nil {
+			return response, err
+		}
+		content = &OtherContent{Type: blockType, Raw: raw}
+	}
+
+	if len(response.Content) > index {
+		return response, nil
+	}
+	response.Content = append(response.Content, content)
+
+	if toolUseContent, ok := content.(*ToolUseContent); ok && payload.StreamingChunkFunc != nil {
+		err := payload.StreamingChunkFunc(ctx, llms.ContentChunk{
+			Type: llms.ContentChunkTypeToolCall,
+			ToolCall: &llms.ToolCallChunk{
+				Index: toolUseIndex(response.Content, index),
+				ID:    toolUseContent.ID,
+				Name:  toolUseContent.Name,
+			},
+		})
+		if err != nil {
+			return response, fmt.Errorf("streaming chunk func returned an error: %w", err)
+		}
 	}
 	return response, nil
 }
 
+// toolUseIndex returns the position of the content block at index among the
+// tool_use blocks of content.
+func toolUseIndex(content []Content, index int) int {
+	n := 0
+	for _, c := range content[:index] {
+		if _, ok := c.(*ToolUseContent); ok {
+			n++
+		}
+	}
+	return n
+}
+
 func handleContentBlockDeltaEvent(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload) (MessageResponsePayload, error) {
 	indexValue, ok := event["index"].(float64)
 	if !ok {
 		return response, errors.New("invalid index field type")
 	}
 	index := int(indexValue)
+	if len(response.Content) <= index {
+		return response, errors.New("content index out of range")
+	}
 
 	delta, ok := event["delta"].(map[string]interface{})
 	if !ok {
@@ -236,38 +442,87 @@ func handleContentBlockDeltaEvent(ctx context.Context, event map[string]interfac
 		return response, errors.New("invalid delta type field type")
 	}
 
-	if deltaType == "text_delta" {
+	var chunk string
+	var contentChunk llms.ContentChunk
+	switch deltaType {
+	case "text_delta":
 		text, ok := delta["text"].(string)
 		if !ok {
 			return response, errors.New("invalid delta text field type")
 		}
-		if len(response.Content) > index {
-			response.Content[index].Text += text
-		} else {
-			return response, errors.New("content index out of range")
+		textContent, ok := response.Content[index].(*TextContent)
+		if !ok {
+			return response, errors.New("text delta for non-text content block")
 		}
-	}
-
-	if payload.StreamingFunc != nil {
-		text, ok := delta["text"].(string)
+		textContent.Text += text
+		chunk = text
+		contentChunk = llms.ContentChunk{Type: llms.ContentChunkTypeText, Text: text}
+	case "input_json_delta":
+		partialJSON, ok := delta["partial_json"].(string)
 		if !ok {
-			return response, errors.New("invalid delta text field type")
+			return response, errors.New("invalid delta partial_json field type")
+		}
+		toolUseContent, ok := response.Content[index].(*ToolUseContent)
+		if !ok {
+			return response, errors.New("input json delta for non-tool_use content block")
+		}
+		toolUseContent.Input = append(toolUseContent.Input, partialJSON...)
+		contentChunk = llms.ContentChunk{
+			Type:     llms.ContentChunkTypeToolCall,
+			ToolCall: &llms.ToolCallChunk{Index: toolUseIndex(response.Content, index), Arguments: partialJSON},
 		}
-		err := payload.StreamingFunc(ctx, []byte(text))
+	default:
+		return response, nil
+	}
+
+	// Only the text is streamed to the StreamingFunc, which has no way to
+	// tell text from tool call arguments.
+	if payload.StreamingFunc != nil && deltaType == "text_delta" {
+		err := payload.StreamingFunc(ctx, []byte(chunk))
 		if err != nil {
 			return response, fmt.Errorf("streaming func returned an error: %w", err)
 		}
 	}
+	if payload.StreamingChunkFunc != nil {
+		err := payload.StreamingChunkFunc(ctx, contentChunk)
+		if err != nil {
+			return response, fmt.Errorf("streaming chunk func returned an error: %w", err)
+		}
+	}
 	return response, nil
 }
 
-func handleMessageDeltaEvent(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+func handleContentBlockStopEvent(event map[string]interface{}, response MessageResponsePayload) (MessageResponsePayload, error) {
+	indexValue, ok := event["index"].(float64)
+	if !ok {
+		return response, errors.New("invalid index field type")
+	}
+	index := int(indexValue)
+	if len(response.Content) <= index {
+		return response, errors.New("content index out of range")
+	}
+
+	// A tool may be invoked without arguments, in which case no input deltas
+	// are sent at all.
+	if toolUseContent, ok := response.Content[index].(*ToolUseContent); ok && len(toolUseContent.Input) == 0 {
+		toolUseContent.Input = json.RawMessage("{}")
+	}
+	return response, nil
+}
+
+func handleMessageDeltaEvent(ctx context.Context, event map[string]interface{}, response MessageResponsePayload, payload *messagePayload) (MessageResponsePayload, error) {
 	delta, ok := event["delta"].(map[string]interface{})
 	if !ok {
 		return response, errors.New("invalid delta field type")
 	}
 	if stopReason, ok := delta["stop_reason"].(string); ok {
 		response.StopReason = stopReason
+		if payload.StreamingChunkFunc != nil {
+			err := payload.StreamingChunkFunc(ctx, llms.ContentChunk{Type: llms.ContentChunkTypeStop, StopReason: stopReason})
+			if err != nil {
+				return response, fmt.Errorf("streaming chunk func returned an error: %w", err)
+			}
+		}
 	}
 
 	usage, ok := event["usage"].(map[string]interface{})
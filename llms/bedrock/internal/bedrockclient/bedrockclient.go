@@ -85,3 +85,13 @@ func processInputMessagesGeneric(messages []Message) string {
 	}
 	return sb.String()
 }
+
+// usageFromTokenCounts returns the usage for the given prompt and completion
+// token counts.
+func usageFromTokenCounts(promptTokens, completionTokens int) *llms.Usage {
+	return &llms.Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}
+}
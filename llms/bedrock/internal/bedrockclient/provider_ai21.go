@@ -119,6 +119,7 @@ func createAi21Completion(ctx context.Context, client *bedrockruntime.Client, mo
 	}
 
 	choices := make([]*llms.ContentChoice, len(output.Completions))
+	var outputTokens int
 	for i, completion := range output.Completions {
 		choices[i] = &llms.ContentChoice{
 			Content:    completion.Data.Text,
@@ -128,8 +129,13 @@ func createAi21Completion(ctx context.Context, client *bedrockruntime.Client, mo
 				"input_tokens":  len(output.Prompt.Tokens),
 				"output_tokens": len(completion.Data.Tokens),
 			},
+			Usage: usageFromTokenCounts(len(output.Prompt.Tokens), len(completion.Data.Tokens)),
 		}
+		outputTokens += len(completion.Data.Tokens)
 	}
 
-	return &llms.ContentResponse{Choices: choices}, nil
+	return &llms.ContentResponse{
+		Choices: choices,
+		Usage:   usageFromTokenCounts(len(output.Prompt.Tokens), outputTokens),
+	}, nil
 }
@@ -101,6 +101,7 @@ func createAmazonCompletion(ctx context.Context,
 	}
 
 	contentChoices := make([]*llms.ContentChoice, len(output.Results))
+	var outputTokens int
 
 	for i, result := range output.Results {
 		contentChoices[i] = &llms.ContentChoice{
@@ -110,10 +111,13 @@ func createAmazonCompletion(ctx context.Context,
 				"input_tokens":  output.InputTextTokenCount,
 				"output_tokens": result.TokenCount,
 			},
+			Usage: usageFromTokenCounts(output.InputTextTokenCount, result.TokenCount),
 		}
+		outputTokens += result.TokenCount
 	}
 
 	return &llms.ContentResponse{
 		Choices: contentChoices,
+		Usage:   usageFromTokenCounts(output.InputTextTokenCount, outputTokens),
 	}, nil
 }
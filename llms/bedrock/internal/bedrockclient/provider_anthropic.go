@@ -180,6 +180,7 @@ func createAnthropicCompletion(ctx context.Context,
 	} else if stopReason := output.StopReason; stopReason != AnthropicCompletionReasonEndTurn && stopReason != AnthropicCompletionReasonStopSequence {
 		return nil, errors.New("completed due to " + stopReason + ". Maybe try increasing max tokens")
 	}
+	usage := usageFromTokenCounts(output.Usage.InputTokens, output.Usage.OutputTokens)
 	Contentchoices := make([]*llms.ContentChoice, len(output.Content))
 	for i, c := range output.Content {
 		Contentchoices[i] = &llms.ContentChoice{
@@ -189,10 +190,12 @@ func createAnthropicCompletion(ctx context.Context,
 				"input_tokens":  output.Usage.InputTokens,
 				"output_tokens": output.Usage.OutputTokens,
 			},
+			Usage: usage,
 		}
 	}
 	return &llms.ContentResponse{
 		Choices: Contentchoices,
+		Usage:   usage,
 	}, nil
 }
 
@@ -241,6 +244,7 @@ func parseStreamingCompletionResponse(ctx context.Context, client *bedrockruntim
 	defer stream.Close()
 
 	contentchoices := []*llms.ContentChoice{{GenerationInfo: map[string]interface{}{}}}
+	var inputTokens, outputTokens int
 	for e := range stream.Events() {
 		if err = stream.Err(); err != nil {
 			return nil, err
@@ -255,6 +259,7 @@ func parseStreamingCompletionResponse(ctx context.Context, client *bedrockruntim
 
 			switch resp.Type {
 			case "message_start":
+				inputTokens = resp.Message.Usage.InputTokens
 				contentchoices[0].GenerationInfo["input_tokens"] = resp.Message.Usage.InputTokens
 			case "content_block_delta":
 				if err = options.StreamingFunc(ctx, []byte(resp.Delta.Text)); err != nil {
@@ -263,13 +268,17 @@ func parseStreamingCompletionResponse(ctx context.Context, client *bedrockruntim
 				contentchoices[0].Content += resp.Delta.Text
 			case "message_delta":
 				contentchoices[0].StopReason = resp.Delta.StopReason
+				outputTokens = resp.Usage.OutputTokens
 				contentchoices[0].GenerationInfo["output_tokens"] = resp.Usage.OutputTokens
 			}
 		}
 	}
 
+	usage := usageFromTokenCounts(inputTokens, outputTokens)
+	contentchoices[0].Usage = usage
 	return &llms.ContentResponse{
 		Choices: contentchoices,
+		Usage:   usage,
 	}, nil
 }
 
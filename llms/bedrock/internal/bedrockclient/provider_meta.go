@@ -83,6 +83,7 @@ func createMetaCompletion(ctx context.Context,
 		return nil, err
 	}
 
+	usage := usageFromTokenCounts(output.PromptTokenCount, output.GenerationTokenCount)
 	return &llms.ContentResponse{
 		Choices: []*llms.ContentChoice{
 			{
@@ -92,7 +93,9 @@ func createMetaCompletion(ctx context.Context,
 					"input_tokens":  output.PromptTokenCount,
 					"output_tokens": output.GenerationTokenCount,
 				},
+				Usage: usage,
 			},
 		},
+		Usage: usage,
 	}, nil
 }
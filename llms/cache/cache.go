@@ -5,22 +5,40 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"sync/atomic"
 
 	"github.com/tmc/langchaingo/llms"
 )
 
 // Backend is the interface that needs to be implemented by cache backends.
 type Backend interface {
-	// Get a value from the cache. If the key is not found, return `nil`.
-	Get(ctx context.Context, key string) *llms.ContentResponse
+	// Get a value from the cache. If the key is not found, return `nil` and
+	// no error.
+	Get(ctx context.Context, key string) (*llms.ContentResponse, error)
 	// Put a value into the cache.
-	Put(ctx context.Context, key string, response *llms.ContentResponse)
+	Put(ctx context.Context, key string, response *llms.ContentResponse) error
+}
+
+// Stats are the statistics of a Cacher.
+type Stats struct {
+	// Hits is the number of calls answered from the cache.
+	Hits int64
+	// Misses is the number of calls that were not found in the cache and
+	// were sent to the LLM.
+	Misses int64
+	// Skips is the number of calls that were not cacheable, see WithSkip.
+	Skips int64
 }
 
 // Cacher is an LLM wrapper that caches the responses from the LLM.
 type Cacher struct {
 	llm   llms.Model
 	cache Backend
+	opts  options
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	skips  atomic.Int64
 }
 
 // assert that `Cacher` implements the `llms.Model` interface.
@@ -28,10 +46,20 @@ var _ llms.Model = (*Cacher)(nil)
 
 // New wraps a Model and adds caching capabilities using the provided
 // cache backend.
-func New(llm llms.Model, backend Backend) *Cacher {
+func New(llm llms.Model, backend Backend, opts ...Option) *Cacher {
 	return &Cacher{
 		llm:   llm,
 		cache: backend,
+		opts:  applyOptions(opts...),
+	}
+}
+
+// Stats returns the statistics of the cacher since it was created.
+func (c *Cacher) Stats() Stats {
+	return Stats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Skips:  c.skips.Load(),
 	}
 }
 
@@ -55,32 +83,60 @@ func (c *Cacher) GenerateContent(ctx context.Context, messages []llms.MessageCon
 		opt(&opts)
 	}
 
+	if c.opts.skip != nil && c.opts.skip(opts) {
+		c.skips.Add(1)
+		return c.llm.GenerateContent(ctx, messages, options...)
+	}
+
 	key, err := hashKeyForCache(messages, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	if response := c.cache.Get(ctx, key); response != nil {
-		if opts.StreamingFunc != nil && len(response.Choices) > 0 {
-			// only stream the first choice.
-			if err := opts.StreamingFunc(ctx, []byte(response.Choices[0].Content)); err != nil {
-				return nil, err
-			}
+	response, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if response != nil {
+		c.hits.Add(1)
+		if err := streamCachedResponse(ctx, opts, response); err != nil {
+			return nil, err
 		}
-
 		return response, nil
 	}
 
-	response, err := c.llm.GenerateContent(ctx, messages, options...)
+	c.misses.Add(1)
+	response, err = c.llm.GenerateContent(ctx, messages, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	c.cache.Put(ctx, key, response)
+	if err := c.cache.Put(ctx, key, response); err != nil {
+		return nil, err
+	}
 
 	return response, nil
 }
 
+// streamCachedResponse replays a cached response to the streaming functions of
+// opts.
+func streamCachedResponse(ctx context.Context, opts llms.CallOptions, response *llms.ContentResponse) error {
+	if opts.StreamingFunc != nil && len(response.Choices) > 0 {
+		// only stream the first choice.
+		if err := opts.StreamingFunc(ctx, []byte(response.Choices[0].Content)); err != nil {
+			return err
+		}
+	}
+	if opts.StreamingChunkFunc != nil {
+		for _, chunk := range llms.ChunksFromResponse(response) {
+			if err := opts.StreamingChunkFunc(ctx, chunk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // hashKeyForCache is a helper function that generates a unique key for a given
 // set of messages and call options.
 func hashKeyForCache(messages []llms.MessageContent, opts llms.CallOptions) (string, error) {
@@ -172,3 +172,57 @@ func TestCache_Call_Streaming(t *testing.T) {
 	rq.True(mockCache.hit)
 	rq.True(stream)
 }
+
+func TestCache_Stats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	mockLLM := newMockLLM(&llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "world"}}}, nil)
+	llm := New(mockLLM, newMockCache(), WithSkip(SkipNonDeterministic))
+
+	_, err := llm.Call(ctx, "hello")
+	rq.NoError(err)
+	_, err = llm.Call(ctx, "hello")
+	rq.NoError(err)
+	_, err = llm.Call(ctx, "hello", llms.WithTemperature(0.7))
+	rq.NoError(err)
+	_, err = llm.Call(ctx, "hello", llms.WithTemperature(0.7))
+	rq.NoError(err)
+
+	rq.Equal(Stats{Hits: 1, Misses: 1, Skips: 2}, llm.Stats())
+	rq.Equal(3, mockLLM.called)
+}
+
+func TestCache_BackendError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	mockLLM := newMockLLM(&llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "world"}}}, nil)
+	llm := New(mockLLM, failingCache{})
+
+	_, err := llm.Call(ctx, "hello")
+	rq.ErrorIs(err, errCacheUnavailable)
+	rq.Equal(0, mockLLM.called)
+}
+
+func TestSkipNonDeterministic(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts llms.CallOptions
+		skip bool
+	}{
+		{"default", llms.CallOptions{}, false},
+		{"temperature", llms.CallOptions{Temperature: 0.5}, true},
+		{"temperature with seed", llms.CallOptions{Temperature: 0.5, Seed: 42}, false},
+		{"several candidates", llms.CallOptions{CandidateCount: 2}, true},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.skip, SkipNonDeterministic(tc.opts), tc.name)
+	}
+}
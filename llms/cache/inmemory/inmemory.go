@@ -5,8 +5,12 @@ import (
 
 	cache "github.com/Code-Hex/go-generics-cache"
 	"github.com/tmc/langchaingo/llms"
+	llmcache "github.com/tmc/langchaingo/llms/cache"
 )
 
+// assert that `InMemory` implements the `cache.Backend` interface.
+var _ llmcache.Backend = (*InMemory)(nil)
+
 // InMemory is an in-memory `cache.Backend`.
 type InMemory struct {
 	Options Options
@@ -29,15 +33,17 @@ func New(ctx context.Context, opts ...Option) (*InMemory, error) {
 }
 
 // Get a value from the cache. If the key is not found, return `nil`.
-func (im *InMemory) Get(_ context.Context, key string) *llms.ContentResponse {
-	// errors are ignored, instead we return `nil` and pretend the key
+func (im *InMemory) Get(_ context.Context, key string) (*llms.ContentResponse, error) {
+	// a missing key is not an error, we return `nil` and pretend the key
 	// wasn't found.
 	v, _ := im.cache.Get(key)
 
-	return v
+	return v, nil
 }
 
 // Put a value into the cache.
-func (im *InMemory) Put(_ context.Context, key string, value *llms.ContentResponse) {
+func (im *InMemory) Put(_ context.Context, key string, value *llms.ContentResponse) error {
 	im.cache.Set(key, value, im.Options.ItemOptions...)
+
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	cache "github.com/Code-Hex/go-generics-cache"
+	"github.com/Code-Hex/go-generics-cache/policy/lru"
 	"github.com/tmc/langchaingo/llms"
 )
 
@@ -49,6 +50,17 @@ func WithExpiration(expiration time.Duration) Option {
 	}
 }
 
+// WithMaxEntries bounds the cache to n entries, evicting the least recently
+// used entry when it is full. This is the same as:
+// `WithCacheOptions(cache.AsLRU[string, *llms.ContentResponse](lru.WithCapacity(n)))`.
+func WithMaxEntries(n int) Option {
+	return func(o *Options) error {
+		o.CacheOptions = append(o.CacheOptions, cache.AsLRU[string, *llms.ContentResponse](lru.WithCapacity(n)))
+
+		return nil
+	}
+}
+
 func applyOptions(opts ...Option) (*Options, error) {
 	o := new(Options)
 
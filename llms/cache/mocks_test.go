@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tmc/langchaingo/llms"
 )
@@ -61,14 +62,30 @@ type mockCache struct {
 	hit     bool
 }
 
-func (m *mockCache) Get(_ context.Context, key string) *llms.ContentResponse {
+func (m *mockCache) Get(_ context.Context, key string) (*llms.ContentResponse, error) {
 	v, ok := m.entries[key]
 	m.hit = ok
 
-	return v
+	return v, nil
 }
 
-func (m *mockCache) Put(_ context.Context, key string, response *llms.ContentResponse) {
+func (m *mockCache) Put(_ context.Context, key string, response *llms.ContentResponse) error {
 	m.entries[key] = response
 	m.puts++
+
+	return nil
+}
+
+// === Mock for a failing cache.Backend
+
+var errCacheUnavailable = errors.New("cache unavailable")
+
+type failingCache struct{}
+
+func (failingCache) Get(context.Context, string) (*llms.ContentResponse, error) {
+	return nil, errCacheUnavailable
+}
+
+func (failingCache) Put(context.Context, string, *llms.ContentResponse) error {
+	return errCacheUnavailable
 }
@@ -0,0 +1,35 @@
+package cache
+
+import "github.com/tmc/langchaingo/llms"
+
+// Option is a functional argument that configures the Cacher.
+type Option func(*options)
+
+type options struct {
+	skip func(opts llms.CallOptions) bool
+}
+
+// WithSkip sets a function deciding which calls bypass the cache: their
+// responses are neither looked up nor stored. By default, all calls are
+// cached. See SkipNonDeterministic.
+func WithSkip(skip func(opts llms.CallOptions) bool) Option {
+	return func(o *options) {
+		o.skip = skip
+	}
+}
+
+// SkipNonDeterministic reports whether a call with opts is expected to give a
+// different response each time it is made, because it samples with a positive
+// temperature and no seed, or asks for several candidates. Use it with
+// WithSkip.
+func SkipNonDeterministic(opts llms.CallOptions) bool {
+	return (opts.Temperature > 0 && opts.Seed == 0) || opts.CandidateCount > 1 || opts.N > 1
+}
+
+func applyOptions(opts ...Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// DefaultPrefix is the default prefix of the keys the responses are stored
+// under.
+const DefaultPrefix = "langchaingo:llm_cache:"
+
+// Option is a functional argument that configures the Redis backend.
+type Option func(*Redis)
+
+// WithClient sets the Redis client to use. Either WithClient or WithURL must
+// be given.
+func WithClient(client rueidis.Client) Option {
+	return func(r *Redis) {
+		r.client = client
+	}
+}
+
+// WithURL sets the URL of the Redis server to connect to, such as
+// "redis://localhost:6379/0".
+func WithURL(url string) Option {
+	return func(r *Redis) {
+		r.url = url
+	}
+}
+
+// WithPrefix sets the prefix of the keys the responses are stored under.
+// Defaults to DefaultPrefix.
+func WithPrefix(prefix string) Option {
+	return func(r *Redis) {
+		r.prefix = prefix
+	}
+}
+
+// WithTTL sets how long a response is kept after it has been stored. By
+// default, responses do not expire.
+func WithTTL(ttl time.Duration) Option {
+	return func(r *Redis) {
+		r.ttl = ttl
+	}
+}
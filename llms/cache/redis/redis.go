@@ -0,0 +1,94 @@
+// Package redis provides a `cache.Backend` that stores the cached responses in
+// Redis, so that they can be shared between processes and survive between
+// runs. To bound the size of the cache, configure the Redis server with a
+// `maxmemory` limit and the `allkeys-lru` eviction policy.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/cache"
+)
+
+// ErrMissingClient is returned by New when neither a client nor a URL is
+// given.
+var ErrMissingClient = errors.New("redis: missing client or URL")
+
+// Redis is a Redis `cache.Backend`.
+type Redis struct {
+	client rueidis.Client
+	url    string
+	prefix string
+	ttl    time.Duration
+}
+
+// assert that `Redis` implements the `cache.Backend` interface.
+var _ cache.Backend = (*Redis)(nil)
+
+// New creates a new Redis `cache.Backend` with the supplied options.
+func New(opts ...Option) (*Redis, error) {
+	r := &Redis{
+		prefix: DefaultPrefix,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.client == nil {
+		if r.url == "" {
+			return nil, ErrMissingClient
+		}
+		clientOption, err := rueidis.ParseURL(r.url)
+		if err != nil {
+			return nil, err
+		}
+		r.client, err = rueidis.NewClient(clientOption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Get a value from the cache. If the key is not found or its value has
+// expired, return `nil`.
+func (r *Redis) Get(ctx context.Context, key string) (*llms.ContentResponse, error) {
+	data, err := r.client.Do(ctx, r.client.B().Get().Key(r.prefix+key).Build()).AsBytes()
+	if rueidis.IsRedisNil(err) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var response llms.ContentResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("decode cached response: %w", err)
+	}
+	return &response, nil
+}
+
+// Put a value into the cache.
+func (r *Redis) Put(ctx context.Context, key string, response *llms.ContentResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("encode response: %w", err)
+	}
+
+	set := r.client.B().Set().Key(r.prefix + key).Value(rueidis.BinaryString(data))
+	if r.ttl > 0 {
+		return r.client.Do(ctx, set.Px(r.ttl).Build()).Error()
+	}
+	return r.client.Do(ctx, set.Build()).Error()
+}
+
+// Close closes the connection to the Redis server.
+func (r *Redis) Close() {
+	r.client.Close()
+}
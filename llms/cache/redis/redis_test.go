@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func getRedisURL(t *testing.T) string {
+	t.Helper()
+
+	if url := os.Getenv("REDIS_URL"); url != "" {
+		return url
+	}
+
+	ctx := context.Background()
+	container, err := tcredis.RunContainer(ctx, testcontainers.WithImage("docker.io/redis:7"))
+	if err != nil && strings.Contains(err.Error(), "Cannot connect to the Docker daemon") {
+		t.Skip("Docker not available")
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	})
+
+	url, err := container.ConnectionString(ctx)
+	require.NoError(t, err)
+	return url
+}
+
+func TestRedis(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+	ttl := time.Second
+
+	c, err := New(WithURL(getRedisURL(t)), WithPrefix(t.Name()+":"), WithTTL(ttl))
+	rq.NoError(err)
+	defer c.Close()
+
+	v, err := c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.Nil(v, "empty cache should be empty")
+
+	val := &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{
+			Content: "value",
+		}},
+	}
+	rq.NoError(c.Put(ctx, "key1", val))
+	v, err = c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.Equal(val, v)
+
+	time.Sleep(ttl * 2)
+	v, err = c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.Nil(v, "value should have expired")
+}
+
+func TestNewMissingClient(t *testing.T) {
+	t.Parallel()
+
+	_, err := New()
+	require.ErrorIs(t, err, ErrMissingClient)
+}
@@ -0,0 +1,70 @@
+package semantic
+
+import (
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+const (
+	defaultThreshold  = 0.95
+	defaultCandidates = 4
+)
+
+// Option is a functional argument that configures the Cacher.
+type Option func(*options)
+
+type options struct {
+	threshold     float32
+	candidates    int
+	searchOptions []vectorstores.Option
+	skip          func(opts llms.CallOptions) bool
+}
+
+// WithThreshold sets the minimum cosine similarity between the final user
+// message of a call and a cached one for the cached response to be returned.
+// Defaults to 0.95.
+func WithThreshold(threshold float32) Option {
+	return func(o *options) {
+		o.threshold = threshold
+	}
+}
+
+// WithCandidates sets the number of similar messages fetched from the vector
+// store on each lookup. The search is filtered by scope, unless the filters of
+// WithSearchOptions are in the native syntax of the store: the messages of
+// other scopes are then discarded after the search, so raise it when many
+// models or system prompts share the store. Defaults to 4.
+func WithCandidates(n int) Option {
+	return func(o *options) {
+		o.candidates = n
+	}
+}
+
+// WithSearchOptions sets options passed to the vector store when searching
+// for and adding messages, such as a namespace. Filters given as a
+// filter.Filter are combined with the scope of the messages.
+func WithSearchOptions(opts ...vectorstores.Option) Option {
+	return func(o *options) {
+		o.searchOptions = append(o.searchOptions, opts...)
+	}
+}
+
+// WithSkip sets a function deciding which calls bypass the cache: their
+// responses are neither looked up nor stored. By default, all calls are
+// cached. See cache.SkipNonDeterministic.
+func WithSkip(skip func(opts llms.CallOptions) bool) Option {
+	return func(o *options) {
+		o.skip = skip
+	}
+}
+
+func applyOptions(opts ...Option) options {
+	o := options{
+		threshold:  defaultThreshold,
+		candidates: defaultCandidates,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
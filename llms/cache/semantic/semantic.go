@@ -0,0 +1,301 @@
+// Package semantic provides a wrapper that caches the responses of a `llms.Model` by the
+// meaning of the final user message rather than by its exact text. Messages are embedded and
+// stored in a vector store; a call whose final user message is close enough to a cached one,
+// by cosine similarity, gets the cached response. Matches are scoped by model name and system
+// prompt, so that the same question asked to a different model or persona is not answered
+// from the cache.
+package semantic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/cache"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+const (
+	// metadataScope is the metadata key of the scope of a cached message.
+	metadataScope = "llm_cache_scope"
+	// metadataResponse is the metadata key of the JSON encoded response to a
+	// cached message.
+	metadataResponse = "llm_cache_response"
+)
+
+// Cacher is an LLM wrapper that caches the responses from the LLM by semantic
+// similarity of the final user message.
+type Cacher struct {
+	llm      llms.Model
+	embedder embeddings.Embedder
+	store    vectorstores.VectorStore
+	opts     options
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	skips  atomic.Int64
+}
+
+// assert that `Cacher` implements the `llms.Model` interface.
+var _ llms.Model = (*Cacher)(nil)
+
+// New wraps a Model and caches its responses in store, embedding the messages
+// with embedder.
+func New(llm llms.Model, embedder embeddings.Embedder, store vectorstores.VectorStore, opts ...Option) *Cacher {
+	return &Cacher{
+		llm:      llm,
+		embedder: embedder,
+		store:    store,
+		opts:     applyOptions(opts...),
+	}
+}
+
+// Stats returns the statistics of the cacher since it was created. Calls
+// without a user message are counted as skipped.
+func (c *Cacher) Stats() cache.Stats {
+	return cache.Stats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Skips:  c.skips.Load(),
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (c *Cacher) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, c, prompt, options...)
+}
+
+// GenerateContent asks the model to generate content from a sequence of
+// messages, unless a response to a similar final user message is cached.
+func (c *Cacher) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	query := lastText(messages, llms.ChatMessageTypeHuman)
+	if query == "" || (c.opts.skip != nil && c.opts.skip(opts)) {
+		c.skips.Add(1)
+		return c.llm.GenerateContent(ctx, messages, options...)
+	}
+
+	vector, err := c.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("embed query: %w", err)
+	}
+	embedder := queryEmbedder{Embedder: c.embedder, query: query, vector: vector}
+	scope := scopeKey(opts.Model, lastText(messages, llms.ChatMessageTypeSystem))
+
+	response, err := c.lookup(ctx, embedder, scope)
+	if err != nil {
+		return nil, err
+	}
+	if response != nil {
+		c.hits.Add(1)
+		if err := streamCachedResponse(ctx, opts, response); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+
+	c.misses.Add(1)
+	response, err = c.llm.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("encode response: %w", err)
+	}
+	doc := schema.Document{
+		PageContent: query,
+		Metadata: map[string]any{
+			metadataScope:    scope,
+			metadataResponse: string(data),
+		},
+	}
+	searchOptions := append([]vectorstores.Option{vectorstores.WithEmbedder(embedder)}, c.opts.searchOptions...)
+	if _, err := c.store.AddDocuments(ctx, []schema.Document{doc}, searchOptions...); err != nil {
+		return nil, fmt.Errorf("store response: %w", err)
+	}
+
+	return response, nil
+}
+
+// lookup returns the cached response to the message most similar to the query
+// of embedder in scope, if it is similar enough, or nil.
+//
+// The similarity of the candidates is computed from their stored vectors if
+// the store implements vectorstores.VectorSearcher, since stores report scores
+// on different scales. Otherwise, the score of the store is taken as the cosine
+// similarity.
+func (c *Cacher) lookup(ctx context.Context, embedder queryEmbedder, scope string) (*llms.ContentResponse, error) {
+	docs, vectors, err := c.search(ctx, embedder, scope)
+	if err != nil {
+		return nil, fmt.Errorf("search cache: %w", err)
+	}
+
+	best, bestSimilarity := -1, float32(0)
+	for i, doc := range docs {
+		// the scope is checked again in case the scope filter was not pushed
+		// down, see lookupOptions.
+		if s, _ := doc.Metadata[metadataScope].(string); s != scope {
+			continue
+		}
+		similarity := doc.Score
+		if vectors != nil {
+			similarity = cosineSimilarity(embedder.vector, vectors[i])
+		}
+		if similarity >= c.opts.threshold && similarity > bestSimilarity {
+			best, bestSimilarity = i, similarity
+		}
+	}
+	if best < 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	data, _ := docs[best].Metadata[metadataResponse].(string)
+	var response llms.ContentResponse
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		return nil, fmt.Errorf("decode cached response: %w", err)
+	}
+	return &response, nil
+}
+
+// search returns the candidate messages of the query of embedder in scope, and
+// their stored vectors if the store returns them.
+func (c *Cacher) search(
+	ctx context.Context,
+	embedder queryEmbedder,
+	scope string,
+) ([]schema.Document, [][]float32, error) {
+	searchOptions := c.lookupOptions(embedder, scope)
+	if searcher, ok := c.store.(vectorstores.VectorSearcher); ok {
+		res, err := searcher.SimilaritySearchWithVectors(ctx, embedder.query, c.opts.candidates, searchOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(res.Vectors) != len(res.Documents) {
+			return res.Documents, nil, nil
+		}
+		return res.Documents, res.Vectors, nil
+	}
+	docs, err := c.store.SimilaritySearch(ctx, embedder.query, c.opts.candidates, searchOptions...)
+	return docs, nil, err
+}
+
+// lookupOptions returns the options of the search for cached messages in
+// scope. The scope is added to the filters of the options, unless they are
+// in the native syntax of the store.
+func (c *Cacher) lookupOptions(embedder queryEmbedder, scope string) []vectorstores.Option {
+	searchOptions := append([]vectorstores.Option{vectorstores.WithEmbedder(embedder)}, c.opts.searchOptions...)
+	opts := vectorstores.Options{}
+	for _, opt := range searchOptions {
+		opt(&opts)
+	}
+
+	scopeFilter := filter.Eq(metadataScope, scope)
+	switch filters := opts.Filters.(type) {
+	case nil:
+		searchOptions = append(searchOptions, vectorstores.WithFilters(scopeFilter))
+	case filter.Filter:
+		searchOptions = append(searchOptions, vectorstores.WithFilters(filter.And(filters, scopeFilter)))
+	}
+	return searchOptions
+}
+
+// queryEmbedder is an embedder that reuses the vector of the query it was
+// created for, so that the query is embedded only once per call.
+type queryEmbedder struct {
+	embeddings.Embedder
+	query  string
+	vector []float32
+}
+
+func (e queryEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	if text == e.query {
+		return e.vector, nil
+	}
+	return e.Embedder.EmbedQuery(ctx, text)
+}
+
+func (e queryEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 1 && texts[0] == e.query {
+		return [][]float32{e.vector}, nil
+	}
+	return e.Embedder.EmbedDocuments(ctx, texts)
+}
+
+// lastText returns the text of the last message of the given role.
+func lastText(messages []llms.MessageContent, role llms.ChatMessageType) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != role {
+			continue
+		}
+		var texts []string
+		for _, part := range messages[i].Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				texts = append(texts, text.Text)
+			}
+		}
+		return strings.Join(texts, "\n")
+	}
+	return ""
+}
+
+// scopeKey returns the scope of the messages sent to model with the given
+// system prompt. Only messages of the same scope match each other.
+func scopeKey(model, systemPrompt string) string {
+	hash := sha256.Sum256([]byte(model + "\x00" + systemPrompt))
+	return hex.EncodeToString(hash[:])
+}
+
+func cosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
+
+// streamCachedResponse sends a cached response to the streaming functions of
+// opts, as if it was being generated.
+func streamCachedResponse(ctx context.Context, opts llms.CallOptions, response *llms.ContentResponse) error {
+	if opts.StreamingFunc != nil && len(response.Choices) > 0 {
+		if err := opts.StreamingFunc(ctx, []byte(response.Choices[0].Content)); err != nil {
+			return err
+		}
+	}
+	if opts.StreamingChunkFunc != nil {
+		for _, chunk := range llms.ChunksFromResponse(response) {
+			if err := opts.StreamingChunkFunc(ctx, chunk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package semantic
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/tmc/langchaingo/vectorstores/inmemory"
+)
+
+// testEmbedder embeds the texts it knows to fixed vectors.
+type testEmbedder map[string][]float32
+
+func (e testEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i], _ = e.EmbedQuery(ctx, text)
+	}
+	return vectors, nil
+}
+
+func (e testEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	if v, ok := e[text]; ok {
+		return v, nil
+	}
+	return []float32{0, 0, 1}, nil
+}
+
+// testStore is a brute force vector store using the embedder of the options.
+type testStore struct {
+	docs    []schema.Document
+	vectors [][]float32
+}
+
+func (s *testStore) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) ([]string, error) {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	for _, doc := range docs {
+		v, err := opts.Embedder.EmbedQuery(ctx, doc.PageContent)
+		if err != nil {
+			return nil, err
+		}
+		s.docs = append(s.docs, doc)
+		s.vectors = append(s.vectors, v)
+	}
+	return nil, nil
+}
+
+func (s *testStore) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	q, err := opts.Embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	f, _ := opts.Filters.(filter.Filter)
+	docs := make([]schema.Document, 0, len(s.docs))
+	for i, doc := range s.docs {
+		if f.Op != "" && !f.Match(doc.Metadata) {
+			continue
+		}
+		doc.Score = cosineSimilarity(q, s.vectors[i])
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Score > docs[j].Score })
+	if len(docs) > numDocuments {
+		docs = docs[:numDocuments]
+	}
+	return docs, nil
+}
+
+// countingEmbedder counts the calls to its embedder.
+type countingEmbedder struct {
+	testEmbedder
+	queries   int
+	documents int
+}
+
+func (e *countingEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	e.documents++
+	return e.testEmbedder.EmbedDocuments(ctx, texts)
+}
+
+func (e *countingEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	e.queries++
+	return e.testEmbedder.EmbedQuery(ctx, text)
+}
+
+// not synchronized, don't use concurrently!
+type mockLLM struct {
+	called int
+}
+
+func (m *mockLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *mockLLM) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+	m.called++
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "answer to " + lastText(messages, llms.ChatMessageTypeHuman)}}}, nil
+}
+
+var embedder = testEmbedder{
+	"How do I reset my password?":          {1, 0, 0},
+	"how can I reset my password":          {0.99, 0.1, 0},
+	"How do I delete my account?":          {0.6, 0.8, 0},
+	"What are your opening hours, please?": {0, 0, 1},
+}
+
+func messages(system, human string) []llms.MessageContent {
+	var ms []llms.MessageContent
+	if system != "" {
+		ms = append(ms, llms.TextParts(llms.ChatMessageTypeSystem, system))
+	}
+	return append(ms, llms.TextParts(llms.ChatMessageTypeHuman, human))
+}
+
+func TestCacher(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	llm := &mockLLM{}
+	c := New(llm, embedder, &testStore{}, WithThreshold(0.9))
+
+	resp, err := c.GenerateContent(ctx, messages("", "How do I reset my password?"))
+	rq.NoError(err)
+	rq.Equal("answer to How do I reset my password?", resp.Choices[0].Content)
+
+	// a paraphrase is answered from the cache.
+	resp, err = c.GenerateContent(ctx, messages("", "how can I reset my password"))
+	rq.NoError(err)
+	rq.Equal("answer to How do I reset my password?", resp.Choices[0].Content)
+	rq.Equal(1, llm.called)
+
+	// a different question is not.
+	resp, err = c.GenerateContent(ctx, messages("", "How do I delete my account?"))
+	rq.NoError(err)
+	rq.Equal("answer to How do I delete my account?", resp.Choices[0].Content)
+	rq.Equal(2, llm.called)
+
+	rq.Equal(int64(1), c.Stats().Hits)
+	rq.Equal(int64(2), c.Stats().Misses)
+}
+
+func TestCacherScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	llm := &mockLLM{}
+	c := New(llm, embedder, &testStore{})
+
+	_, err := c.GenerateContent(ctx, messages("You are a pirate.", "How do I reset my password?"))
+	rq.NoError(err)
+
+	// the same question with another persona or model is not answered from
+	// the cache.
+	_, err = c.GenerateContent(ctx, messages("You are a butler.", "How do I reset my password?"))
+	rq.NoError(err)
+	_, err = c.GenerateContent(ctx, messages("You are a pirate.", "How do I reset my password?"), llms.WithModel("other"))
+	rq.NoError(err)
+	rq.Equal(3, llm.called)
+
+	_, err = c.GenerateContent(ctx, messages("You are a pirate.", "How do I reset my password?"))
+	rq.NoError(err)
+	rq.Equal(3, llm.called)
+}
+
+func TestCacherScopeCandidates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	llm := &mockLLM{}
+	c := New(llm, embedder, &testStore{}, WithThreshold(0.9), WithCandidates(1))
+
+	_, err := c.GenerateContent(ctx, messages("You are a pirate.", "how can I reset my password"))
+	rq.NoError(err)
+	_, err = c.GenerateContent(ctx, messages("You are a butler.", "How do I reset my password?"))
+	rq.NoError(err)
+	rq.Equal(2, llm.called)
+
+	// the closer message of the butler does not crowd out the one of the
+	// pirate.
+	resp, err := c.GenerateContent(ctx, messages("You are a pirate.", "How do I reset my password?"))
+	rq.NoError(err)
+	rq.Equal("answer to how can I reset my password", resp.Choices[0].Content)
+	rq.Equal(2, llm.called)
+}
+
+func TestCacherEmbedsOnlyQuery(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(embeddings.Embedder) (vectorstores.VectorStore, error){
+		"scores": func(embeddings.Embedder) (vectorstores.VectorStore, error) { return &testStore{}, nil },
+		"vectors": func(e embeddings.Embedder) (vectorstores.VectorStore, error) {
+			return inmemory.New(inmemory.WithEmbedder(e))
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			rq := require.New(t)
+
+			embedder := &countingEmbedder{testEmbedder: embedder}
+			store, err := newStore(embedder)
+			rq.NoError(err)
+			llm := &mockLLM{}
+			c := New(llm, embedder, store, WithThreshold(0.9))
+
+			_, err = c.GenerateContent(ctx, messages("", "How do I reset my password?"))
+			rq.NoError(err)
+			_, err = c.GenerateContent(ctx, messages("", "How do I delete my account?"))
+			rq.NoError(err)
+			resp, err := c.GenerateContent(ctx, messages("", "how can I reset my password"))
+			rq.NoError(err)
+			rq.Equal("answer to How do I reset my password?", resp.Choices[0].Content)
+			rq.Equal(2, llm.called)
+
+			// each call embeds its query once, and the cached messages are
+			// not embedded again.
+			rq.Equal(3, embedder.queries)
+			rq.Equal(0, embedder.documents)
+		})
+	}
+}
+
+func TestCacherStreaming(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	c := New(&mockLLM{}, embedder, &testStore{})
+	_, err := c.GenerateContent(ctx, messages("", "What are your opening hours, please?"))
+	rq.NoError(err)
+
+	var streamed string
+	_, err = c.GenerateContent(ctx, messages("", "What are your opening hours, please?"),
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			streamed += string(chunk)
+			return nil
+		}))
+	rq.NoError(err)
+	rq.Equal("answer to What are your opening hours, please?", streamed)
+}
@@ -0,0 +1,53 @@
+package sqlite3
+
+import (
+	"database/sql"
+	"time"
+)
+
+// DefaultTableName is the default name of the table the responses are stored
+// in.
+const DefaultTableName = "langchaingo_llm_cache"
+
+// Option is a functional argument that configures the SQLite backend.
+type Option func(*SQLite)
+
+// WithDB sets the database connection to use. If it is not set, a connection
+// to the database at the address set by WithDBAddress is opened.
+func WithDB(db *sql.DB) Option {
+	return func(s *SQLite) {
+		s.db = db
+	}
+}
+
+// WithDBAddress sets the file path of the database. Defaults to an in-memory
+// database, which does not persist the responses.
+func WithDBAddress(addr string) Option {
+	return func(s *SQLite) {
+		s.dbAddress = addr
+	}
+}
+
+// WithTableName sets the name of the table the responses are stored in.
+// Defaults to DefaultTableName.
+func WithTableName(name string) Option {
+	return func(s *SQLite) {
+		s.tableName = name
+	}
+}
+
+// WithTTL sets how long a response is kept after it has been stored. By
+// default, responses do not expire.
+func WithTTL(ttl time.Duration) Option {
+	return func(s *SQLite) {
+		s.ttl = ttl
+	}
+}
+
+// WithMaxEntries bounds the cache to n responses, evicting the least recently
+// used responses when it is full. By default, the cache is not bounded.
+func WithMaxEntries(n int) Option {
+	return func(s *SQLite) {
+		s.maxEntries = n
+	}
+}
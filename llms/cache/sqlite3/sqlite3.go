@@ -0,0 +1,146 @@
+// Package sqlite3 provides a `cache.Backend` that persists the cached responses
+// in a SQLite database, so that they survive between runs.
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/cache"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS %[1]s (
+	key TEXT PRIMARY KEY,
+	response BLOB NOT NULL,
+	expires_at INTEGER,
+	accessed_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_%[1]s_accessed_at ON %[1]s (accessed_at);`
+
+// SQLite is a SQLite `cache.Backend`.
+type SQLite struct {
+	db         *sql.DB
+	dbAddress  string
+	tableName  string
+	ttl        time.Duration
+	maxEntries int
+}
+
+// assert that `SQLite` implements the `cache.Backend` interface.
+var _ cache.Backend = (*SQLite)(nil)
+
+// New creates a new SQLite `cache.Backend` with the supplied options, creating
+// the table of the responses if it does not exist.
+func New(ctx context.Context, opts ...Option) (*SQLite, error) {
+	s := &SQLite{
+		dbAddress: ":memory:",
+		tableName: DefaultTableName,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.db == nil {
+		db, err := sql.Open("sqlite3", s.dbAddress)
+		if err != nil {
+			return nil, err
+		}
+		// Each connection to an in-memory database has its own database, and
+		// SQLite only supports a single writer anyway.
+		db.SetMaxOpenConns(1)
+		s.db = db
+	}
+
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(schema, s.tableName)); err != nil {
+		return nil, fmt.Errorf("create cache table: %w", err)
+	}
+	return s, nil
+}
+
+// Get a value from the cache. If the key is not found or its value has
+// expired, return `nil`.
+func (s *SQLite) Get(ctx context.Context, key string) (*llms.ContentResponse, error) {
+	var data []byte
+	var expiresAt sql.NullInt64
+	query := fmt.Sprintf("SELECT response, expires_at FROM %s WHERE key = ?", s.tableName)
+	err := s.db.QueryRowContext(ctx, query, key).Scan(&data, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if expiresAt.Valid && now.UnixNano() >= expiresAt.Int64 {
+		query := fmt.Sprintf("DELETE FROM %s WHERE key = ?", s.tableName)
+		_, err := s.db.ExecContext(ctx, query, key)
+		return nil, err
+	}
+
+	if s.maxEntries > 0 {
+		query := fmt.Sprintf("UPDATE %s SET accessed_at = ? WHERE key = ?", s.tableName)
+		if _, err := s.db.ExecContext(ctx, query, now.UnixNano(), key); err != nil {
+			return nil, err
+		}
+	}
+
+	var response llms.ContentResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("decode cached response: %w", err)
+	}
+	return &response, nil
+}
+
+// Put a value into the cache.
+func (s *SQLite) Put(ctx context.Context, key string, response *llms.ContentResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("encode response: %w", err)
+	}
+
+	now := time.Now()
+	var expiresAt sql.NullInt64
+	if s.ttl > 0 {
+		expiresAt = sql.NullInt64{Int64: now.Add(s.ttl).UnixNano(), Valid: true}
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (key, response, expires_at, accessed_at) VALUES (?, ?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET response = excluded.response, expires_at = excluded.expires_at, accessed_at = excluded.accessed_at`,
+		s.tableName)
+	if _, err := s.db.ExecContext(ctx, query, key, data, expiresAt, now.UnixNano()); err != nil {
+		return err
+	}
+	return s.evict(ctx, now)
+}
+
+// evict removes the expired responses and, if the cache is bounded, the least
+// recently used responses in excess.
+func (s *SQLite) evict(ctx context.Context, now time.Time) error {
+	if s.ttl > 0 {
+		query := fmt.Sprintf("DELETE FROM %s WHERE expires_at <= ?", s.tableName)
+		if _, err := s.db.ExecContext(ctx, query, now.UnixNano()); err != nil {
+			return err
+		}
+	}
+	if s.maxEntries > 0 {
+		query := fmt.Sprintf(`DELETE FROM %[1]s WHERE key IN (
+	SELECT key FROM %[1]s ORDER BY accessed_at DESC LIMIT -1 OFFSET ?
+)`, s.tableName)
+		if _, err := s.db.ExecContext(ctx, query, s.maxEntries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the database connection.
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,95 @@
+package sqlite3
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestSQLite(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	c, err := New(ctx, WithDBAddress(path))
+	rq.NoError(err)
+
+	v, err := c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.Nil(v, "empty cache should be empty")
+
+	val := &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{
+			Content:    "value",
+			StopReason: "stop",
+		}},
+		Usage: &llms.Usage{PromptTokens: 1, CompletionTokens: 2, TotalTokens: 3},
+	}
+	rq.NoError(c.Put(ctx, "key1", val))
+	rq.NoError(c.Close())
+
+	// the value survives reopening the database.
+	c, err = New(ctx, WithDBAddress(path))
+	rq.NoError(err)
+	defer c.Close()
+
+	v, err = c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.Equal(val, v)
+}
+
+func TestSQLiteTTL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+	ttl := 50 * time.Millisecond
+
+	c, err := New(ctx, WithTTL(ttl))
+	rq.NoError(err)
+	defer c.Close()
+
+	rq.NoError(c.Put(ctx, "key1", &llms.ContentResponse{}))
+	v, err := c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.NotNil(v)
+
+	time.Sleep(ttl * 2)
+	v, err = c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.Nil(v, "value should have expired")
+}
+
+func TestSQLiteMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rq := require.New(t)
+
+	c, err := New(ctx, WithMaxEntries(2))
+	rq.NoError(err)
+	defer c.Close()
+
+	val := &llms.ContentResponse{}
+	rq.NoError(c.Put(ctx, "key1", val))
+	rq.NoError(c.Put(ctx, "key2", val))
+
+	// reading key1 makes key2 the least recently used entry.
+	v, err := c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.NotNil(v)
+
+	rq.NoError(c.Put(ctx, "key3", val))
+	v, err = c.Get(ctx, "key2")
+	rq.NoError(err)
+	rq.Nil(v, "least recently used value should have been evicted")
+	v, err = c.Get(ctx, "key1")
+	rq.NoError(err)
+	rq.NotNil(v)
+}
@@ -53,12 +53,19 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		return nil, err
 	}
 
+	usage := &llms.Usage{
+		PromptTokens:     result.InputTokens,
+		CompletionTokens: result.OutputTokens,
+		TotalTokens:      result.InputTokens + result.OutputTokens,
+	}
 	resp := &llms.ContentResponse{
 		Choices: []*llms.ContentChoice{
 			{
 				Content: result.Text,
+				Usage:   usage,
 			},
 		},
+		Usage: usage,
 	}
 	return resp, nil
 }
@@ -101,12 +101,19 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		return nil, err
 	}
 
+	usage := &llms.Usage{
+		PromptTokens:     result.Usage.PromptTokens,
+		CompletionTokens: result.Usage.CompletionTokens,
+		TotalTokens:      result.Usage.TotalTokens,
+	}
 	resp := &llms.ContentResponse{
 		Choices: []*llms.ContentChoice{
 			{
 				Content: result.Result,
+				Usage:   usage,
 			},
 		},
+		Usage: usage,
 	}
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, resp)
@@ -0,0 +1,179 @@
+package llms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ErrorKind classifies the failure of a call to a model provider.
+type ErrorKind string
+
+const (
+	// ErrorKindUnknown is a failure that could not be classified.
+	ErrorKindUnknown ErrorKind = "unknown"
+	// ErrorKindRateLimit is a failure due to the provider throttling requests.
+	ErrorKindRateLimit ErrorKind = "rate_limit"
+	// ErrorKindServer is a failure on the provider side, such as an internal
+	// error or an overloaded service.
+	ErrorKindServer ErrorKind = "server"
+	// ErrorKindNetwork is a failure to reach the provider.
+	ErrorKindNetwork ErrorKind = "network"
+	// ErrorKindTimeout is a call that did not complete in time.
+	ErrorKindTimeout ErrorKind = "timeout"
+	// ErrorKindCanceled is a call that was canceled by the caller.
+	ErrorKindCanceled ErrorKind = "canceled"
+	// ErrorKindAuthentication is a failure due to missing or invalid
+	// credentials, or insufficient permissions.
+	ErrorKindAuthentication ErrorKind = "authentication"
+	// ErrorKindInvalidRequest is a request the provider rejected as invalid.
+	ErrorKindInvalidRequest ErrorKind = "invalid_request"
+)
+
+// Sentinel errors for each kind, to be used with errors.Is:
+//
+//	if errors.Is(err, llms.ErrRateLimit) { ... }
+var (
+	ErrRateLimit      = &ProviderError{Kind: ErrorKindRateLimit}
+	ErrServer         = &ProviderError{Kind: ErrorKindServer}
+	ErrNetwork        = &ProviderError{Kind: ErrorKindNetwork}
+	ErrTimeout        = &ProviderError{Kind: ErrorKindTimeout}
+	ErrCanceled       = &ProviderError{Kind: ErrorKindCanceled}
+	ErrAuthentication = &ProviderError{Kind: ErrorKindAuthentication}
+	ErrInvalidRequest = &ProviderError{Kind: ErrorKindInvalidRequest}
+)
+
+// ProviderError is a classified error returned by a model provider.
+type ProviderError struct {
+	// Kind is the class of the failure.
+	Kind ErrorKind
+	// StatusCode is the HTTP status code of the response, if any.
+	StatusCode int
+	// RetryAfter is how long the provider asked to wait before retrying, as
+	// reported by the Retry-After header of the response.
+	RetryAfter time.Duration
+	// Err is the underlying error.
+	Err error
+}
+
+// NewProviderError classifies err, returned for a response with the given
+// status code and header. header may be nil.
+func NewProviderError(statusCode int, header http.Header, err error) *ProviderError {
+	return &ProviderError{
+		Kind:       kindFromStatusCode(statusCode),
+		StatusCode: statusCode,
+		RetryAfter: parseRetryAfter(header),
+		Err:        err,
+	}
+}
+
+func (e *ProviderError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("llm provider error: %s", e.Kind)
+	}
+	return e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel error of the kind of e.
+func (e *ProviderError) Is(target error) bool {
+	t, ok := target.(*ProviderError)
+	if !ok || t.Err != nil {
+		return false
+	}
+	return t.Kind == e.Kind
+}
+
+// Retryable reports whether the call that failed with e may succeed if it is
+// made again.
+func (e *ProviderError) Retryable() bool {
+	switch e.Kind { //nolint:exhaustive
+	case ErrorKindRateLimit, ErrorKindServer, ErrorKindNetwork, ErrorKindTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// statusCodePattern matches the status codes in the messages of errors that
+// backends return without classifying them.
+var statusCodePattern = regexp.MustCompile(`(?i)(?:status code|status|error)[: ]+(\d{3})\b`)
+
+// ClassifyError returns the classification of err. Errors that are, or wrap,
+// a *ProviderError are returned as is. Other errors are classified on a best
+// effort basis from the context, network and status code information they
+// carry. ClassifyError returns nil if err is nil.
+func ClassifyError(err error) *ProviderError {
+	if err == nil {
+		return nil
+	}
+
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr
+	}
+
+	kind := ErrorKindUnknown
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		kind = ErrorKindCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		kind = ErrorKindTimeout
+	case errors.As(err, &netErr):
+		kind = ErrorKindNetwork
+		if netErr.Timeout() {
+			kind = ErrorKindTimeout
+		}
+	default:
+		if m := statusCodePattern.FindStringSubmatch(err.Error()); m != nil {
+			statusCode, _ := strconv.Atoi(m[1])
+			return NewProviderError(statusCode, nil, err)
+		}
+	}
+	return &ProviderError{Kind: kind, Err: err}
+}
+
+func kindFromStatusCode(statusCode int) ErrorKind {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorKindRateLimit
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrorKindAuthentication
+	case statusCode == http.StatusRequestTimeout:
+		return ErrorKindTimeout
+	// Anthropic reports an overloaded service with the non-standard 529.
+	case statusCode >= http.StatusInternalServerError && statusCode <= 599:
+		return ErrorKindServer
+	case statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+		return ErrorKindInvalidRequest
+	default:
+		return ErrorKindUnknown
+	}
+}
+
+// parseRetryAfter parses the Retry-After header, given either in seconds or
+// as an HTTP date.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package llms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		err       error
+		kind      ErrorKind
+		retryable bool
+	}{
+		{"rate limit", NewProviderError(http.StatusTooManyRequests, nil, errors.New("slow down")), ErrorKindRateLimit, true},
+		{"overloaded", NewProviderError(529, nil, errors.New("overloaded")), ErrorKindServer, true},
+		{"unauthorized", NewProviderError(http.StatusUnauthorized, nil, errors.New("bad key")), ErrorKindAuthentication, false},
+		{"bad request", NewProviderError(http.StatusBadRequest, nil, errors.New("bad")), ErrorKindInvalidRequest, false},
+		{"wrapped", fmt.Errorf("chain: %w", NewProviderError(http.StatusBadGateway, nil, errors.New("bad gateway"))), ErrorKindServer, true},
+		{"status code in message", errors.New("API returned unexpected status code: 429: rate limited"), ErrorKindRateLimit, true},
+		{"googleapi message", errors.New("googleapi: Error 503: unavailable"), ErrorKindServer, true},
+		{"deadline", fmt.Errorf("request: %w", context.DeadlineExceeded), ErrorKindTimeout, true},
+		{"canceled", context.Canceled, ErrorKindCanceled, false},
+		{"unknown", errors.New("something broke"), ErrorKindUnknown, false},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			classified := ClassifyError(tc.err)
+			assert.Equal(t, tc.kind, classified.Kind)
+			assert.Equal(t, tc.retryable, classified.Retryable())
+		})
+	}
+
+	assert.Nil(t, ClassifyError(nil))
+}
+
+func TestProviderErrorIs(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("call: %w", NewProviderError(http.StatusTooManyRequests, nil, errors.New("slow down")))
+	assert.ErrorIs(t, err, ErrRateLimit)
+	assert.NotErrorIs(t, err, ErrServer)
+	assert.Equal(t, "call: slow down", err.Error())
+}
+
+func TestProviderErrorRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	err := NewProviderError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"7"}}, nil)
+	assert.Equal(t, 7*time.Second, err.RetryAfter)
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	err = NewProviderError(http.StatusServiceUnavailable, http.Header{"Retry-After": []string{date}}, nil)
+	assert.InDelta(t, time.Minute.Seconds(), err.RetryAfter.Seconds(), 2)
+}
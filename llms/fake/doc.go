@@ -0,0 +1,4 @@
+// Package fake provides a `llms.Model` replaying scripted responses, so that code built on models,
+// such as agents and chains, can be tested offline and deterministically. The messages of every
+// call are recorded for inspection.
+package fake
@@ -0,0 +1,110 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// ErrNoMoreResponses is returned when the model is called more times than it
+// has responses.
+var ErrNoMoreResponses = errors.New("fake: no more responses")
+
+// LLM is a model replaying its responses in order, one per call.
+type LLM struct {
+	mu        sync.Mutex
+	responses []*llms.ContentResponse
+	next      int
+	calls     [][]llms.MessageContent
+}
+
+// assert that `LLM` implements the `llms.Model` interface.
+var _ llms.Model = (*LLM)(nil)
+
+// New creates a model replaying the responses. See Text and ToolCalls for
+// building simple responses.
+func New(responses ...*llms.ContentResponse) *LLM {
+	return &LLM{responses: responses}
+}
+
+// Text returns a response with the content.
+func Text(content string) *llms.ContentResponse {
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: content, StopReason: "stop"}}}
+}
+
+// ToolCalls returns a response calling the tools.
+func ToolCalls(calls ...llms.ToolCall) *llms.ContentResponse {
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{ToolCalls: calls, StopReason: "tool_calls"}}}
+}
+
+// ToolCall returns a call of the function with the JSON arguments.
+func ToolCall(id, name, arguments string) llms.ToolCall {
+	return llms.ToolCall{
+		ID:           id,
+		Type:         "function",
+		FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments},
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+// GenerateContent records the messages and returns the next response. The
+// content of the first choice is streamed as a single chunk if a streaming
+// function is given.
+func (l *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	l.mu.Lock()
+	l.calls = append(l.calls, messages)
+	if l.next >= len(l.responses) {
+		l.mu.Unlock()
+		return nil, ErrNoMoreResponses
+	}
+	resp := l.responses[l.next]
+	l.next++
+	l.mu.Unlock()
+
+	if opts.StreamingFunc != nil && len(resp.Choices) > 0 && resp.Choices[0].Content != "" {
+		if err := opts.StreamingFunc(ctx, []byte(resp.Choices[0].Content)); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Calls returns the messages of the calls made so far.
+func (l *LLM) Calls() [][]llms.MessageContent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([][]llms.MessageContent(nil), l.calls...)
+}
+
+// Remaining returns the number of responses not replayed yet.
+func (l *LLM) Remaining() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.responses) - l.next
+}
+
+// Reset replays the responses from the first one again and forgets the
+// calls.
+func (l *LLM) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next = 0
+	l.calls = nil
+}
@@ -0,0 +1,41 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/fake"
+)
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	llm := fake.New(fake.Text("foo"), fake.ToolCalls(fake.ToolCall("call_1", "search", `{"query":"bar"}`)))
+
+	var streamed string
+	out, err := llm.Call(ctx, "first", llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+		streamed += string(chunk)
+		return nil
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "foo", out)
+	assert.Equal(t, "foo", streamed)
+
+	resp, err := llm.GenerateContent(ctx, []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "second")})
+	require.NoError(t, err)
+	assert.Equal(t, "search", resp.Choices[0].ToolCalls[0].FunctionCall.Name)
+	assert.Zero(t, llm.Remaining())
+
+	_, err = llm.Call(ctx, "third")
+	require.ErrorIs(t, err, fake.ErrNoMoreResponses)
+	require.Len(t, llm.Calls(), 3)
+	assert.Equal(t, []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "second")}, llm.Calls()[1])
+
+	llm.Reset()
+	assert.Equal(t, 2, llm.Remaining())
+	assert.Empty(t, llm.Calls())
+}
@@ -141,6 +141,10 @@ func (ToolCallResponse) isPart() {}
 // It can potentially return multiple content choices.
 type ContentResponse struct {
 	Choices []*ContentChoice
+
+	// Usage is the token usage of the whole call, or nil if the backend did
+	// not report it.
+	Usage *Usage
 }
 
 // ContentChoice is one of the response choices returned by GenerateContent
@@ -162,6 +166,30 @@ type ContentChoice struct {
 
 	// ToolCalls is a list of tool calls the model asks to invoke.
 	ToolCalls []ToolCall
+
+	// Usage is the token usage attributed to this choice, or nil if the
+	// backend did not report it. Backends that only report usage for the
+	// whole call attach the same Usage to every choice.
+	Usage *Usage
+}
+
+// Usage is the number of tokens consumed by a GenerateContent call, as
+// reported by the backend. Counts a backend does not report are left at zero.
+type Usage struct {
+	// PromptTokens is the number of tokens in the input messages, including
+	// CachedTokens.
+	PromptTokens int
+	// CompletionTokens is the number of generated tokens, including
+	// ReasoningTokens.
+	CompletionTokens int
+	// TotalTokens is the total number of tokens billed for the call.
+	TotalTokens int
+	// CachedTokens is the number of prompt tokens that were read from the
+	// backend's prompt cache.
+	CachedTokens int
+	// ReasoningTokens is the number of completion tokens the model spent on
+	// reasoning that is not part of the returned content.
+	ReasoningTokens int
 }
 
 // TextParts is a helper function to create a MessageContent with a role and a
@@ -63,6 +63,11 @@ func (g *Vertex) GenerateContent(ctx context.Context, messages []llms.MessageCon
 	model.SetTopP(float32(opts.TopP))
 	model.SetTopK(int32(opts.TopK))
 	model.StopSequences = opts.StopWords
+	// This version of the Vertex AI API does not accept response schemas, so
+	// only the response MIME type is set.
+	if opts.JSONMode || opts.ResponseSchema != nil {
+		model.ResponseMIMEType = "application/json"
+	}
 	model.SafetySettings = []*genai.SafetySetting{
 		{
 			Category:  genai.HarmCategoryDangerousContent,
@@ -108,13 +113,22 @@ func (g *Vertex) GenerateContent(ctx context.Context, messages []llms.MessageCon
 	return response, nil
 }
 
-// convertCandidates converts a sequence of genai.Candidate to a response.
-func convertCandidates(candidates []*genai.Candidate) (*llms.ContentResponse, error) {
+// convertCandidates converts a sequence of genai.Candidate and the usage
+// metadata of their response to a response.
+func convertCandidates(candidates []*genai.Candidate, usageMetadata *genai.UsageMetadata) (*llms.ContentResponse, error) {
 	var contentResponse llms.ContentResponse
-	var toolCalls []llms.ToolCall
+
+	if usageMetadata != nil {
+		contentResponse.Usage = &llms.Usage{
+			PromptTokens:     int(usageMetadata.PromptTokenCount),
+			CompletionTokens: int(usageMetadata.CandidatesTokenCount),
+			TotalTokens:      int(usageMetadata.TotalTokenCount),
+		}
+	}
 
 	for _, candidate := range candidates {
 		buf := strings.Builder{}
+		var toolCalls []llms.ToolCall
 
 		if candidate.Content != nil {
 			for _, part := range candidate.Content.Parts {
@@ -152,6 +166,7 @@ func convertCandidates(candidates []*genai.Candidate) (*llms.ContentResponse, er
 				StopReason:     candidate.FinishReason.String(),
 				GenerationInfo: metadata,
 				ToolCalls:      toolCalls,
+				Usage:          contentResponse.Usage,
 			})
 	}
 	return &contentResponse, nil
@@ -237,7 +252,7 @@ func generateFromSingleMessage(ctx context.Context, model *genai.GenerativeModel
 		return nil, err
 	}
 
-	if opts.StreamingFunc == nil {
+	if opts.StreamingFunc == nil && opts.StreamingChunkFunc == nil {
 		// When no streaming is requested, just call GenerateContent and return
 		// the complete response with a list of candidates.
 		resp, err := model.GenerateContent(ctx, convertedParts...)
@@ -248,7 +263,7 @@ func generateFromSingleMessage(ctx context.Context, model *genai.GenerativeModel
 		if len(resp.Candidates) == 0 {
 			return nil, ErrNoContentInResponse
 		}
-		return convertCandidates(resp.Candidates)
+		return convertCandidates(resp.Candidates, resp.UsageMetadata)
 	}
 	iter := model.GenerateContentStream(ctx, convertedParts...)
 	return convertAndStreamFromIterator(ctx, iter, opts)
@@ -256,11 +271,18 @@ func generateFromSingleMessage(ctx context.Context, model *genai.GenerativeModel
 
 func generateFromMessages(ctx context.Context, model *genai.GenerativeModel, messages []llms.MessageContent, opts *llms.CallOptions) (*llms.ContentResponse, error) {
 	history := make([]*genai.Content, 0, len(messages))
-	for _, mc := range messages {
+	for i, mc := range messages {
 		content, err := convertContent(mc)
 		if err != nil {
 			return nil, err
 		}
+		// The responses to parallel function calls must be sent back in a
+		// single turn, so consecutive tool messages are merged.
+		if mc.Role == llms.ChatMessageTypeTool && i > 0 && messages[i-1].Role == llms.ChatMessageTypeTool && len(history) > 0 {
+			last := history[len(history)-1]
+			last.Parts = append(last.Parts, content.Parts...)
+			continue
+		}
 		history = append(history, content)
 	}
 
@@ -273,7 +295,7 @@ func generateFromMessages(ctx context.Context, model *genai.GenerativeModel, mes
 	session := model.StartChat()
 	session.History = history
 
-	if opts.StreamingFunc == nil {
+	if opts.StreamingFunc == nil && opts.StreamingChunkFunc == nil {
 		resp, err := session.SendMessage(ctx, reqContent.Parts...)
 		if err != nil {
 			return nil, err
@@ -282,7 +304,7 @@ func generateFromMessages(ctx context.Context, model *genai.GenerativeModel, mes
 		if len(resp.Candidates) == 0 {
 			return nil, ErrNoContentInResponse
 		}
-		return convertCandidates(resp.Candidates)
+		return convertCandidates(resp.Candidates, resp.UsageMetadata)
 	}
 	iter := session.SendMessageStream(ctx, reqContent.Parts...)
 	return convertAndStreamFromIterator(ctx, iter, opts)
@@ -297,6 +319,8 @@ func convertAndStreamFromIterator(ctx context.Context, iter *genai.GenerateConte
 	candidate := &genai.Candidate{
 		Content: &genai.Content{},
 	}
+	var usageMetadata *genai.UsageMetadata
+	toolCallIndex := 0
 DoStream:
 	for {
 		resp, err := iter.Next()
@@ -306,6 +330,10 @@ DoStream:
 		if err != nil {
 			return nil, fmt.Errorf("error in stream mode: %w", err)
 		}
+		// Each chunk reports the usage of the stream so far.
+		if resp.UsageMetadata != nil {
+			usageMetadata = resp.UsageMetadata
+		}
 
 		if len(resp.Candidates) != 1 {
 			return nil, fmt.Errorf("expect single candidate in stream mode; got %v", len(resp.Candidates))
@@ -322,15 +350,64 @@ DoStream:
 		candidate.CitationMetadata = respCandidate.CitationMetadata
 
 		for _, part := range respCandidate.Content.Parts {
-			if text, ok := part.(genai.Text); ok {
+			if text, ok := part.(genai.Text); ok && opts.StreamingFunc != nil {
 				if opts.StreamingFunc(ctx, []byte(text)) != nil {
 					break DoStream
 				}
 			}
+			if opts.StreamingChunkFunc != nil {
+				if err := streamPart(ctx, opts, part, &toolCallIndex); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	resp, err := convertCandidates([]*genai.Candidate{candidate}, usageMetadata)
+	if err != nil || opts.StreamingChunkFunc == nil {
+		return resp, err
+	}
+	chunks := []llms.ContentChunk{{Type: llms.ContentChunkTypeStop, StopReason: resp.Choices[0].StopReason}}
+	if resp.Usage != nil {
+		chunks = append(chunks, llms.ContentChunk{Type: llms.ContentChunkTypeUsage, Usage: resp.Usage})
+	}
+	for _, chunk := range chunks {
+		if err := opts.StreamingChunkFunc(ctx, chunk); err != nil {
+			return nil, fmt.Errorf("streaming chunk func returned an error: %w", err)
 		}
 	}
+	return resp, nil
+}
 
-	return convertCandidates([]*genai.Candidate{candidate})
+// streamPart passes a part of a streamed response to the opts-provided
+// streaming chunk function. Function calls are sent whole, so each of them
+// is a single chunk; toolCallIndex counts the function calls streamed so far.
+func streamPart(ctx context.Context, opts *llms.CallOptions, part genai.Part, toolCallIndex *int) error {
+	var chunk llms.ContentChunk
+	switch v := part.(type) {
+	case genai.Text:
+		chunk = llms.ContentChunk{Type: llms.ContentChunkTypeText, Text: string(v)}
+	case genai.FunctionCall:
+		b, err := json.Marshal(v.Args)
+		if err != nil {
+			return err
+		}
+		chunk = llms.ContentChunk{
+			Type: llms.ContentChunkTypeToolCall,
+			ToolCall: &llms.ToolCallChunk{
+				Index:     *toolCallIndex,
+				Name:      v.Name,
+				Arguments: string(b),
+			},
+		}
+		*toolCallIndex++
+	default:
+		return nil
+	}
+	if err := opts.StreamingChunkFunc(ctx, chunk); err != nil {
+		return fmt.Errorf("streaming chunk func returned an error: %w", err)
+	}
+	return nil
 }
 
 // convertTools converts from a list of langchaingo tools to a list of genai
@@ -395,8 +472,21 @@ func convertTools(tools []llms.Tool) ([]*genai.Tool, error) {
 		}
 
 		if required, ok := params["required"]; ok {
-			rs := required.([]string)
-			schema.Required = rs
+			// Parameters decoded from JSON hold []any rather than []string.
+			switch rs := required.(type) {
+			case []string:
+				schema.Required = rs
+			case []any:
+				for _, r := range rs {
+					rString, ok := r.(string)
+					if !ok {
+						return nil, fmt.Errorf("tool [%d]: expected string for required", i)
+					}
+					schema.Required = append(schema.Required, rString)
+				}
+			default:
+				return nil, fmt.Errorf("tool [%d]: expected list of strings for required", i)
+			}
 		}
 		genaiFuncDecl.Parameters = schema
 
@@ -422,6 +512,8 @@ func convertToolSchemaType(ty string) genai.Type {
 		return genai.TypeInteger
 	case "boolean":
 		return genai.TypeBoolean
+	case "array":
+		return genai.TypeArray
 	default:
 		return genai.TypeUnspecified
 	}
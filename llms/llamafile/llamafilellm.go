@@ -98,6 +98,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	req = makeLlamaOptionsFromOptions(req, opts)
 
 	streamedResponse := ""
+	var usage *llms.Usage
 	fn := func(response llamafileclient.ChatResponse) error {
 		if opts.StreamingFunc != nil && response.Content != "" {
 			if err := opts.StreamingFunc(ctx, []byte(response.Content)); err != nil {
@@ -107,6 +108,15 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		if response.Content != "" {
 			streamedResponse += response.Content
 		}
+		// The final response carries the token counts of the whole generation.
+		if response.Stop {
+			usage = &llms.Usage{
+				PromptTokens:     response.TokensEvaluated,
+				CompletionTokens: response.TokensPredicted,
+				TotalTokens:      response.TokensEvaluated + response.TokensPredicted,
+				CachedTokens:     response.TokensCached,
+			}
+		}
 
 		return nil
 	}
@@ -123,8 +133,10 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		Choices: []*llms.ContentChoice{
 			{
 				Content: streamedResponse,
+				Usage:   usage,
 			},
 		},
+		Usage: usage,
 	}, nil
 }
 
@@ -146,7 +146,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 
 	choices := createChoice(resp)
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{Choices: choices, Usage: choices[0].Usage}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
@@ -194,6 +194,11 @@ func createChoice(resp maritacaclient.ChatResponse) []*llms.ContentChoice {
 				"PromptTokens":     resp.Metrics.Usage.PromptTokens,
 				"TotalTokens":      resp.Metrics.Usage.TotalTokens,
 			},
+			Usage: &llms.Usage{
+				PromptTokens:     resp.Metrics.Usage.PromptTokens,
+				CompletionTokens: resp.Metrics.Usage.CompletionTokens,
+				TotalTokens:      resp.Metrics.Usage.TotalTokens,
+			},
 		},
 	}
 }
@@ -127,9 +127,59 @@ func mistralChatParamsFromCallOptions(callOpts *llms.CallOptions) sdk.ChatReques
 			},
 		})
 	}
+	for _, tool := range callOpts.Tools {
+		if tool.Function == nil {
+			continue
+		}
+		chatOpts.Tools = append(chatOpts.Tools, sdk.Tool{
+			Type: "function",
+			Function: sdk.Function{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			},
+		})
+	}
+	chatOpts.ToolChoice = mistralToolChoice(callOpts.ToolChoice)
 	return chatOpts
 }
 
+// mistralToolChoice converts a tool choice to its Mistral equivalent. The
+// platform cannot be asked to call a specific tool, so asking for one means
+// asking for any.
+func mistralToolChoice(choice any) string {
+	switch choice := choice.(type) {
+	case string:
+		if choice == "required" {
+			return sdk.ToolChoiceAny
+		}
+		return choice
+	case llms.ToolChoice, *llms.ToolChoice:
+		return sdk.ToolChoiceAny
+	default:
+		return ""
+	}
+}
+
+// toolCallsFromMistralToolCalls converts the tool calls of a Mistral message.
+func toolCallsFromMistralToolCalls(toolCalls []sdk.ToolCall) []llms.ToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+	result := make([]llms.ToolCall, len(toolCalls))
+	for i, tc := range toolCalls {
+		result[i] = llms.ToolCall{
+			ID:   tc.Id,
+			Type: string(tc.Type),
+			FunctionCall: &llms.FunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: tc.Function.Arguments,
+			},
+		}
+	}
+	return result
+}
+
 func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llms.CallOptions, messages []sdk.ChatMessage, chatOpts sdk.ChatRequestParams) (*llms.ContentResponse, error) {
 	res, err := m.client.Chat(callOptions.Model, messages, &chatOpts)
 	m.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, nil)
@@ -145,6 +195,7 @@ func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llm
 
 	langchainContentResponse := &llms.ContentResponse{
 		Choices: make([]*llms.ContentChoice, 0),
+		Usage:   usageFromUsageInfo(res.Usage),
 	}
 	for idx, choice := range res.Choices {
 		langchainContentResponse.Choices = append(langchainContentResponse.Choices, &llms.ContentChoice{
@@ -155,10 +206,12 @@ func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llm
 				"model":   res.Model,
 				"usage":   res.Usage,
 			},
+			Usage: langchainContentResponse.Usage,
 		})
 		toolCalls := choice.Message.ToolCalls
 		if len(toolCalls) > 0 {
 			langchainContentResponse.Choices[idx].FuncCall = (*llms.FunctionCall)(&toolCalls[0].Function)
+			langchainContentResponse.Choices[idx].ToolCalls = toolCallsFromMistralToolCalls(toolCalls)
 		}
 	}
 	m.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, langchainContentResponse)
@@ -185,6 +238,11 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 		langchainContentResponse.Choices[0].GenerationInfo["created"] = chatResChunk.Created
 		langchainContentResponse.Choices[0].GenerationInfo["model"] = chatResChunk.Model
 		langchainContentResponse.Choices[0].GenerationInfo["usage"] = chatResChunk.Usage
+		// Only the last chunk of the stream reports the usage.
+		if chatResChunk.Usage.TotalTokens > 0 {
+			langchainContentResponse.Usage = usageFromUsageInfo(chatResChunk.Usage)
+			langchainContentResponse.Choices[0].Usage = langchainContentResponse.Usage
+		}
 		if chatResChunk.Error == nil {
 			for _, choice := range chatResChunk.Choices {
 				chunkStr += choice.Delta.Content
@@ -192,6 +250,8 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 				langchainContentResponse.Choices[0].StopReason = string(choice.FinishReason)
 				if len(choice.Delta.ToolCalls) > 0 {
 					langchainContentResponse.Choices[0].FuncCall = (*llms.FunctionCall)(&choice.Delta.ToolCalls[0].Function)
+					langchainContentResponse.Choices[0].ToolCalls = append(langchainContentResponse.Choices[0].ToolCalls,
+						toolCallsFromMistralToolCalls(choice.Delta.ToolCalls)...)
 				}
 			}
 			err := callOptions.StreamingFunc(ctx, []byte(chunkStr))
@@ -206,21 +266,46 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 	return langchainContentResponse, nil
 }
 
+func usageFromUsageInfo(usage sdk.UsageInfo) *llms.Usage {
+	return &llms.Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+}
+
 func convertToMistralChatMessages(langchainMessages []llms.MessageContent) ([]sdk.ChatMessage, error) {
 	messages := make([]sdk.ChatMessage, 0)
 	for _, msg := range langchainMessages {
 		msgText := ""
+		var toolCalls []sdk.ToolCall
 		for _, part := range msg.Parts {
-			textContent, ok := part.(llms.TextContent)
-			if !ok {
+			switch p := part.(type) {
+			case llms.TextContent:
+				msgText += p.Text
+			case llms.ToolCall:
+				if p.FunctionCall == nil {
+					return nil, errors.New("tool call without function call")
+				}
+				toolCalls = append(toolCalls, sdk.ToolCall{
+					Id:   p.ID,
+					Type: sdk.ToolTypeFunction,
+					Function: sdk.FunctionCall{
+						Name:      p.FunctionCall.Name,
+						Arguments: p.FunctionCall.Arguments,
+					},
+				})
+			case llms.ToolCallResponse:
+				// Each tool response is a message of its own.
+				messages = append(messages, sdk.ChatMessage{Role: "tool", Name: p.Name, Content: p.Content})
+			default:
 				return nil, errors.New("unsupported content type encountered while preparing chat messages to send to mistral platform")
 			}
-			msgText += textContent.Text
 		}
-		chatMsg := sdk.ChatMessage{Content: msgText, Role: "user"}
+		chatMsg := sdk.ChatMessage{Content: msgText, Role: "user", ToolCalls: toolCalls}
 
 		setMistralChatMessageRole(&msg, &chatMsg) // #nosec G601
-		if chatMsg.Content != "" && chatMsg.Role != "" {
+		if (chatMsg.Content != "" || len(chatMsg.ToolCalls) > 0) && chatMsg.Role != "" {
 			messages = append(messages, chatMsg)
 		}
 	}
@@ -1,6 +1,7 @@
 package ollamaclient
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -41,9 +42,38 @@ type GenerateRequest struct {
 type ImageData []byte
 
 type Message struct {
-	Role    string      `json:"role"` // one of ["system", "user", "assistant"]
-	Content string      `json:"content"`
-	Images  []ImageData `json:"images,omitempty"`
+	Role      string      `json:"role"` // one of ["system", "user", "assistant", "tool"]
+	Content   string      `json:"content"`
+	Images    []ImageData `json:"images,omitempty"`
+	ToolCalls []ToolCall  `json:"tool_calls,omitempty"`
+	// ToolName is the name of the tool whose result is in Content.
+	// Only present in tool messages.
+	ToolName string `json:"tool_name,omitempty"`
+}
+
+// ToolCall is a call to a tool requested by the model.
+type ToolCall struct {
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction is the function invoked by a ToolCall. Unlike OpenAI,
+// Ollama sends the arguments as a JSON object rather than a string.
+type ToolCallFunction struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// Tool is a tool the model may call.
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction is the definition of a function tool.
+type ToolFunction struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Parameters  any    `json:"parameters"`
 }
 
 type ChatRequest struct {
@@ -52,6 +82,7 @@ type ChatRequest struct {
 	Stream    *bool      `json:"stream,omitempty"`
 	Format    string     `json:"format"`
 	KeepAlive string     `json:"keep_alive,omitempty"`
+	Tools     []Tool     `json:"tools,omitempty"`
 
 	Options Options `json:"options"`
 }
@@ -95,7 +126,8 @@ type ChatResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 	Message   *Message  `json:"message,omitempty"`
 
-	Done bool `json:"done"`
+	Done       bool   `json:"done"`
+	DoneReason string `json:"done_reason,omitempty"`
 
 	Metrics
 }
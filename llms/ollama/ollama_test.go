@@ -3,6 +3,9 @@ package ollama
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -129,3 +132,196 @@ func TestWithKeepAlive(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, vector)
 }
+
+var weatherTool = llms.Tool{
+	Type: "function",
+	Function: &llms.FunctionDefinition{
+		Name:        "getCurrentWeather",
+		Description: "Get the current weather in a given location",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"location": map[string]any{"type": "string"},
+			},
+			"required": []string{"location"},
+		},
+	},
+}
+
+// newFakeServer returns an httptest stand-in for the Ollama chat endpoint that
+// records the decoded requests and replies with the given ndjson lines.
+func newFakeServer(t *testing.T, lines []string, requests *[]map[string]any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		var req map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGenerateContentToolCalls(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newFakeServer(t, []string{
+		`{"model":"llama3.1","message":{"role":"assistant","content":"","tool_calls":[` +
+			`{"function":{"name":"getCurrentWeather","arguments":{"location":"Boston"}}},` +
+			`{"function":{"name":"getCurrentWeather","arguments":{"location":"Chicago"}}}]},` +
+			`"done":true,"prompt_eval_count":10,"eval_count":20}`,
+	}, &requests)
+
+	llm, err := New(WithServerURL(srv.URL), WithModel("llama3.1"))
+	require.NoError(t, err)
+
+	rsp, err := llm.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston and Chicago?")},
+		llms.WithTools([]llms.Tool{weatherTool}))
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	sentTools, err := json.Marshal(requests[0]["tools"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"type":"function","function":{
+		"name":"getCurrentWeather",
+		"description":"Get the current weather in a given location",
+		"parameters":{"type":"object","properties":{"location":{"type":"string"}},"required":["location"]}
+	}}]`, string(sentTools))
+
+	c1 := rsp.Choices[0]
+	require.Len(t, c1.ToolCalls, 2)
+	assert.NotEmpty(t, c1.ToolCalls[0].ID)
+	assert.NotEqual(t, c1.ToolCalls[0].ID, c1.ToolCalls[1].ID)
+	assert.Equal(t, "function", c1.ToolCalls[0].Type)
+	assert.Equal(t, "getCurrentWeather", c1.ToolCalls[0].FunctionCall.Name)
+	assert.JSONEq(t, `{"location":"Boston"}`, c1.ToolCalls[0].FunctionCall.Arguments)
+	assert.JSONEq(t, `{"location":"Chicago"}`, c1.ToolCalls[1].FunctionCall.Arguments)
+	assert.Equal(t, c1.ToolCalls[0].FunctionCall, c1.FuncCall)
+}
+
+func TestGenerateContentToolResponses(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newFakeServer(t, []string{
+		`{"model":"llama3.1","message":{"role":"assistant","content":"Sunny and rainy."},"done":true}`,
+	}, &requests)
+
+	llm, err := New(WithServerURL(srv.URL), WithModel("llama3.1"))
+	require.NoError(t, err)
+
+	messages := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston and Chicago?"),
+		{
+			Role: llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{
+				llms.ToolCall{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "getCurrentWeather", Arguments: `{"location":"Boston"}`}},
+				llms.ToolCall{ID: "call_2", Type: "function", FunctionCall: &llms.FunctionCall{Name: "getCurrentWeather", Arguments: `{"location":"Chicago"}`}},
+			},
+		},
+		{
+			Role: llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{
+				llms.ToolCallResponse{ToolCallID: "call_1", Name: "getCurrentWeather", Content: "sunny"},
+				llms.ToolCallResponse{ToolCallID: "call_2", Name: "getCurrentWeather", Content: "rainy"},
+			},
+		},
+	}
+	rsp, err := llm.GenerateContent(context.Background(), messages, llms.WithTools([]llms.Tool{weatherTool}))
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny and rainy.", rsp.Choices[0].Content)
+	assert.Empty(t, rsp.Choices[0].ToolCalls)
+
+	require.Len(t, requests, 1)
+	sentMessages, err := json.Marshal(requests[0]["messages"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"role":"user","content":"Weather in Boston and Chicago?"},
+		{"role":"assistant","content":"","tool_calls":[
+			{"function":{"name":"getCurrentWeather","arguments":{"location":"Boston"}}},
+			{"function":{"name":"getCurrentWeather","arguments":{"location":"Chicago"}}}
+		]},
+		{"role":"tool","content":"sunny","tool_name":"getCurrentWeather"},
+		{"role":"tool","content":"rainy","tool_name":"getCurrentWeather"}
+	]`, string(sentMessages))
+}
+
+func TestGenerateContentToolCallsStreaming(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newFakeServer(t, []string{
+		`{"model":"llama3.1","message":{"role":"assistant","content":"Let me check. "},"done":false}`,
+		`{"model":"llama3.1","message":{"role":"assistant","content":"","tool_calls":[` +
+			`{"function":{"name":"getCurrentWeather","arguments":{"location":"Boston"}}}]},"done":false}`,
+		`{"model":"llama3.1","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":10,"eval_count":20}`,
+	}, &requests)
+
+	llm, err := New(WithServerURL(srv.URL), WithModel("llama3.1"))
+	require.NoError(t, err)
+
+	var chunks []string
+	rsp, err := llm.GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston?")},
+		llms.WithTools([]llms.Tool{weatherTool}),
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		}))
+	require.NoError(t, err)
+
+	assert.Equal(t, true, requests[0]["stream"])
+	require.Len(t, chunks, 3)
+	assert.Equal(t, "Let me check. ", chunks[0])
+	assert.JSONEq(t, `[{"function":{"name":"getCurrentWeather","arguments":{"location":"Boston"}}}]`, chunks[1])
+
+	c1 := rsp.Choices[0]
+	assert.Equal(t, "Let me check. ", c1.Content)
+	require.Len(t, c1.ToolCalls, 1)
+	assert.JSONEq(t, `{"location":"Boston"}`, c1.ToolCalls[0].FunctionCall.Arguments)
+	assert.Equal(t, 30, c1.GenerationInfo["TotalTokens"])
+	assert.Equal(t, &llms.Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30}, rsp.Usage)
+}
+
+func TestStreamContent(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	srv := newFakeServer(t, []string{
+		`{"model":"llama3.1","message":{"role":"assistant","content":"Let me check. "},"done":false}`,
+		`{"model":"llama3.1","message":{"role":"assistant","content":"","tool_calls":[` +
+			`{"function":{"name":"getCurrentWeather","arguments":{"location":"Boston"}}}]},"done":false}`,
+		`{"model":"llama3.1","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop",` +
+			`"prompt_eval_count":10,"eval_count":20}`,
+	}, &requests)
+
+	llm, err := New(WithServerURL(srv.URL), WithModel("llama3.1"))
+	require.NoError(t, err)
+
+	var chunks []llms.ContentChunk
+	for chunk := range llms.StreamContent(context.Background(), llm,
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Boston?")},
+		llms.WithTools([]llms.Tool{weatherTool}),
+	) {
+		chunks = append(chunks, chunk)
+	}
+
+	assert.Equal(t, true, requests[0]["stream"])
+	require.Len(t, chunks, 4)
+	assert.Equal(t, llms.ContentChunk{Type: llms.ContentChunkTypeText, Text: "Let me check. "}, chunks[0])
+	assert.Equal(t, llms.ContentChunkTypeToolCall, chunks[1].Type)
+	assert.Equal(t, 0, chunks[1].ToolCall.Index)
+	assert.NotEmpty(t, chunks[1].ToolCall.ID)
+	assert.Equal(t, "getCurrentWeather", chunks[1].ToolCall.Name)
+	assert.JSONEq(t, `{"location":"Boston"}`, chunks[1].ToolCall.Arguments)
+	assert.Equal(t, llms.ContentChunk{Type: llms.ContentChunkTypeStop, StopReason: "stop"}, chunks[2])
+	assert.Equal(t, llms.ContentChunk{
+		Type:  llms.ContentChunkTypeUsage,
+		Usage: &llms.Usage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30},
+	}, chunks[3])
+}
@@ -2,8 +2,11 @@ package ollama
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/ollama/internal/ollamaclient"
@@ -61,39 +64,9 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		model = opts.Model
 	}
 
-	// Our input is a sequence of MessageContent, each of which potentially has
-	// a sequence of Part that could be text, images etc.
-	// We have to convert it to a format Ollama undestands: ChatRequest, which
-	// has a sequence of Message, each of which has a role and content - single
-	// text + potential images.
-	chatMsgs := make([]*ollamaclient.Message, 0, len(messages))
-	for _, mc := range messages {
-		msg := &ollamaclient.Message{Role: typeToRole(mc.Role)}
-
-		// Look at all the parts in mc; expect to find a single Text part and
-		// any number of binary parts.
-		var text string
-		foundText := false
-		var images []ollamaclient.ImageData
-
-		for _, p := range mc.Parts {
-			switch pt := p.(type) {
-			case llms.TextContent:
-				if foundText {
-					return nil, errors.New("expecting a single Text content")
-				}
-				foundText = true
-				text = pt.Text
-			case llms.BinaryContent:
-				images = append(images, ollamaclient.ImageData(pt.Data))
-			default:
-				return nil, errors.New("only support Text and BinaryContent parts right now")
-			}
-		}
-
-		msg.Content = text
-		msg.Images = images
-		chatMsgs = append(chatMsgs, msg)
+	chatMsgs, err := makeChatMessages(messages)
+	if err != nil {
+		return nil, err
 	}
 
 	format := o.options.format
@@ -108,7 +81,8 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		Format:   format,
 		Messages: chatMsgs,
 		Options:  ollamaOptions,
-		Stream:   func(b bool) *bool { return &b }(opts.StreamingFunc != nil),
+		Stream:   func(b bool) *bool { return &b }(opts.StreamingFunc != nil || opts.StreamingChunkFunc != nil),
+		Tools:    makeTools(opts),
 	}
 
 	keepAlive := o.options.keepAlive
@@ -118,16 +92,20 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 
 	var fn ollamaclient.ChatResponseFunc
 	streamedResponse := ""
+	var streamedToolCalls []llms.ToolCall
 	var resp ollamaclient.ChatResponse
 
 	fn = func(response ollamaclient.ChatResponse) error {
-		if opts.StreamingFunc != nil && response.Message != nil {
-			if err := opts.StreamingFunc(ctx, []byte(response.Message.Content)); err != nil {
-				return err
-			}
+		var toolCalls []llms.ToolCall
+		if response.Message != nil {
+			toolCalls = toolCallsFromToolCalls(response.Message.ToolCalls)
+		}
+		if err := streamChatResponse(ctx, opts, response, toolCalls, len(streamedToolCalls)); err != nil {
+			return err
 		}
 		if response.Message != nil {
 			streamedResponse += response.Message.Content
+			streamedToolCalls = append(streamedToolCalls, toolCalls...)
 		}
 		if response.Done {
 			resp = response
@@ -139,14 +117,22 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		return nil
 	}
 
-	err := o.client.GenerateChat(ctx, req, fn)
+	err = o.client.GenerateChat(ctx, req, fn)
 	if err != nil {
 		if o.CallbacksHandler != nil {
 			o.CallbacksHandler.HandleLLMError(ctx, err)
 		}
 		return nil, err
 	}
+	if resp.Message == nil {
+		return nil, ErrEmptyResponse
+	}
 
+	usage := &llms.Usage{
+		PromptTokens:     resp.PromptEvalCount,
+		CompletionTokens: resp.EvalCount,
+		TotalTokens:      resp.PromptEvalCount + resp.EvalCount,
+	}
 	choices := []*llms.ContentChoice{
 		{
 			Content: resp.Message.Content,
@@ -155,10 +141,17 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				"PromptTokens":     resp.PromptEvalCount,
 				"TotalTokens":      resp.EvalCount + resp.PromptEvalCount,
 			},
+			StopReason: resp.DoneReason,
+			ToolCalls:  streamedToolCalls,
+			Usage:      usage,
 		},
 	}
+	// populate legacy single-function call field for backwards compatibility
+	if len(choices[0].ToolCalls) > 0 {
+		choices[0].FuncCall = choices[0].ToolCalls[0].FunctionCall
+	}
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{Choices: choices, Usage: usage}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
@@ -167,6 +160,61 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	return response, nil
 }
 
+// streamChatResponse passes a response of a streaming chat to the streaming
+// functions in opts. toolCalls are the tool calls of the response and
+// toolCallIndex the number of tool calls streamed before them.
+func streamChatResponse(ctx context.Context, opts llms.CallOptions, response ollamaclient.ChatResponse, toolCalls []llms.ToolCall, toolCallIndex int) error {
+	if opts.StreamingFunc != nil && response.Message != nil {
+		chunk := []byte(response.Message.Content)
+		if len(response.Message.ToolCalls) > 0 {
+			// Ollama sends each tool call whole rather than as deltas, so
+			// the complete calls are forwarded, as the OpenAI backend does.
+			chunk, _ = json.Marshal(response.Message.ToolCalls) // nolint:errchkjson
+		}
+		if err := opts.StreamingFunc(ctx, chunk); err != nil {
+			return err
+		}
+	}
+	if opts.StreamingChunkFunc == nil {
+		return nil
+	}
+
+	var chunks []llms.ContentChunk
+	if response.Message != nil && response.Message.Content != "" {
+		chunks = append(chunks, llms.ContentChunk{Type: llms.ContentChunkTypeText, Text: response.Message.Content})
+	}
+	for i, tc := range toolCalls {
+		chunks = append(chunks, llms.ContentChunk{
+			Type: llms.ContentChunkTypeToolCall,
+			ToolCall: &llms.ToolCallChunk{
+				Index:     toolCallIndex + i,
+				ID:        tc.ID,
+				Name:      tc.FunctionCall.Name,
+				Arguments: tc.FunctionCall.Arguments,
+			},
+		})
+	}
+	if response.Done {
+		if response.DoneReason != "" {
+			chunks = append(chunks, llms.ContentChunk{Type: llms.ContentChunkTypeStop, StopReason: response.DoneReason})
+		}
+		chunks = append(chunks, llms.ContentChunk{
+			Type: llms.ContentChunkTypeUsage,
+			Usage: &llms.Usage{
+				PromptTokens:     response.PromptEvalCount,
+				CompletionTokens: response.EvalCount,
+				TotalTokens:      response.PromptEvalCount + response.EvalCount,
+			},
+		})
+	}
+	for _, chunk := range chunks {
+		if err := opts.StreamingChunkFunc(ctx, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float32, error) {
 	embeddings := [][]float32{}
 
@@ -198,6 +246,134 @@ func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]flo
 	return embeddings, nil
 }
 
+// makeChatMessages converts our input, a sequence of MessageContent, each of
+// which potentially has a sequence of Part that could be text, images etc., to
+// a format Ollama understands: a sequence of Message, each of which has a role
+// and content - single text + potential images - or tool calls and results.
+func makeChatMessages(messages []llms.MessageContent) ([]*ollamaclient.Message, error) {
+	chatMsgs := make([]*ollamaclient.Message, 0, len(messages))
+	for _, mc := range messages {
+		msg := &ollamaclient.Message{Role: typeToRole(mc.Role)}
+
+		// Look at all the parts in mc; expect to find a single Text part and
+		// any number of binary parts and tool calls.
+		var text string
+		foundText := false
+		var images []ollamaclient.ImageData
+
+		for _, p := range mc.Parts {
+			switch pt := p.(type) {
+			case llms.TextContent:
+				if foundText {
+					return nil, errors.New("expecting a single Text content")
+				}
+				foundText = true
+				text = pt.Text
+			case llms.BinaryContent:
+				images = append(images, ollamaclient.ImageData(pt.Data))
+			case llms.ToolCall:
+				toolCall, err := toolCallFromToolCall(pt)
+				if err != nil {
+					return nil, err
+				}
+				msg.ToolCalls = append(msg.ToolCalls, toolCall)
+			case llms.ToolCallResponse:
+				// Ollama expects one message per tool result.
+				chatMsgs = append(chatMsgs, &ollamaclient.Message{
+					Role:     "tool",
+					Content:  pt.Content,
+					ToolName: pt.Name,
+				})
+			default:
+				return nil, errors.New("only support Text, BinaryContent, ToolCall and ToolCallResponse parts right now")
+			}
+		}
+		if mc.Role == llms.ChatMessageTypeTool && !foundText && len(images) == 0 {
+			continue
+		}
+
+		msg.Content = text
+		msg.Images = images
+		chatMsgs = append(chatMsgs, msg)
+	}
+	return chatMsgs, nil
+}
+
+// makeTools converts the tools and legacy functions of the call options to
+// Ollama tools. Ollama has no notion of a tool choice, so tools are only
+// dropped when the caller asked for none to be used.
+func makeTools(opts llms.CallOptions) []ollamaclient.Tool {
+	if choice, ok := opts.ToolChoice.(string); ok && choice == "none" {
+		return nil
+	}
+
+	var tools []ollamaclient.Tool
+	for _, fn := range opts.Functions {
+		tools = append(tools, ollamaclient.Tool{
+			Type: "function",
+			Function: ollamaclient.ToolFunction{
+				Name:        fn.Name,
+				Description: fn.Description,
+				Parameters:  fn.Parameters,
+			},
+		})
+	}
+	for _, tool := range opts.Tools {
+		if tool.Function == nil {
+			continue
+		}
+		tools = append(tools, ollamaclient.Tool{
+			Type: tool.Type,
+			Function: ollamaclient.ToolFunction{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			},
+		})
+	}
+	return tools
+}
+
+// toolCallFromToolCall converts an llms.ToolCall to an Ollama tool call.
+func toolCallFromToolCall(tc llms.ToolCall) (ollamaclient.ToolCall, error) {
+	if tc.FunctionCall == nil {
+		return ollamaclient.ToolCall{}, fmt.Errorf("tool call %v has no function call", tc.ID)
+	}
+	arguments := json.RawMessage(tc.FunctionCall.Arguments)
+	if len(arguments) == 0 {
+		arguments = json.RawMessage("{}")
+	}
+	if !json.Valid(arguments) {
+		return ollamaclient.ToolCall{}, fmt.Errorf("invalid arguments for tool call %v", tc.ID)
+	}
+	return ollamaclient.ToolCall{
+		Function: ollamaclient.ToolCallFunction{
+			Name:      tc.FunctionCall.Name,
+			Arguments: arguments,
+		},
+	}, nil
+}
+
+// toolCallsFromToolCalls converts Ollama tool calls to llms.ToolCalls. Ollama
+// does not identify tool calls, so a unique ID is generated for each of them.
+func toolCallsFromToolCalls(tcs []ollamaclient.ToolCall) []llms.ToolCall {
+	if len(tcs) == 0 {
+		return nil
+	}
+	toolCalls := make([]llms.ToolCall, len(tcs))
+	for i, tc := range tcs {
+		toolCalls[i] = llms.ToolCall{
+			ID:   "call_" + uuid.NewString(),
+			Type: "function",
+			FunctionCall: &llms.FunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: string(tc.Function.Arguments),
+			},
+		}
+	}
+	return toolCalls
+}
+
 func typeToRole(typ llms.ChatMessageType) string {
 	switch typ {
 	case llms.ChatMessageTypeSystem:
@@ -29,6 +29,7 @@ type ChatRequest struct {
 	N                int            `json:"n,omitempty"`
 	StopWords        []string       `json:"stop,omitempty"`
 	Stream           bool           `json:"stream,omitempty"`
+	StreamOptions    *StreamOptions `json:"stream_options,omitempty"`
 	FrequencyPenalty float64        `json:"frequency_penalty,omitempty"`
 	PresencePenalty  float64        `json:"presence_penalty,omitempty"`
 	Seed             int            `json:"seed,omitempty"`
@@ -53,6 +54,9 @@ type ChatRequest struct {
 	// StreamingFunc is a function to be called for each chunk of a streaming response.
 	// Return an error to stop streaming early.
 	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	// StreamingChunkFunc is a function to be called for each typed chunk of a
+	// streaming response. Return an error to stop streaming early.
+	StreamingChunkFunc func(ctx context.Context, chunk llms.ContentChunk) error `json:"-"`
 
 	// Deprecated: use Tools instead.
 	Functions []FunctionDefinition `json:"functions,omitempty"`
@@ -63,6 +67,13 @@ type ChatRequest struct {
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
+// StreamOptions are options for streaming responses.
+type StreamOptions struct {
+	// IncludeUsage makes the API send an additional chunk with the token usage
+	// of the request before the end of the stream.
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
 // ToolType is the type of a tool.
 type ToolType string
 
@@ -98,6 +109,16 @@ type ToolCall struct {
 // ResponseFormat is the format of the response.
 type ResponseFormat struct {
 	Type string `json:"type"`
+	// JSONSchema is the schema of the response, if Type is "json_schema".
+	JSONSchema *ResponseFormatJSONSchema `json:"json_schema,omitempty"`
+}
+
+// ResponseFormatJSONSchema is the schema of a "json_schema" response format.
+type ResponseFormatJSONSchema struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      any    `json:"schema"`
+	Strict      bool   `json:"strict,omitempty"`
 }
 
 // ChatMessage is a message in a chat request.
@@ -252,6 +273,13 @@ type ChatUsage struct {
 	PromptTokens     int `json:"prompt_tokens"`
 	CompletionTokens int `json:"completion_tokens"`
 	TotalTokens      int `json:"total_tokens"`
+
+	PromptTokensDetails struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"prompt_tokens_details"`
+	CompletionTokensDetails struct {
+		ReasoningTokens int `json:"reasoning_tokens"`
+	} `json:"completion_tokens_details"`
 }
 
 // ChatCompletionResponse is a response to a chat request.
@@ -282,7 +310,9 @@ type StreamedChatResponsePayload struct {
 		} `json:"delta,omitempty"`
 		FinishReason FinishReason `json:"finish_reason,omitempty"`
 	} `json:"choices,omitempty"`
-	Error error `json:"-"` // use for error handling only
+	// Usage is only set on the last chunk, when requested via StreamOptions.
+	Usage *ChatUsage `json:"usage,omitempty"`
+	Error error      `json:"-"` // use for error handling only
 }
 
 // FunctionDefinition is a definition of a function that can be called by the model.
@@ -316,8 +346,14 @@ type FunctionCall struct {
 }
 
 func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatCompletionResponse, error) {
-	if payload.StreamingFunc != nil {
+	if payload.StreamingFunc != nil || payload.StreamingChunkFunc != nil {
 		payload.Stream = true
+		// The usage is only requested for the chunk streaming function, which
+		// reports it, since some OpenAI compatible servers reject stream
+		// options. Azure rejects them on older API versions.
+		if payload.StreamingChunkFunc != nil && !IsAzure(c.apiType) {
+			payload.StreamOptions = &StreamOptions{IncludeUsage: true}
+		}
 	}
 	// Build request payload
 
@@ -352,12 +388,12 @@ func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatCom
 		// status code.
 		var errResp errorMessage
 		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg) // nolint:goerr113
+			return nil, llms.NewProviderError(r.StatusCode, r.Header, errors.New(msg)) // nolint:goerr113
 		}
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message) // nolint:goerr113
+		return nil, llms.NewProviderError(r.StatusCode, r.Header, fmt.Errorf("%s: %s", msg, errResp.Error.Message)) // nolint:goerr113
 	}
-	if payload.StreamingFunc != nil {
+	if payload.Stream {
 		return parseStreamingChatResponse(ctx, r, payload)
 	}
 	// Parse response
@@ -411,6 +447,10 @@ func combineStreamingChatResponse(ctx context.Context, payload *ChatRequest, res
 			return nil, streamResponse.Error
 		}
 
+		if streamResponse.Usage != nil {
+			response.Usage = *streamResponse.Usage
+		}
+
 		if len(streamResponse.Choices) == 0 {
 			continue
 		}
@@ -423,7 +463,9 @@ func combineStreamingChatResponse(ctx context.Context, payload *ChatRequest, res
 			chunk = updateFunctionCall(response.Choices[0].Message, choice.Delta.FunctionCall)
 		}
 
+		var toolCallChunks []llms.ContentChunk
 		if len(choice.Delta.ToolCalls) > 0 {
+			toolCallChunks = toolCallContentChunks(response.Choices[0].Message.ToolCalls, choice.Delta.ToolCalls)
 			chunk, response.Choices[0].Message.ToolCalls = updateToolCalls(response.Choices[0].Message.ToolCalls, choice.Delta.ToolCalls)
 		}
 
@@ -433,10 +475,70 @@ func combineStreamingChatResponse(ctx context.Context, payload *ChatRequest, res
 				return nil, fmt.Errorf("streaming func returned an error: %w", err)
 			}
 		}
+
+		if payload.StreamingChunkFunc != nil {
+			var chunks []llms.ContentChunk
+			if choice.Delta.Content != "" {
+				chunks = append(chunks, llms.ContentChunk{Type: llms.ContentChunkTypeText, Text: choice.Delta.Content})
+			}
+			chunks = append(chunks, toolCallChunks...)
+			if choice.FinishReason != "" {
+				chunks = append(chunks, llms.ContentChunk{Type: llms.ContentChunkTypeStop, StopReason: string(choice.FinishReason)})
+			}
+			for _, c := range chunks {
+				if err := payload.StreamingChunkFunc(ctx, c); err != nil {
+					return nil, fmt.Errorf("streaming chunk func returned an error: %w", err)
+				}
+			}
+		}
+	}
+
+	// The usage, if requested, arrives in a chunk of its own after the last choice.
+	if payload.StreamingChunkFunc != nil && response.Usage.TotalTokens > 0 {
+		err := payload.StreamingChunkFunc(ctx, llms.ContentChunk{
+			Type: llms.ContentChunkTypeUsage,
+			Usage: &llms.Usage{
+				PromptTokens:     response.Usage.PromptTokens,
+				CompletionTokens: response.Usage.CompletionTokens,
+				TotalTokens:      response.Usage.TotalTokens,
+				CachedTokens:     response.Usage.PromptTokensDetails.CachedTokens,
+				ReasoningTokens:  response.Usage.CompletionTokensDetails.ReasoningTokens,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("streaming chunk func returned an error: %w", err)
+		}
 	}
 	return &response, nil
 }
 
+// toolCallContentChunks converts tool call deltas to chunks, given the tool
+// calls accumulated before the deltas are applied. As in updateToolCalls, a
+// delta without a type continues the arguments of the last tool call.
+func toolCallContentChunks(tools []ToolCall, delta []*ToolCall) []llms.ContentChunk {
+	index := len(tools) - 1
+	chunks := make([]llms.ContentChunk, 0, len(delta))
+	for _, t := range delta {
+		if t.Type == `` && t.Function.Arguments != `` {
+			if index < 0 {
+				continue
+			}
+		} else {
+			index++
+		}
+		chunks = append(chunks, llms.ContentChunk{
+			Type: llms.ContentChunkTypeToolCall,
+			ToolCall: &llms.ToolCallChunk{
+				Index:     index,
+				ID:        t.ID,
+				Name:      t.Function.Name,
+				Arguments: t.Function.Arguments,
+			},
+		})
+	}
+	return chunks
+}
+
 func updateFunctionCall(message ChatMessage, functionCall *FunctionCall) []byte {
 	if message.FunctionCall == nil {
 		message.FunctionCall = functionCall
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
 )
 
 func TestParseStreamingChatResponse_FinishReason(t *testing.T) {
@@ -52,3 +53,112 @@ func TestChatMessage_MarshalUnmarshal(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, msg, msg2)
 }
+
+func TestParseStreamingChatResponse_Usage(t *testing.T) {
+	t.Parallel()
+	mockBody := `data: {"choices":[{"index":0,"delta":{"role":"assistant","content":"hello"},"finish_reason":"stop"}]}
+
+data: {"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15,"prompt_tokens_details":{"cached_tokens":8}}}
+
+data: [DONE]
+`
+	r := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(mockBody)),
+	}
+
+	req := &ChatRequest{
+		StreamingFunc: func(_ context.Context, _ []byte) error {
+			return nil
+		},
+	}
+
+	resp, err := parseStreamingChatResponse(context.Background(), r, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", resp.Choices[0].Message.Content)
+	assert.Equal(t, 12, resp.Usage.PromptTokens)
+	assert.Equal(t, 3, resp.Usage.CompletionTokens)
+	assert.Equal(t, 15, resp.Usage.TotalTokens)
+	assert.Equal(t, 8, resp.Usage.PromptTokensDetails.CachedTokens)
+}
+
+func TestParseStreamingChatResponse_Chunks(t *testing.T) {
+	t.Parallel()
+	mockBody := `data: {"choices":[{"index":0,"delta":{"role":"assistant","content":"Let me check."}}]}
+
+data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"weather","arguments":""}}]}}]}
+
+data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":\"Paris\"}"}}]}}]}
+
+data: {"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}
+
+data: {"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}
+
+data: [DONE]
+`
+	r := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(mockBody)),
+	}
+
+	var chunks []llms.ContentChunk
+	req := &ChatRequest{
+		StreamingChunkFunc: func(_ context.Context, chunk llms.ContentChunk) error {
+			chunks = append(chunks, chunk)
+			return nil
+		},
+	}
+
+	resp, err := parseStreamingChatResponse(context.Background(), r, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"city":"Paris"}`, resp.Choices[0].Message.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, []llms.ContentChunk{
+		{Type: llms.ContentChunkTypeText, Text: "Let me check."},
+		{Type: llms.ContentChunkTypeToolCall, ToolCall: &llms.ToolCallChunk{Index: 0, ID: "call_1", Name: "weather"}},
+		{Type: llms.ContentChunkTypeToolCall, ToolCall: &llms.ToolCallChunk{Index: 0, Arguments: `{"city":"Paris"}`}},
+		{Type: llms.ContentChunkTypeStop, StopReason: "tool_calls"},
+		{Type: llms.ContentChunkTypeUsage, Usage: &llms.Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}},
+	}, chunks)
+}
+
+// requestRecorder records the bodies of the requests, and replies with an
+// empty stream.
+type requestRecorder struct {
+	bodies []map[string]any
+}
+
+func (d *requestRecorder) Do(req *http.Request) (*http.Response, error) {
+	var body map[string]any
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	d.bodies = append(d.bodies, body)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString("data: [DONE]\n")),
+	}, nil
+}
+
+func TestCreateChatStreamOptions(t *testing.T) {
+	t.Parallel()
+
+	doer := &requestRecorder{}
+	c, err := New("token", "gpt-4o", "http://localhost", "", APITypeOpenAI, "", doer, "")
+	require.NoError(t, err)
+
+	_, err = c.CreateChat(context.Background(), &ChatRequest{
+		StreamingFunc: func(_ context.Context, _ []byte) error { return nil },
+	})
+	require.NoError(t, err)
+	_, err = c.CreateChat(context.Background(), &ChatRequest{
+		StreamingChunkFunc: func(_ context.Context, _ llms.ContentChunk) error { return nil },
+	})
+	require.NoError(t, err)
+
+	// the usage is only requested when it is streamed.
+	require.Len(t, doer.bodies, 2)
+	assert.NotContains(t, doer.bodies[0], "stream_options")
+	assert.Equal(t, map[string]any{"include_usage": true}, doer.bodies[1]["stream_options"])
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/tmc/langchaingo/llms"
 )
 
 const (
@@ -67,10 +69,10 @@ func (c *Client) createEmbedding(ctx context.Context, payload *embeddingPayload)
 		// status code.
 		var errResp errorMessage
 		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg) // nolint:goerr113
+			return nil, llms.NewProviderError(r.StatusCode, r.Header, errors.New(msg)) // nolint:goerr113
 		}
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message) // nolint:goerr113
+		return nil, llms.NewProviderError(r.StatusCode, r.Header, fmt.Errorf("%s: %s", msg, errResp.Error.Message)) // nolint:goerr113
 	}
 
 	var response embeddingResponsePayload
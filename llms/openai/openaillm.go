@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/jsonschema"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/openai/internal/openaiclient"
 )
@@ -107,12 +108,25 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		PresencePenalty:  opts.PresencePenalty,
 
 		FunctionCallBehavior: openaiclient.FunctionCallBehavior(opts.FunctionCallBehavior),
+		StreamingChunkFunc:   opts.StreamingChunkFunc,
 		Seed:                 opts.Seed,
 		Metadata:             opts.Metadata,
 	}
 	if opts.JSONMode {
 		req.ResponseFormat = ResponseFormatJSON
 	}
+	// The API only accepts object schemas.
+	if rs := opts.ResponseSchema; rs != nil && rs.Schema != nil && rs.Schema.Type == jsonschema.Object {
+		req.ResponseFormat = &ResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &openaiclient.ResponseFormatJSONSchema{
+				Name:        rs.Name,
+				Description: rs.Description,
+				Schema:      rs.Schema,
+				Strict:      rs.Strict,
+			},
+		}
+	}
 
 	// since req.Functions is deprecated, we need to use the new Tools API.
 	for _, fn := range opts.Functions {
@@ -133,6 +147,10 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		}
 		req.Tools = append(req.Tools, t)
 	}
+	// The API rejects a tool choice without tools.
+	if len(req.Tools) > 0 {
+		req.ToolChoice = opts.ToolChoice
+	}
 
 	result, err := o.client.CreateChat(ctx, req)
 	if err != nil {
@@ -142,6 +160,13 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		return nil, ErrEmptyResponse
 	}
 
+	usage := &llms.Usage{
+		PromptTokens:     result.Usage.PromptTokens,
+		CompletionTokens: result.Usage.CompletionTokens,
+		TotalTokens:      result.Usage.TotalTokens,
+		CachedTokens:     result.Usage.PromptTokensDetails.CachedTokens,
+		ReasoningTokens:  result.Usage.CompletionTokensDetails.ReasoningTokens,
+	}
 	choices := make([]*llms.ContentChoice, len(result.Choices))
 	for i, c := range result.Choices {
 		choices[i] = &llms.ContentChoice{
@@ -152,6 +177,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				"PromptTokens":     result.Usage.PromptTokens,
 				"TotalTokens":      result.Usage.TotalTokens,
 			},
+			Usage: usage,
 		}
 
 		// Legacy function call handling
@@ -161,8 +187,8 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				Arguments: c.Message.FunctionCall.Arguments,
 			}
 		}
-		if c.FinishReason == "tool_calls" {
-			// TODO: we can only handle a single tool call for now, we need to evolve the API to handle multiple tool calls.
+		// A forced tool choice finishes with "stop" rather than "tool_calls".
+		if len(c.Message.ToolCalls) > 0 {
 			for _, tool := range c.Message.ToolCalls {
 				choices[i].ToolCalls = append(choices[i].ToolCalls, llms.ToolCall{
 					ID:   tool.ID,
@@ -179,7 +205,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 			}
 		}
 	}
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{Choices: choices, Usage: usage}
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
 	}
@@ -0,0 +1,65 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestStreamContent(t *testing.T) {
+	t.Parallel()
+
+	events := []string{
+		`{"choices":[{"index":0,"delta":{"role":"assistant","content":"Let me check."}}]}`,
+		`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"weather","arguments":""}}]}}]}`, //nolint:lll
+		`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":\"Paris\"}"}}]}}]}`,
+		`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+	}
+
+	// The server waits for each event to be received by the client before
+	// sending the next one, so the test only passes if the chunks are
+	// streamed as they arrive.
+	received := make(chan struct{}, len(events))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprintf(w, "data: %s\n\n", event)
+			w.(http.Flusher).Flush()
+			select {
+			case <-received:
+			case <-time.After(5 * time.Second):
+				t.Errorf("event not streamed: %s", event)
+				return
+			}
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	llm, err := New(WithToken("test"), WithBaseURL(server.URL), WithModel("gpt-4o"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var chunks []llms.ContentChunk
+	for chunk := range llms.StreamContent(ctx, llm, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "What is the weather in Paris?"),
+	}) {
+		chunks = append(chunks, chunk)
+		received <- struct{}{}
+	}
+
+	assert.Equal(t, []llms.ContentChunk{
+		{Type: llms.ContentChunkTypeText, Text: "Let me check."},
+		{Type: llms.ContentChunkTypeToolCall, ToolCall: &llms.ToolCallChunk{ID: "call_1", Name: "weather"}},
+		{Type: llms.ContentChunkTypeToolCall, ToolCall: &llms.ToolCallChunk{Arguments: `{"city":"Paris"}`}},
+		{Type: llms.ContentChunkTypeStop, StopReason: "tool_calls"},
+	}, chunks)
+}
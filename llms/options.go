@@ -21,6 +21,10 @@ type CallOptions struct {
 	// StreamingFunc is a function to be called for each chunk of a streaming response.
 	// Return an error to stop streaming early.
 	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+	// StreamingChunkFunc is a function to be called for each typed chunk of a
+	// streaming response. Return an error to stop streaming early.
+	// Most callers should use [StreamContent] instead of setting it directly.
+	StreamingChunkFunc func(ctx context.Context, chunk ContentChunk) error `json:"-"`
 	// TopK is the number of tokens to consider for top-k sampling.
 	TopK int `json:"top_k"`
 	// TopP is the cumulative probability for top-p sampling.
@@ -42,6 +46,12 @@ type CallOptions struct {
 
 	// JSONMode is a flag to enable JSON mode.
 	JSONMode bool `json:"json"`
+	// ResponseSchema is the schema the response must match. Backends that
+	// support structured outputs natively constrain the response to it.
+	ResponseSchema *ResponseSchema `json:"response_schema,omitempty"`
+	// StructuredOutputRetries is the number of times [GenerateStructured]
+	// re-prompts the model when its response does not match the schema.
+	StructuredOutputRetries int `json:"-"`
 
 	// Tools is a list of tools to use. Each tool can be a specific tool or a function.
 	Tools []Tool `json:"tools,omitempty"`
@@ -155,6 +165,14 @@ func WithStreamingFunc(streamingFunc func(ctx context.Context, chunk []byte) err
 	}
 }
 
+// WithStreamingChunkFunc specifies the function called for each typed chunk
+// of a streaming response.
+func WithStreamingChunkFunc(streamingChunkFunc func(ctx context.Context, chunk ContentChunk) error) CallOption {
+	return func(o *CallOptions) {
+		o.StreamingChunkFunc = streamingChunkFunc
+	}
+}
+
 // WithTopK will add an option to use top-k sampling.
 func WithTopK(topK int) CallOption {
 	return func(o *CallOptions) {
@@ -258,6 +276,24 @@ func WithJSONMode() CallOption {
 	}
 }
 
+// WithResponseSchema will add an option to constrain the response to schema.
+// Backends without native support for response schemas ignore it; see
+// [GenerateStructured] for a portable way to get structured responses.
+func WithResponseSchema(schema *ResponseSchema) CallOption {
+	return func(o *CallOptions) {
+		o.ResponseSchema = schema
+	}
+}
+
+// WithStructuredOutputRetries will add an option to set how many times
+// [GenerateStructured] re-prompts the model when its response does not match
+// the schema. The default is 2; a negative value disables re-prompting.
+func WithStructuredOutputRetries(retries int) CallOption {
+	return func(o *CallOptions) {
+		o.StructuredOutputRetries = retries
+	}
+}
+
 // WithMetadata will add an option to set metadata to include in the request.
 // The meaning of this field is specific to the backend in use.
 func WithMetadata(metadata map[string]interface{}) CallOption {
@@ -0,0 +1,4 @@
+// Package ratelimit provides a generic wrapper that enforces client-side request-per-minute
+// and token-per-minute budgets on the calls to a `llms.Model`, making callers wait until the
+// budget allows a call instead of running into the rate limits of the provider.
+package ratelimit
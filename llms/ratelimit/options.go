@@ -0,0 +1,68 @@
+package ratelimit
+
+import "github.com/tmc/langchaingo/llms"
+
+// Option is a functional argument that configures the Limiter.
+type Option func(*options)
+
+type options struct {
+	requestsPerMinute int
+	tokensPerMinute   int
+	estimateTokens    func(messages []llms.MessageContent, opts llms.CallOptions) int
+}
+
+// WithRequestsPerMinute sets the number of calls allowed per minute. By
+// default, the number of calls is not limited.
+func WithRequestsPerMinute(n int) Option {
+	return func(o *options) {
+		o.requestsPerMinute = n
+	}
+}
+
+// WithTokensPerMinute sets the number of tokens, prompt and completion
+// combined, allowed per minute. By default, the number of tokens is not
+// limited.
+func WithTokensPerMinute(n int) Option {
+	return func(o *options) {
+		o.tokensPerMinute = n
+	}
+}
+
+// WithTokenEstimator sets the function estimating the number of tokens a call
+// will use before it is made. The default estimate is one token per four
+// characters of text in the messages, plus the maximum number of completion
+// tokens of the call.
+func WithTokenEstimator(estimate func(messages []llms.MessageContent, opts llms.CallOptions) int) Option {
+	return func(o *options) {
+		o.estimateTokens = estimate
+	}
+}
+
+func defaultEstimateTokens(messages []llms.MessageContent, opts llms.CallOptions) int {
+	chars := 0
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			switch p := part.(type) {
+			case llms.TextContent:
+				chars += len(p.Text)
+			case llms.ToolCall:
+				if p.FunctionCall != nil {
+					chars += len(p.FunctionCall.Name) + len(p.FunctionCall.Arguments)
+				}
+			case llms.ToolCallResponse:
+				chars += len(p.Content)
+			}
+		}
+	}
+	return chars/4 + opts.MaxTokens
+}
+
+func applyOptions(opts ...Option) options {
+	o := options{
+		estimateTokens: defaultEstimateTokens,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
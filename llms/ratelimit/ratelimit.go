@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+	"golang.org/x/time/rate"
+)
+
+// Limiter is an LLM wrapper that limits the rate of the calls to the LLM.
+type Limiter struct {
+	llm      llms.Model
+	opts     options
+	requests *rate.Limiter
+	tokens   *rate.Limiter
+}
+
+// assert that `Limiter` implements the `llms.Model` interface.
+var _ llms.Model = (*Limiter)(nil)
+
+// New wraps a Model and limits the rate of its calls according to opts. A
+// Limiter is safe for concurrent use; share it between all the callers that
+// draw on the same budget.
+func New(llm llms.Model, opts ...Option) *Limiter {
+	l := &Limiter{
+		llm:  llm,
+		opts: applyOptions(opts...),
+	}
+	if n := l.opts.requestsPerMinute; n > 0 {
+		l.requests = rate.NewLimiter(perMinute(n), n)
+	}
+	if n := l.opts.tokensPerMinute; n > 0 {
+		l.tokens = rate.NewLimiter(perMinute(n), n)
+	}
+	return l
+}
+
+func perMinute(n int) rate.Limit {
+	return rate.Limit(float64(n) / time.Minute.Seconds())
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (l *Limiter) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+// GenerateContent asks the model to generate content from a sequence of
+// messages, once the budgets allow it. The tokens of a call are estimated
+// before it is made; if the response reports more tokens than estimated, the
+// difference is charged to the following calls.
+func (l *Limiter) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if l.requests != nil {
+		if err := l.requests.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	estimate := 0
+	if l.tokens != nil {
+		estimate = min(l.opts.estimateTokens(messages, opts), l.tokens.Burst())
+		if err := l.tokens.WaitN(ctx, estimate); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := l.llm.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.tokens != nil && resp.Usage != nil {
+		if extra := resp.Usage.TotalTokens - estimate; extra > 0 {
+			l.tokens.ReserveN(time.Now(), min(extra, l.tokens.Burst()))
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// not synchronized, don't use concurrently!
+type mockLLM struct {
+	calls int
+	usage *llms.Usage
+}
+
+func (m *mockLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *mockLLM) GenerateContent(_ context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+	m.calls++
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok"}}, Usage: m.usage}, nil
+}
+
+func generate(t *testing.T, l *Limiter, prompt string) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := l.GenerateContent(ctx, []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, prompt)})
+	return err
+}
+
+func TestLimiterRequestsPerMinute(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{}
+	l := New(llm, WithRequestsPerMinute(2))
+
+	require.NoError(t, generate(t, l, "one"))
+	require.NoError(t, generate(t, l, "two"))
+	// The third request would have to wait for 30s, past the deadline.
+	require.Error(t, generate(t, l, "three"))
+	assert.Equal(t, 2, llm.calls)
+}
+
+func TestLimiterTokensPerMinute(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{}
+	l := New(llm, WithTokensPerMinute(100), WithTokenEstimator(func(_ []llms.MessageContent, _ llms.CallOptions) int {
+		return 60
+	}))
+
+	require.NoError(t, generate(t, l, "one"))
+	require.Error(t, generate(t, l, "two"))
+	assert.Equal(t, 1, llm.calls)
+}
+
+func TestLimiterChargesActualUsage(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{usage: &llms.Usage{TotalTokens: 100}}
+	l := New(llm, WithTokensPerMinute(100))
+
+	// "hello world!" is estimated at 3 tokens, but the response reports 100,
+	// which uses up the whole budget.
+	require.NoError(t, generate(t, l, "hello world!"))
+	require.Error(t, generate(t, l, "hello world!"))
+	assert.Equal(t, 1, llm.calls)
+}
@@ -0,0 +1,5 @@
+// Package retry provides a generic wrapper that retries the calls to a `llms.Model` failing
+// with transient errors, such as rate limits or server errors, with exponential backoff and
+// jitter. Errors are classified with `llms.ClassifyError`, and the delay requested by the
+// provider through the Retry-After header is honored.
+package retry
@@ -0,0 +1,67 @@
+package retry
+
+import (
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// Option is a functional argument that configures the Retrier.
+type Option func(*options)
+
+type options struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	retryable      func(error) bool
+}
+
+// WithMaxRetries sets the number of times a failed call is retried. Defaults
+// to 3.
+func WithMaxRetries(n int) Option {
+	return func(o *options) {
+		o.maxRetries = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the maximum delay
+// between retries. The delay doubles after each retry. Defaults to 500ms and
+// 30s.
+func WithBackoff(initial, maximum time.Duration) Option {
+	return func(o *options) {
+		o.initialBackoff = initial
+		o.maxBackoff = maximum
+	}
+}
+
+// WithRetryable sets the function deciding whether a failed call is retried.
+// By default, the calls failing with a rate limit, server, network or timeout
+// error are retried.
+func WithRetryable(retryable func(err error) bool) Option {
+	return func(o *options) {
+		o.retryable = retryable
+	}
+}
+
+func defaultRetryable(err error) bool {
+	return llms.ClassifyError(err).Retryable()
+}
+
+func applyOptions(opts ...Option) options {
+	o := options{
+		maxRetries:     defaultMaxRetries,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		retryable:      defaultRetryable,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
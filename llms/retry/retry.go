@@ -0,0 +1,122 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+	"golang.org/x/exp/slices"
+)
+
+// Retrier is an LLM wrapper that retries the calls failing with transient
+// errors.
+type Retrier struct {
+	llm  llms.Model
+	opts options
+}
+
+// assert that `Retrier` implements the `llms.Model` interface.
+var _ llms.Model = (*Retrier)(nil)
+
+// New wraps a Model and retries its failed calls according to opts.
+func New(llm llms.Model, opts ...Option) *Retrier {
+	return &Retrier{
+		llm:  llm,
+		opts: applyOptions(opts...),
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Retrier) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent asks the model to generate content from a sequence of
+// messages, retrying on transient errors. A streamed call is only retried if
+// it failed before anything was streamed, so that no chunk is delivered twice.
+func (r *Retrier) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	// The options are clipped so that appending to them never writes to the
+	// backing array of the caller.
+	options = slices.Clip(options)
+	streamed := false
+	if opts.StreamingFunc != nil {
+		streamingFunc := opts.StreamingFunc
+		options = append(options, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			streamed = true
+			return streamingFunc(ctx, chunk)
+		}))
+	}
+	if opts.StreamingChunkFunc != nil {
+		streamingChunkFunc := opts.StreamingChunkFunc
+		options = append(options, llms.WithStreamingChunkFunc(func(ctx context.Context, chunk llms.ContentChunk) error {
+			streamed = true
+			return streamingChunkFunc(ctx, chunk)
+		}))
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := r.llm.GenerateContent(ctx, messages, options...)
+		if err == nil {
+			return resp, nil
+		}
+		if streamed || attempt >= r.opts.maxRetries || !r.opts.retryable(err) {
+			if attempt > 0 {
+				return nil, fmt.Errorf("giving up after %d attempts: %w", attempt+1, err)
+			}
+			return nil, err
+		}
+
+		if err := sleep(ctx, r.delay(attempt, err)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// delay returns how long to wait before retrying the call that failed with err
+// after the given number of previous attempts. The Retry-After delay requested
+// by the provider takes precedence over the exponential backoff.
+func (r *Retrier) delay(attempt int, err error) time.Duration {
+	if retryAfter := llms.ClassifyError(err).RetryAfter; retryAfter > 0 {
+		return retryAfter
+	}
+
+	backoff := r.opts.initialBackoff
+	for i := 0; i < attempt && backoff < r.opts.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.opts.maxBackoff {
+		backoff = r.opts.maxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	// Equal jitter: wait at least half of the backoff, so that concurrent
+	// callers spread their retries without retrying too early.
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)) //nolint:gosec
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,165 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// not synchronized, don't use concurrently!
+type mockLLM struct {
+	calls  int
+	errs   []error
+	stream bool
+}
+
+func (m *mockLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *mockLLM) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	m.calls++
+	if m.stream && opts.StreamingFunc != nil {
+		if err := opts.StreamingFunc(ctx, []byte("partial")); err != nil {
+			return nil, err
+		}
+	}
+	if len(m.errs) >= m.calls {
+		return nil, m.errs[m.calls-1]
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok"}}}, nil
+}
+
+func statusError(statusCode int, header http.Header) error {
+	return llms.NewProviderError(statusCode, header, errors.New(http.StatusText(statusCode)))
+}
+
+func TestRetrier(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			wantCalls: 1,
+		},
+		{
+			name:      "transient errors",
+			errs:      []error{statusError(http.StatusTooManyRequests, nil), statusError(http.StatusBadGateway, nil)},
+			wantCalls: 3,
+		},
+		{
+			name:      "unclassified status code",
+			errs:      []error{errors.New("API returned unexpected status code: 503")},
+			wantCalls: 2,
+		},
+		{
+			name:      "permanent error",
+			errs:      []error{statusError(http.StatusUnauthorized, nil)},
+			wantCalls: 1,
+			wantErr:   llms.ErrAuthentication,
+		},
+		{
+			name: "too many errors",
+			errs: []error{
+				statusError(http.StatusInternalServerError, nil),
+				statusError(http.StatusInternalServerError, nil),
+				statusError(http.StatusInternalServerError, nil),
+			},
+			wantCalls: 3,
+			wantErr:   llms.ErrServer,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			llm := &mockLLM{errs: tc.errs}
+			r := New(llm, WithMaxRetries(2), WithBackoff(time.Millisecond, 2*time.Millisecond))
+			resp, err := r.GenerateContent(context.Background(), nil)
+
+			assert.Equal(t, tc.wantCalls, llm.calls)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", resp.Choices[0].Content)
+		})
+	}
+}
+
+func TestRetrierRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{errs: []error{statusError(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}})}}
+	r := New(llm, WithBackoff(time.Millisecond, time.Millisecond))
+
+	start := time.Now()
+	_, err := r.GenerateContent(context.Background(), nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetrierStreamed(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{errs: []error{statusError(http.StatusBadGateway, nil)}, stream: true}
+	r := New(llm, WithBackoff(time.Millisecond, time.Millisecond))
+
+	var chunks []string
+	_, err := r.GenerateContent(context.Background(), nil, llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}))
+	require.ErrorIs(t, err, llms.ErrServer)
+	assert.Equal(t, 1, llm.calls)
+	assert.Equal(t, []string{"partial"}, chunks)
+}
+
+func TestRetrierContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	llm := &mockLLM{errs: []error{statusError(http.StatusServiceUnavailable, nil)}}
+	r := New(llm, WithBackoff(time.Hour, time.Hour))
+	_, err := r.GenerateContent(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, llm.calls)
+}
+
+func TestRetrierKeepsCallerOptions(t *testing.T) {
+	t.Parallel()
+
+	llm := &mockLLM{stream: true}
+	r := New(llm)
+
+	options := []llms.CallOption{
+		llms.WithStreamingFunc(func(context.Context, []byte) error { return nil }),
+		llms.WithModel("spare"),
+	}
+	_, err := r.GenerateContent(context.Background(), nil, options[:1]...)
+	require.NoError(t, err)
+
+	var opts llms.CallOptions
+	options[1](&opts)
+	assert.Equal(t, "spare", opts.Model)
+}
@@ -0,0 +1,45 @@
+package router
+
+import "time"
+
+// breaker is the circuit breaker of a backend. It is not synchronized; the
+// router guards it with its mutex.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether a call may be made to the backend at now.
+func (b *breaker) allow(now time.Time) bool {
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return false
+	}
+	// The cooldown has passed: let a single call through to probe the backend.
+	b.probing = true
+	return true
+}
+
+func (b *breaker) success() {
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *breaker) failure(now time.Time) {
+	b.failures++
+	b.probing = false
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// release ends a probe whose outcome says nothing about the backend.
+func (b *breaker) release() {
+	b.probing = false
+}
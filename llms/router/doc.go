@@ -0,0 +1,5 @@
+// Package router provides a `llms.Model` that routes calls across several backend models. Calls
+// fall back to the next backend when one fails, can be balanced across backends with weighted
+// round-robin, and skip backends that keep failing until they have had time to recover. The
+// name of the backend that answered is reported in the `GenerationInfo` of the response.
+package router
@@ -0,0 +1,77 @@
+package router
+
+import (
+	"time"
+
+	"github.com/tmc/langchaingo/callbacks"
+)
+
+// Strategy decides the order in which the backends are tried.
+type Strategy int
+
+const (
+	// StrategyFallback tries the backends in the order they were given.
+	StrategyFallback Strategy = iota
+	// StrategyRoundRobin starts each call with the next backend in a weighted
+	// round-robin, then falls back to the other backends in the order they
+	// were given.
+	StrategyRoundRobin
+)
+
+// Option is a functional argument that configures the Router.
+type Option func(*options)
+
+type options struct {
+	strategy         Strategy
+	failureThreshold int
+	cooldown         time.Duration
+	shouldFallback   func(error) bool
+	callbacksHandler callbacks.Handler
+}
+
+// WithStrategy sets the routing strategy. Defaults to StrategyFallback.
+func WithStrategy(strategy Strategy) Option {
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
+
+// WithCircuitBreaker skips a backend for the cooldown period once it has
+// failed failureThreshold times in a row. After the cooldown, a single call is
+// let through to probe the backend: the backend is used again if the call
+// succeeds, and skipped for another cooldown period if it fails. By default,
+// backends are never skipped.
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) Option {
+	return func(o *options) {
+		o.failureThreshold = failureThreshold
+		o.cooldown = cooldown
+	}
+}
+
+// WithShouldFallback sets the function deciding whether a failed call falls
+// back to the next backend. By default, all errors fall back, unless the
+// context of the call is done.
+func WithShouldFallback(shouldFallback func(err error) bool) Option {
+	return func(o *options) {
+		o.shouldFallback = shouldFallback
+	}
+}
+
+// WithCallback sets the callbacks handler of the router. If the handler also
+// implements Handler, it is notified of every backend failure.
+func WithCallback(callbacksHandler callbacks.Handler) Option {
+	return func(o *options) {
+		o.callbacksHandler = callbacksHandler
+	}
+}
+
+func applyOptions(opts ...Option) options {
+	o := options{
+		strategy:       StrategyFallback,
+		shouldFallback: func(error) bool { return true },
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
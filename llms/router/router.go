@@ -0,0 +1,239 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+	"golang.org/x/exp/slices"
+)
+
+// GenerationInfoBackend is the key of the name of the backend that answered in
+// the GenerationInfo of the choices of a response.
+const GenerationInfoBackend = "Backend"
+
+var (
+	// ErrNoBackends is returned by New when no backend is given.
+	ErrNoBackends = errors.New("router: no backends")
+	// ErrNoHealthyBackend is returned when the circuit breakers of all the
+	// backends are open.
+	ErrNoHealthyBackend = errors.New("router: no healthy backend")
+)
+
+// Backend is a model the router can route calls to.
+type Backend struct {
+	// Name identifies the backend in responses, errors and callbacks.
+	Name string
+	// Model is the model calls are routed to.
+	Model llms.Model
+	// Weight is the share of calls the backend starts with when using the
+	// StrategyRoundRobin strategy. A weight of zero or less is taken as 1.
+	Weight int
+}
+
+// Handler is implemented by callbacks handlers that want to be notified of the
+// backends failing. See WithCallback.
+type Handler interface {
+	HandleBackendError(ctx context.Context, backend string, err error)
+}
+
+// Router is an LLM that routes calls across several backends.
+type Router struct {
+	backends []Backend
+	opts     options
+
+	mu       sync.Mutex
+	breakers []*breaker
+	// current holds the current weights of the smooth weighted round-robin.
+	current []int
+}
+
+// assert that `Router` implements the `llms.Model` interface.
+var _ llms.Model = (*Router)(nil)
+
+// New creates a router across the given backends.
+func New(backends []Backend, opts ...Option) (*Router, error) {
+	if len(backends) == 0 {
+		return nil, ErrNoBackends
+	}
+
+	r := &Router{
+		backends: make([]Backend, len(backends)),
+		opts:     applyOptions(opts...),
+		breakers: make([]*breaker, len(backends)),
+		current:  make([]int, len(backends)),
+	}
+	for i, b := range backends {
+		if b.Weight <= 0 {
+			b.Weight = 1
+		}
+		if b.Name == "" {
+			b.Name = fmt.Sprintf("backend-%d", i)
+		}
+		r.backends[i] = b
+		r.breakers[i] = &breaker{threshold: r.opts.failureThreshold, cooldown: r.opts.cooldown}
+	}
+	return r, nil
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Router) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent asks the backends to generate content from a sequence of
+// messages, trying them in the order given by the strategy until one of them
+// succeeds. A streamed call does not fall back once the failing backend has
+// streamed anything, so that no chunk is delivered twice.
+func (r *Router) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint:cyclop
+	if r.opts.callbacksHandler != nil {
+		r.opts.callbacksHandler.HandleLLMGenerateContentStart(ctx, messages)
+	}
+
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	streamed := false
+	// The options are clipped so that appending to them never writes to the
+	// backing array of the caller.
+	options = append(slices.Clip(options), trackStreaming(opts, &streamed)...)
+
+	var errs []error
+	for _, i := range r.order() {
+		if !r.allow(i) {
+			continue
+		}
+
+		backend := r.backends[i]
+		resp, err := backend.Model.GenerateContent(ctx, messages, options...)
+		if err == nil {
+			r.record(i, nil)
+			setBackend(resp, backend.Name)
+			if r.opts.callbacksHandler != nil {
+				r.opts.callbacksHandler.HandleLLMGenerateContentEnd(ctx, resp)
+			}
+			return resp, nil
+		}
+
+		r.record(i, err)
+		err = fmt.Errorf("backend %s: %w", backend.Name, err)
+		errs = append(errs, err)
+		if h, ok := r.opts.callbacksHandler.(Handler); ok {
+			h.HandleBackendError(ctx, backend.Name, err)
+		}
+		if streamed || ctx.Err() != nil || !r.opts.shouldFallback(err) {
+			break
+		}
+	}
+
+	err := ErrNoHealthyBackend
+	if len(errs) > 0 {
+		err = errors.Join(errs...)
+	}
+	if r.opts.callbacksHandler != nil {
+		r.opts.callbacksHandler.HandleLLMError(ctx, err)
+	}
+	return nil, err
+}
+
+// order returns the indices of the backends in the order they are tried.
+func (r *Router) order() []int {
+	first := 0
+	if r.opts.strategy == StrategyRoundRobin {
+		first = r.next()
+	}
+
+	order := make([]int, 0, len(r.backends))
+	order = append(order, first)
+	for i := range r.backends {
+		if i != first {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// next picks the backend to start with using the smooth weighted round-robin
+// algorithm, which spreads the picks of each backend evenly.
+func (r *Router) next() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total, best := 0, 0
+	for i, b := range r.backends {
+		r.current[i] += b.Weight
+		total += b.Weight
+		if r.current[i] > r.current[best] {
+			best = i
+		}
+	}
+	r.current[best] -= total
+	return best
+}
+
+func (r *Router) allow(i int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.breakers[i].allow(time.Now())
+}
+
+// record records the outcome of a call to the backend at index i. Calls
+// failing because their context is done say nothing about the health of the
+// backend, so they are not counted as failures.
+func (r *Router) record(i int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.breakers[i]
+	switch {
+	case err == nil:
+		b.success()
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		b.release()
+	default:
+		b.failure(time.Now())
+	}
+}
+
+// trackStreaming returns call options wrapping the streaming functions of opts
+// to set streamed once they have been called.
+func trackStreaming(opts llms.CallOptions, streamed *bool) []llms.CallOption {
+	var wrappers []llms.CallOption
+	if opts.StreamingFunc != nil {
+		streamingFunc := opts.StreamingFunc
+		wrappers = append(wrappers, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			*streamed = true
+			return streamingFunc(ctx, chunk)
+		}))
+	}
+	if opts.StreamingChunkFunc != nil {
+		streamingChunkFunc := opts.StreamingChunkFunc
+		wrappers = append(wrappers, llms.WithStreamingChunkFunc(func(ctx context.Context, chunk llms.ContentChunk) error {
+			*streamed = true
+			return streamingChunkFunc(ctx, chunk)
+		}))
+	}
+	return wrappers
+}
+
+func setBackend(resp *llms.ContentResponse, name string) {
+	if resp == nil {
+		return
+	}
+	for _, choice := range resp.Choices {
+		if choice.GenerationInfo == nil {
+			choice.GenerationInfo = make(map[string]any)
+		}
+		choice.GenerationInfo[GenerationInfoBackend] = name
+	}
+}
@@ -0,0 +1,197 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var errBackend = errors.New("backend failed")
+
+// not synchronized, don't use concurrently!
+type mockLLM struct {
+	content string
+	err     error
+	stream  bool
+	calls   int
+}
+
+func (m *mockLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *mockLLM) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	m.calls++
+	if m.stream && opts.StreamingFunc != nil {
+		if err := opts.StreamingFunc(ctx, []byte(m.content)); err != nil {
+			return nil, err
+		}
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.content}}}, nil
+}
+
+type testHandler struct {
+	callbacks.SimpleHandler
+	failed   []string
+	answered []string
+}
+
+func (h *testHandler) HandleBackendError(_ context.Context, backend string, _ error) {
+	h.failed = append(h.failed, backend)
+}
+
+func (h *testHandler) HandleLLMGenerateContentEnd(_ context.Context, res *llms.ContentResponse) {
+	h.answered = append(h.answered, res.Choices[0].GenerationInfo[GenerationInfoBackend].(string))
+}
+
+func TestRouterFallback(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockLLM{err: errBackend}
+	secondary := &mockLLM{content: "from secondary"}
+	handler := &testHandler{}
+	r, err := New([]Backend{
+		{Name: "primary", Model: primary},
+		{Name: "secondary", Model: secondary},
+	}, WithCallback(handler))
+	require.NoError(t, err)
+
+	resp, err := r.GenerateContent(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "from secondary", resp.Choices[0].Content)
+	assert.Equal(t, "secondary", resp.Choices[0].GenerationInfo[GenerationInfoBackend])
+	assert.Equal(t, []string{"primary"}, handler.failed)
+	assert.Equal(t, []string{"secondary"}, handler.answered)
+}
+
+func TestRouterAllFail(t *testing.T) {
+	t.Parallel()
+
+	r, err := New([]Backend{
+		{Name: "a", Model: &mockLLM{err: errBackend}},
+		{Name: "b", Model: &mockLLM{err: llms.ErrRateLimit}},
+	})
+	require.NoError(t, err)
+
+	_, err = r.GenerateContent(context.Background(), nil)
+	require.ErrorIs(t, err, errBackend)
+	require.ErrorIs(t, err, llms.ErrRateLimit)
+	assert.Contains(t, err.Error(), "backend a")
+	assert.Contains(t, err.Error(), "backend b")
+}
+
+func TestRouterNoFallbackAfterStreaming(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockLLM{content: "partial", err: errBackend, stream: true}
+	secondary := &mockLLM{content: "from secondary", stream: true}
+	r, err := New([]Backend{{Name: "primary", Model: primary}, {Name: "secondary", Model: secondary}})
+	require.NoError(t, err)
+
+	var chunks []string
+	_, err = r.GenerateContent(context.Background(), nil, llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}))
+	require.ErrorIs(t, err, errBackend)
+	assert.Equal(t, []string{"partial"}, chunks)
+	assert.Equal(t, 0, secondary.calls)
+}
+
+func TestRouterWeightedRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	r, err := New([]Backend{
+		{Name: "a", Model: &mockLLM{content: "a"}, Weight: 2},
+		{Name: "b", Model: &mockLLM{content: "b"}},
+	}, WithStrategy(StrategyRoundRobin))
+	require.NoError(t, err)
+
+	var answers []string
+	for i := 0; i < 6; i++ {
+		resp, err := r.GenerateContent(context.Background(), nil)
+		require.NoError(t, err)
+		answers = append(answers, resp.Choices[0].Content)
+	}
+	assert.Equal(t, []string{"a", "b", "a", "a", "b", "a"}, answers)
+}
+
+func TestRouterCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockLLM{err: errBackend}
+	secondary := &mockLLM{content: "from secondary"}
+	r, err := New([]Backend{
+		{Name: "primary", Model: primary},
+		{Name: "secondary", Model: secondary},
+	}, WithCircuitBreaker(2, 20*time.Millisecond))
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		_, err := r.GenerateContent(context.Background(), nil)
+		require.NoError(t, err)
+	}
+	// The primary backend is skipped once it has failed twice.
+	assert.Equal(t, 2, primary.calls)
+
+	// After the cooldown, the primary backend is probed again, and used once
+	// it has recovered.
+	time.Sleep(30 * time.Millisecond)
+	primary.err = nil
+	primary.content = "from primary"
+	resp, err := r.GenerateContent(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", resp.Choices[0].GenerationInfo[GenerationInfoBackend])
+	assert.Equal(t, 3, primary.calls)
+}
+
+func TestRouterNoHealthyBackend(t *testing.T) {
+	t.Parallel()
+
+	r, err := New([]Backend{{Name: "a", Model: &mockLLM{err: errBackend}}}, WithCircuitBreaker(1, time.Hour))
+	require.NoError(t, err)
+
+	_, err = r.GenerateContent(context.Background(), nil)
+	require.ErrorIs(t, err, errBackend)
+	_, err = r.GenerateContent(context.Background(), nil)
+	require.ErrorIs(t, err, ErrNoHealthyBackend)
+}
+
+func TestNewNoBackends(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(nil)
+	require.ErrorIs(t, err, ErrNoBackends)
+}
+
+func TestRouterKeepsCallerOptions(t *testing.T) {
+	t.Parallel()
+
+	r, err := New([]Backend{{Name: "primary", Model: &mockLLM{content: "ok", stream: true}}})
+	require.NoError(t, err)
+
+	options := []llms.CallOption{
+		llms.WithStreamingFunc(func(context.Context, []byte) error { return nil }),
+		llms.WithModel("spare"),
+	}
+	_, err = r.GenerateContent(context.Background(), nil, options[:1]...)
+	require.NoError(t, err)
+
+	var opts llms.CallOptions
+	options[1](&opts)
+	assert.Equal(t, "spare", opts.Model)
+}
@@ -0,0 +1,144 @@
+package llms
+
+import (
+	"context"
+
+	"golang.org/x/exp/slices"
+)
+
+// ContentChunkType is the kind of a ContentChunk.
+type ContentChunkType string
+
+const (
+	// ContentChunkTypeText is a chunk carrying a piece of the response text.
+	ContentChunkTypeText ContentChunkType = "text"
+	// ContentChunkTypeToolCall is a chunk carrying a piece of a tool call.
+	ContentChunkTypeToolCall ContentChunkType = "tool_call"
+	// ContentChunkTypeStop is a chunk carrying the reason the model stopped.
+	ContentChunkTypeStop ContentChunkType = "stop"
+	// ContentChunkTypeUsage is a chunk carrying the token usage of the call.
+	ContentChunkTypeUsage ContentChunkType = "usage"
+	// ContentChunkTypeError is the last chunk of a stream that failed.
+	ContentChunkTypeError ContentChunkType = "error"
+)
+
+// ContentChunk is a single event of a streamed response.
+type ContentChunk struct {
+	Type ContentChunkType
+
+	// Text is the text delta of a ContentChunkTypeText chunk.
+	Text string
+	// ToolCall is the tool call delta of a ContentChunkTypeToolCall chunk.
+	ToolCall *ToolCallChunk
+	// StopReason is the reason the model stopped, set on ContentChunkTypeStop
+	// chunks.
+	StopReason string
+	// Usage is the token usage of the call, set on ContentChunkTypeUsage
+	// chunks.
+	Usage *Usage
+	// Err is the error that ended the stream, set on ContentChunkTypeError
+	// chunks.
+	Err error
+}
+
+// ToolCallChunk is a piece of a tool call. The first chunk of a tool call
+// carries its ID and Name; the Arguments of all chunks with the same Index
+// concatenate to the JSON arguments of the call.
+type ToolCallChunk struct {
+	// Index is the position of the tool call in the response.
+	Index int
+	// ID is the unique identifier of the tool call.
+	ID string
+	// Name is the name of the function to call.
+	Name string
+	// Arguments is a fragment of the JSON arguments of the call.
+	Arguments string
+}
+
+// StreamContent asks model to generate content from messages and streams the
+// response back as a sequence of chunks: text and tool call deltas, followed
+// by the stop reason and the token usage, if the backend reports them.
+//
+// The returned channel is closed once the response is complete. If generation
+// fails, the last chunk is of type ContentChunkTypeError. Callers that stop
+// reading before the channel is closed must cancel ctx to release the
+// underlying request.
+//
+// Backends that support it stream chunks as they are generated by honoring
+// [CallOptions.StreamingChunkFunc]; for all other backends the chunks are
+// derived from the complete response once it is available.
+func StreamContent(ctx context.Context, model Model, messages []MessageContent, options ...CallOption) <-chan ContentChunk {
+	chunks := make(chan ContentChunk)
+
+	go func() {
+		defer close(chunks)
+
+		send := func(ctx context.Context, chunk ContentChunk) error {
+			select {
+			case chunks <- chunk:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		streamed := false
+		// The options are copied, since appending to them could write into
+		// the array of the caller.
+		callOptions := append(slices.Clone(options), WithStreamingChunkFunc(func(ctx context.Context, chunk ContentChunk) error {
+			streamed = true
+			return send(ctx, chunk)
+		}))
+
+		resp, err := model.GenerateContent(ctx, messages, callOptions...)
+		if err != nil {
+			_ = send(ctx, ContentChunk{Type: ContentChunkTypeError, Err: err})
+			return
+		}
+		if streamed {
+			return
+		}
+
+		for _, chunk := range ChunksFromResponse(resp) {
+			if send(ctx, chunk) != nil {
+				return
+			}
+		}
+	}()
+
+	return chunks
+}
+
+// ChunksFromResponse splits the first choice of a complete response into the
+// chunks a streaming backend would have produced for it.
+func ChunksFromResponse(resp *ContentResponse) []ContentChunk {
+	if resp == nil || len(resp.Choices) == 0 {
+		return nil
+	}
+	choice := resp.Choices[0]
+
+	var chunks []ContentChunk
+	if choice.Content != "" {
+		chunks = append(chunks, ContentChunk{Type: ContentChunkTypeText, Text: choice.Content})
+	}
+	for i, toolCall := range choice.ToolCalls {
+		chunk := &ToolCallChunk{Index: i, ID: toolCall.ID}
+		if toolCall.FunctionCall != nil {
+			chunk.Name = toolCall.FunctionCall.Name
+			chunk.Arguments = toolCall.FunctionCall.Arguments
+		}
+		chunks = append(chunks, ContentChunk{Type: ContentChunkTypeToolCall, ToolCall: chunk})
+	}
+	if choice.StopReason != "" {
+		chunks = append(chunks, ContentChunk{Type: ContentChunkTypeStop, StopReason: choice.StopReason})
+	}
+
+	usage := resp.Usage
+	if usage == nil {
+		usage = choice.Usage
+	}
+	if usage != nil {
+		chunks = append(chunks, ContentChunk{Type: ContentChunkTypeUsage, Usage: usage})
+	}
+	return chunks
+}
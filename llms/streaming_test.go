@@ -0,0 +1,136 @@
+package llms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamTestModel struct {
+	chunks []ContentChunk
+	resp   *ContentResponse
+	err    error
+}
+
+func (m *streamTestModel) GenerateContent(ctx context.Context, _ []MessageContent, options ...CallOption) (*ContentResponse, error) {
+	opts := CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	for _, chunk := range m.chunks {
+		if err := opts.StreamingChunkFunc(ctx, chunk); err != nil {
+			return nil, err
+		}
+	}
+	return m.resp, m.err
+}
+
+func (m *streamTestModel) Call(ctx context.Context, prompt string, options ...CallOption) (string, error) {
+	return GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func collectChunks(ch <-chan ContentChunk) []ContentChunk {
+	var chunks []ContentChunk
+	for chunk := range ch {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func TestStreamContentNative(t *testing.T) {
+	t.Parallel()
+
+	streamed := []ContentChunk{
+		{Type: ContentChunkTypeText, Text: "Hel"},
+		{Type: ContentChunkTypeText, Text: "lo"},
+		{Type: ContentChunkTypeStop, StopReason: "stop"},
+	}
+	model := &streamTestModel{
+		chunks: streamed,
+		resp:   &ContentResponse{Choices: []*ContentChoice{{Content: "Hello", StopReason: "stop"}}},
+	}
+
+	chunks := collectChunks(StreamContent(context.Background(), model, nil))
+	assert.Equal(t, streamed, chunks)
+}
+
+func TestStreamContentFallback(t *testing.T) {
+	t.Parallel()
+
+	usage := &Usage{PromptTokens: 3, CompletionTokens: 5, TotalTokens: 8}
+	model := &streamTestModel{
+		resp: &ContentResponse{
+			Choices: []*ContentChoice{{
+				Content:    "Let me check.",
+				StopReason: "tool_calls",
+				ToolCalls: []ToolCall{{
+					ID:           "call_1",
+					Type:         "function",
+					FunctionCall: &FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`},
+				}},
+			}},
+			Usage: usage,
+		},
+	}
+
+	chunks := collectChunks(StreamContent(context.Background(), model, nil))
+	assert.Equal(t, []ContentChunk{
+		{Type: ContentChunkTypeText, Text: "Let me check."},
+		{Type: ContentChunkTypeToolCall, ToolCall: &ToolCallChunk{ID: "call_1", Name: "weather", Arguments: `{"city":"Paris"}`}},
+		{Type: ContentChunkTypeStop, StopReason: "tool_calls"},
+		{Type: ContentChunkTypeUsage, Usage: usage},
+	}, chunks)
+}
+
+func TestStreamContentKeepsOptions(t *testing.T) {
+	t.Parallel()
+
+	model := &streamTestModel{resp: &ContentResponse{Choices: []*ContentChoice{{Content: "Hello"}}}}
+
+	// the spare capacity of the options of the caller is not written to.
+	options := make([]CallOption, 1, 2)
+	options[0] = WithTemperature(0)
+	collectChunks(StreamContent(context.Background(), model, nil, options...))
+	assert.Nil(t, options[:2][1])
+}
+
+func TestStreamContentError(t *testing.T) {
+	t.Parallel()
+
+	errBackend := errors.New("backend unavailable")
+	model := &streamTestModel{
+		chunks: []ContentChunk{{Type: ContentChunkTypeText, Text: "partial"}},
+		err:    errBackend,
+	}
+
+	chunks := collectChunks(StreamContent(context.Background(), model, nil))
+	require.Len(t, chunks, 2)
+	assert.Equal(t, "partial", chunks[0].Text)
+	assert.Equal(t, ContentChunkTypeError, chunks[1].Type)
+	assert.ErrorIs(t, chunks[1].Err, errBackend)
+}
+
+func TestStreamContentCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	model := &streamTestModel{
+		chunks: []ContentChunk{
+			{Type: ContentChunkTypeText, Text: "a"},
+			{Type: ContentChunkTypeText, Text: "b"},
+		},
+		resp: &ContentResponse{Choices: []*ContentChoice{{Content: "ab"}}},
+	}
+
+	ch := StreamContent(ctx, model, nil)
+	first := <-ch
+	assert.Equal(t, "a", first.Text)
+	cancel()
+	// The stream must be closed once the context is canceled; the error
+	// chunk, if any, is dropped as nobody is listening anymore.
+	rest := collectChunks(ch)
+	assert.LessOrEqual(t, len(rest), 2)
+}
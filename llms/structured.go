@@ -0,0 +1,215 @@
+package llms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// defaultStructuredOutputRetries is the default number of times
+// GenerateStructured re-prompts the model.
+const defaultStructuredOutputRetries = 2
+
+// ErrInvalidStructuredOutput is returned by GenerateStructured when the model
+// does not produce a response matching the schema.
+var ErrInvalidStructuredOutput = errors.New("response does not match the schema")
+
+// ResponseSchema is the schema that the response of a model must match.
+type ResponseSchema struct {
+	// Name is the name of the schema. Some backends require it to only contain
+	// letters, digits, underscores and dashes.
+	Name string `json:"name"`
+	// Description describes what the response is for.
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON schema of the response.
+	Schema *jsonschema.Definition `json:"schema"`
+	// Strict asks backends that support it to guarantee that the response
+	// matches the schema. Backends only accept strict schemas whose objects
+	// list all their properties as required and disallow additional ones.
+	Strict bool `json:"strict,omitempty"`
+}
+
+const structuredOutputInstructions = "Respond with a JSON value that matches the following JSON schema, without any other text:\n```json\n%s\n```"
+
+const structuredOutputCorrection = "Your response does not match the JSON schema: %s\nRespond again with only the corrected JSON value."
+
+// GenerateStructured asks the model to generate content from a sequence of
+// messages and decodes the response into a value of type T.
+//
+// The JSON schema of the response is the one given with [WithResponseSchema],
+// or else the schema of T reflected with [jsonschema.Reflect], so the struct
+// tags `json`, `description` and `enum` shape the schema. Backends supporting
+// structured outputs enforce it natively, and it is appended to the messages
+// as instructions for the others. The response is validated against the
+// schema; if it does not match, the model is shown the validation errors and
+// asked to correct its response, up to the number of times set by
+// [WithStructuredOutputRetries].
+func GenerateStructured[T any](ctx context.Context, model Model, messages []MessageContent, options ...CallOption) (T, error) {
+	var result T
+	var opts CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	var responseSchema ResponseSchema
+	if opts.ResponseSchema != nil && opts.ResponseSchema.Schema != nil {
+		responseSchema = *opts.ResponseSchema
+	} else {
+		def, err := jsonschema.Reflect(&result)
+		if err != nil {
+			return result, fmt.Errorf("reflect schema: %w", err)
+		}
+		responseSchema = ResponseSchema{Schema: def, Strict: isStrict(def)}
+	}
+	if responseSchema.Name == "" {
+		responseSchema.Name = schemaName(reflect.TypeOf(&result).Elem())
+	}
+	def := responseSchema.Schema
+	retries := opts.StructuredOutputRetries
+	if retries == 0 {
+		retries = defaultStructuredOutputRetries
+	}
+
+	schema, err := json.MarshalIndent(def, "", "  ")
+	if err != nil {
+		return result, fmt.Errorf("encode schema: %w", err)
+	}
+	messages = appendText(messages, fmt.Sprintf(structuredOutputInstructions, schema))
+
+	options = append(options[:len(options):len(options)], WithResponseSchema(&responseSchema))
+	// JSON mode makes backends return an object, so only ask for it when
+	// the response is one.
+	if def.Type == jsonschema.Object {
+		options = append(options, WithJSONMode())
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := model.GenerateContent(ctx, messages, options...)
+		if err != nil {
+			return result, err
+		}
+		if len(resp.Choices) < 1 {
+			return result, errors.New("empty response from model")
+		}
+		content := resp.Choices[0].Content
+
+		err = decodeStructured(def, content, &result)
+		if err == nil {
+			return result, nil
+		}
+		if attempt >= retries {
+			return result, fmt.Errorf("%w: %w", ErrInvalidStructuredOutput, err)
+		}
+		messages = append(messages,
+			TextParts(ChatMessageTypeAI, content),
+			TextParts(ChatMessageTypeHuman, fmt.Sprintf(structuredOutputCorrection, err)),
+		)
+	}
+}
+
+// decodeStructured decodes the JSON value in content into result, after
+// validating it against def.
+func decodeStructured(def *jsonschema.Definition, content string, result any) error {
+	data := []byte(ExtractJSON(content))
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := jsonschema.Validate(def, value); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+var codeFenceRe = regexp.MustCompile("(?s)```(?:json)?\\s*(.*?)\\s*```")
+
+// ExtractJSON returns the JSON value in content, which models sometimes wrap
+// in a code fence or surround with text despite the instructions. Content
+// without any JSON value is returned as is.
+func ExtractJSON(content string) string {
+	content = strings.TrimSpace(content)
+	if m := codeFenceRe.FindStringSubmatch(content); m != nil {
+		content = m[1]
+	}
+	if json.Valid([]byte(content)) {
+		return content
+	}
+	start := strings.IndexAny(content, "{[")
+	if start < 0 {
+		return content
+	}
+	end := strings.LastIndexByte(content, map[byte]byte{'{': '}', '[': ']'}[content[start]])
+	if end < start {
+		return content
+	}
+	return content[start : end+1]
+}
+
+// appendText returns a copy of messages with text appended to the final
+// message if it is from the user, or as a new user message otherwise.
+func appendText(messages []MessageContent, text string) []MessageContent {
+	messages = append([]MessageContent(nil), messages...)
+	if n := len(messages); n > 0 && messages[n-1].Role == ChatMessageTypeHuman {
+		last := messages[n-1]
+		last.Parts = append(last.Parts[:len(last.Parts):len(last.Parts)], TextPart(text))
+		messages[n-1] = last
+		return messages
+	}
+	return append(messages, TextParts(ChatMessageTypeHuman, text))
+}
+
+var schemaNameRe = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// schemaName returns a name for the schema of t.
+func schemaName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if name := schemaNameRe.ReplaceAllString(t.Name(), "_"); name != "" {
+		return name
+	}
+	return "response"
+}
+
+// isStrict reports whether def can be sent as a strict schema: every value has
+// a type, and every object requires all its properties and allows no others.
+// References are strict if the definitions they refer to are.
+func isStrict(def *jsonschema.Definition) bool {
+	for _, d := range def.Defs {
+		if !isStrict(&d) {
+			return false
+		}
+	}
+	if def.Ref != "" {
+		return true
+	}
+	switch def.Type {
+	case "":
+		return false
+	case jsonschema.Object:
+		if additional, ok := def.AdditionalProperties.(bool); !ok || additional {
+			return false
+		}
+		if len(def.Required) != len(def.Properties) {
+			return false
+		}
+		for _, prop := range def.Properties {
+			if !isStrict(&prop) {
+				return false
+			}
+		}
+	case jsonschema.Array:
+		if def.Items == nil || !isStrict(def.Items) {
+			return false
+		}
+	}
+	return true
+}
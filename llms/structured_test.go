@@ -0,0 +1,204 @@
+package llms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// structuredTestModel replies with its responses in order and records the
+// calls made to it.
+// not synchronized, don't use concurrently!
+type structuredTestModel struct {
+	responses []string
+	messages  [][]MessageContent
+	opts      []CallOptions
+}
+
+func (m *structuredTestModel) GenerateContent(_ context.Context, messages []MessageContent, options ...CallOption) (*ContentResponse, error) {
+	opts := CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.messages = append(m.messages, messages)
+	m.opts = append(m.opts, opts)
+	content := m.responses[0]
+	m.responses = m.responses[1:]
+	return &ContentResponse{Choices: []*ContentChoice{{Content: content}}}, nil
+}
+
+func (m *structuredTestModel) Call(ctx context.Context, prompt string, options ...CallOption) (string, error) {
+	return GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+type weather struct {
+	City        string   `json:"city" description:"The name of the city"`
+	Temperature float64  `json:"temperature"`
+	Conditions  string   `json:"conditions" enum:"sunny,cloudy,rainy"`
+	Alerts      []string `json:"alerts"`
+}
+
+func TestGenerateStructured(t *testing.T) {
+	t.Parallel()
+
+	model := &structuredTestModel{responses: []string{
+		"```json\n{\"city\": \"Paris\", \"temperature\": 21.5, \"conditions\": \"sunny\", \"alerts\": []}\n```",
+	}}
+	messages := []MessageContent{TextParts(ChatMessageTypeHuman, "What is the weather in Paris?")}
+
+	got, err := GenerateStructured[weather](context.Background(), model, messages)
+	require.NoError(t, err)
+	assert.Equal(t, weather{City: "Paris", Temperature: 21.5, Conditions: "sunny", Alerts: []string{}}, got)
+
+	require.Len(t, model.opts, 1)
+	opts := model.opts[0]
+	assert.True(t, opts.JSONMode)
+	require.NotNil(t, opts.ResponseSchema)
+	assert.Equal(t, "weather", opts.ResponseSchema.Name)
+	assert.True(t, opts.ResponseSchema.Strict)
+	assert.Equal(t, jsonschema.Object, opts.ResponseSchema.Schema.Type)
+
+	// The instructions are appended to the user message, without modifying
+	// the messages of the caller.
+	require.Len(t, model.messages[0], 1)
+	assert.Len(t, model.messages[0][0].Parts, 2)
+	assert.Len(t, messages[0].Parts, 1)
+}
+
+func TestGenerateStructuredReprompts(t *testing.T) {
+	t.Parallel()
+
+	model := &structuredTestModel{responses: []string{
+		`{"city": "Paris", "temperature": 21.5, "conditions": "foggy", "alerts": []}`,
+		`{"city": "Paris", "temperature": 21.5, "conditions": "cloudy", "alerts": []}`,
+	}}
+	messages := []MessageContent{TextParts(ChatMessageTypeHuman, "What is the weather in Paris?")}
+
+	got, err := GenerateStructured[weather](context.Background(), model, messages)
+	require.NoError(t, err)
+	assert.Equal(t, "cloudy", got.Conditions)
+
+	require.Len(t, model.messages, 2)
+	retry := model.messages[1]
+	require.Len(t, retry, 3)
+	assert.Equal(t, ChatMessageTypeAI, retry[1].Role)
+	assert.Equal(t, ChatMessageTypeHuman, retry[2].Role)
+	assert.Contains(t, retry[2].Parts[0].(TextContent).Text, "$.conditions")
+}
+
+func TestGenerateStructuredNull(t *testing.T) {
+	t.Parallel()
+
+	// encoding/json encodes nil slices as null, so null is a valid value for
+	// them.
+	model := &structuredTestModel{responses: []string{
+		`{"city": "Paris", "temperature": 21.5, "conditions": "sunny", "alerts": null}`,
+	}}
+
+	got, err := GenerateStructured[weather](context.Background(), model,
+		[]MessageContent{TextParts(ChatMessageTypeHuman, "What is the weather in Paris?")})
+	require.NoError(t, err)
+	assert.Nil(t, got.Alerts)
+	assert.Len(t, model.messages, 1)
+}
+
+func TestGenerateStructuredGivesUp(t *testing.T) {
+	t.Parallel()
+
+	model := &structuredTestModel{responses: []string{
+		`{"city": "Paris"}`,
+		`not JSON`,
+	}}
+
+	_, err := GenerateStructured[weather](context.Background(), model,
+		[]MessageContent{TextParts(ChatMessageTypeHuman, "What is the weather in Paris?")},
+		WithStructuredOutputRetries(1))
+	require.ErrorIs(t, err, ErrInvalidStructuredOutput)
+	assert.Len(t, model.messages, 2)
+}
+
+func TestGenerateStructuredSlice(t *testing.T) {
+	t.Parallel()
+
+	model := &structuredTestModel{responses: []string{`Here you go: ["a", "b"]`}}
+
+	got, err := GenerateStructured[[]string](context.Background(), model,
+		[]MessageContent{TextParts(ChatMessageTypeHuman, "List two letters.")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, got)
+	assert.False(t, model.opts[0].JSONMode)
+}
+
+func TestGenerateStructuredResponseSchema(t *testing.T) {
+	t.Parallel()
+
+	// the schema of the caller is used instead of the reflected one.
+	model := &structuredTestModel{responses: []string{`{"city": "Paris"}`}}
+	schema := &ResponseSchema{
+		Name: "city",
+		Schema: &jsonschema.Definition{
+			Type:       jsonschema.Object,
+			Properties: map[string]jsonschema.Definition{"city": {Type: jsonschema.String}},
+			Required:   []string{"city"},
+		},
+	}
+
+	got, err := GenerateStructured[weather](context.Background(), model,
+		[]MessageContent{TextParts(ChatMessageTypeHuman, "Which city?")},
+		WithResponseSchema(schema))
+	require.NoError(t, err)
+	assert.Equal(t, "Paris", got.City)
+	assert.Equal(t, schema, model.opts[0].ResponseSchema)
+}
+
+func TestExtractJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{`{"a": 1}`, `{"a": 1}`},
+		{"```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"```\n[1, 2]\n```", `[1, 2]`},
+		{`The answer is {"a": {"b": 1}}. Done.`, `{"a": {"b": 1}}`},
+		{`no JSON here`, `no JSON here`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ExtractJSON(tt.content))
+	}
+}
+
+func TestIsStrict(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Value    string `json:"value"`
+		Children []node `json:"children"`
+	}
+	type optional struct {
+		Value string `json:"value,omitempty"`
+	}
+	type withMap struct {
+		Values map[string]int `json:"values"`
+	}
+
+	tests := []struct {
+		v    any
+		want bool
+	}{
+		{weather{}, true},
+		{node{}, true},
+		{[]node{}, true},
+		{optional{}, false},
+		{withMap{}, false},
+	}
+	for _, tt := range tests {
+		def, err := jsonschema.Reflect(tt.v)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, isStrict(def), "%T", tt.v)
+	}
+}
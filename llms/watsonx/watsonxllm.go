@@ -53,12 +53,19 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		return nil, ErrEmptyResponse
 	}
 
+	usage := &llms.Usage{
+		PromptTokens:     result.InputTokenCount,
+		CompletionTokens: result.GeneratedTokenCount,
+		TotalTokens:      result.InputTokenCount + result.GeneratedTokenCount,
+	}
 	resp := &llms.ContentResponse{
 		Choices: []*llms.ContentChoice{
 			{
 				Content: result.Text,
+				Usage:   usage,
 			},
 		},
+		Usage: usage,
 	}
 	return resp, nil
 }
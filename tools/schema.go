@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// ErrInvalidArguments is returned by schema tools called with arguments that
+// do not match their schema.
+var ErrInvalidArguments = errors.New("invalid tool arguments")
+
+// Func is a SchemaTool calling a Go function with typed arguments. Its schema
+// is reflected from the type of the arguments with jsonschema.Reflect.
+type Func[Args, Result any] struct {
+	name        string
+	description string
+	schema      *jsonschema.Definition
+	fn          func(ctx context.Context, args Args) (Result, error)
+}
+
+var _ SchemaTool = (*Func[struct{}, string])(nil)
+
+// NewFunc creates a tool calling fn. Args is usually a struct whose fields are
+// the arguments of the tool, described with the tags supported by
+// jsonschema.Reflect:
+//
+//	type weatherArgs struct {
+//		City string `json:"city" description:"The name of the city"`
+//		Unit string `json:"unit" enum:"celsius,fahrenheit"`
+//	}
+//
+//	weather, err := tools.NewFunc("weather", "Gets the current weather in a city.",
+//		func(ctx context.Context, args weatherArgs) (string, error) { ... })
+//
+// A result that is not a string is encoded as JSON.
+func NewFunc[Args, Result any](
+	name, description string,
+	fn func(ctx context.Context, args Args) (Result, error),
+) (*Func[Args, Result], error) {
+	var args Args
+	schema, err := jsonschema.Reflect(&args)
+	if err != nil {
+		return nil, fmt.Errorf("tool %s: %w", name, err)
+	}
+	if schema.Type != jsonschema.Object {
+		return nil, fmt.Errorf("tool %s: arguments must be an object, not %s", name, schema.Type)
+	}
+	return &Func[Args, Result]{
+		name:        name,
+		description: description,
+		schema:      schema,
+		fn:          fn,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (f *Func[Args, Result]) Name() string {
+	return f.name
+}
+
+// Description returns the description of the tool.
+func (f *Func[Args, Result]) Description() string {
+	return f.description
+}
+
+// Schema returns the JSON schema of the arguments of the tool.
+func (f *Func[Args, Result]) Schema() *jsonschema.Definition {
+	return f.schema
+}
+
+// Call calls the tool with input, the arguments encoded as a JSON object.
+func (f *Func[Args, Result]) Call(ctx context.Context, input string) (string, error) {
+	return f.CallArgs(ctx, json.RawMessage(input))
+}
+
+// CallArgs validates and decodes the arguments, then calls the function.
+func (f *Func[Args, Result]) CallArgs(ctx context.Context, args json.RawMessage) (string, error) {
+	var value any
+	if err := json.Unmarshal(args, &value); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	if err := jsonschema.Validate(f.schema, value); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	var decoded Args
+	if err := json.Unmarshal(args, &decoded); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+
+	result, err := f.fn(ctx, decoded)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := any(result).(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("encode result: %w", err)
+	}
+	return string(data), nil
+}
+
+// inputArgs are the arguments of a string tool adapted with AsSchemaTool.
+type inputArgs struct {
+	Input string `json:"input" description:"The input to the tool"`
+}
+
+// stringTool adapts a string tool to the SchemaTool interface.
+type stringTool struct {
+	Tool
+}
+
+// AsSchemaTool returns tool as a SchemaTool. Schema tools are returned as is,
+// and string tools are adapted to take a single "input" string argument.
+func AsSchemaTool(tool Tool) SchemaTool {
+	if st, ok := tool.(SchemaTool); ok {
+		return st
+	}
+	return stringTool{Tool: tool}
+}
+
+func (t stringTool) Schema() *jsonschema.Definition {
+	schema, _ := jsonschema.Reflect(inputArgs{})
+	return schema
+}
+
+func (t stringTool) CallArgs(ctx context.Context, args json.RawMessage) (string, error) {
+	var input inputArgs
+	if err := json.Unmarshal(args, &input); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	return t.Tool.Call(ctx, input.Input)
+}
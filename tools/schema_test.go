@@ -0,0 +1,84 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+type weatherArgs struct {
+	City string `json:"city" description:"The name of the city"`
+	Unit string `json:"unit" enum:"celsius,fahrenheit"`
+}
+
+type weatherResult struct {
+	Temperature float64 `json:"temperature"`
+	Unit        string  `json:"unit"`
+}
+
+func newWeatherTool(t *testing.T) *tools.Func[weatherArgs, weatherResult] {
+	t.Helper()
+
+	tool, err := tools.NewFunc("weather", "Gets the current weather in a city.",
+		func(_ context.Context, args weatherArgs) (weatherResult, error) {
+			return weatherResult{Temperature: 21.5, Unit: args.Unit}, nil
+		})
+	require.NoError(t, err)
+	return tool
+}
+
+func TestFunc(t *testing.T) {
+	t.Parallel()
+
+	tool := newWeatherTool(t)
+	assert.Equal(t, "weather", tool.Name())
+	assert.Equal(t, "Gets the current weather in a city.", tool.Description())
+	assert.Equal(t, []string{"city", "unit"}, tool.Schema().Required)
+	assert.Equal(t, []string{"celsius", "fahrenheit"}, tool.Schema().Properties["unit"].Enum)
+
+	result, err := tool.Call(context.Background(), `{"city": "Paris", "unit": "celsius"}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"temperature": 21.5, "unit": "celsius"}`, result)
+
+	_, err = tool.CallArgs(context.Background(), json.RawMessage(`{"city": "Paris", "unit": "kelvin"}`))
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+	assert.Contains(t, err.Error(), "$.unit")
+
+	_, err = tool.CallArgs(context.Background(), json.RawMessage(`Paris`))
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+}
+
+func TestNewFuncRequiresObjectArguments(t *testing.T) {
+	t.Parallel()
+
+	_, err := tools.NewFunc("echo", "Echoes its input.",
+		func(_ context.Context, s string) (string, error) { return s, nil })
+	require.Error(t, err)
+}
+
+func TestAsSchemaTool(t *testing.T) {
+	t.Parallel()
+
+	weather := newWeatherTool(t)
+	assert.Same(t, weather, tools.AsSchemaTool(weather))
+
+	calculator := tools.AsSchemaTool(tools.Calculator{})
+	assert.Equal(t, "calculator", calculator.Name())
+	assert.Equal(t, &jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"input": {Type: jsonschema.String, Description: "The input to the tool"},
+		},
+		Required:             []string{"input"},
+		AdditionalProperties: false,
+	}, calculator.Schema())
+
+	result, err := calculator.CallArgs(context.Background(), json.RawMessage(`{"input": "1 + 2"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "3", result)
+}
@@ -1,6 +1,11 @@
 package tools
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
 
 // Tool is a tool for the llm agent to interact with different applications.
 type Tool interface {
@@ -8,3 +13,16 @@ type Tool interface {
 	Description() string
 	Call(ctx context.Context, input string) (string, error)
 }
+
+// SchemaTool is a tool that takes structured arguments rather than a single
+// string. Its arguments are a JSON object described by a JSON schema, which
+// agents give to models supporting tool calling. Agents pass the arguments to
+// Call as JSON, so the Call method of a schema tool usually calls CallArgs.
+type SchemaTool interface {
+	Tool
+	// Schema returns the JSON schema of the arguments of the tool.
+	Schema() *jsonschema.Definition
+	// CallArgs calls the tool with arguments encoded as a JSON object matching
+	// the schema.
+	CallArgs(ctx context.Context, args json.RawMessage) (string, error)
+}
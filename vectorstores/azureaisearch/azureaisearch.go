@@ -11,6 +11,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 )
 
 // Store is a wrapper to use azure AI search rest API.
@@ -115,8 +116,14 @@ func (s *Store) SimilaritySearch(
 		}},
 	}
 
-	if filter, ok := opts.Filters.(string); ok {
-		payload.Filter = filter
+	switch filters := opts.Filters.(type) {
+	case string:
+		payload.Filter = filters
+	case filter.Filter:
+		if err := filters.Validate(); err != nil {
+			return nil, err
+		}
+		payload.Filter = createODataFilter(filters)
 	}
 
 	searchResults := SearchDocumentsRequestOuput{}
@@ -0,0 +1,63 @@
+package azureaisearch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// createODataFilter returns the OData filter of the filter. The keys are the
+// names of filterable fields of the index, since the default index stores the
+// metadata as text.
+//
+//nolint:cyclop
+func createODataFilter(f filter.Filter) string {
+	switch f.Op {
+	case filter.OpEq:
+		return fmt.Sprintf("%s eq %s", f.Key, formatODataValue(f.Value))
+	case filter.OpNe:
+		return fmt.Sprintf("%s ne %s", f.Key, formatODataValue(f.Value))
+	case filter.OpIn:
+		expressions := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			expressions = append(expressions, createODataFilter(filter.Eq(f.Key, v)))
+		}
+		return "(" + strings.Join(expressions, " or ") + ")"
+	case filter.OpRange:
+		expressions := []string{}
+		for _, bound := range []struct {
+			operator string
+			value    any
+		}{
+			{"gt", f.Bounds.Gt},
+			{"ge", f.Bounds.Gte},
+			{"lt", f.Bounds.Lt},
+			{"le", f.Bounds.Lte},
+		} {
+			if bound.value != nil {
+				expressions = append(expressions, fmt.Sprintf("%s %s %s", f.Key, bound.operator, formatODataValue(bound.value)))
+			}
+		}
+		return "(" + strings.Join(expressions, " and ") + ")"
+	case filter.OpExists:
+		return fmt.Sprintf("%s ne null", f.Key)
+	case filter.OpAnd, filter.OpOr:
+		expressions := make([]string, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			expressions = append(expressions, createODataFilter(operand))
+		}
+		return "(" + strings.Join(expressions, " "+string(f.Op)+" ") + ")"
+	case filter.OpNot:
+		return "not (" + createODataFilter(f.Filters[0]) + ")"
+	default:
+		return ""
+	}
+}
+
+func formatODataValue(value any) string {
+	if s, ok := value.(string); ok {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return fmt.Sprint(value)
+}
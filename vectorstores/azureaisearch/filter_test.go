@@ -0,0 +1,22 @@
+package azureaisearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestCreateODataFilter(t *testing.T) {
+	t.Parallel()
+
+	f := filter.And(
+		filter.In("category", "hotel", "motel's"),
+		filter.Range("rating", filter.Bounds{Gte: 3, Lt: 4.5}),
+		filter.Not(filter.Or(filter.Eq("smoking", true), filter.Exists("closed"))),
+	)
+	assert.Equal(t,
+		"((category eq 'hotel' or category eq 'motel''s') and (rating ge 3 and rating lt 4.5) and "+
+			"not ((smoking eq true or closed ne null)))",
+		createODataFilter(f))
+}
@@ -36,7 +36,8 @@ func (s *Store) getOptions(options ...vectorstores.Option) vectorstores.Options
 	return opts
 }
 
-// WithFilters can set the filter property in search document payload.
+// WithFilters can set the filter property in search document payload, an OData
+// filter or a filter.Filter.
 func WithFilters(filters any) vectorstores.Option {
 	return func(o *vectorstores.Options) {
 		o.Filters = filters
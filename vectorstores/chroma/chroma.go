@@ -12,6 +12,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"golang.org/x/exp/maps"
 )
 
@@ -20,8 +21,12 @@ var (
 	ErrUnexpectedResponseLength = errors.New("unexpected length of response")
 	ErrNewClient                = errors.New("error creating collection")
 	ErrAddDocument              = errors.New("error adding document")
+	ErrUpsertDocument           = errors.New("error upserting document")
+	ErrGetDocument              = errors.New("error getting document")
+	ErrDeleteDocument           = errors.New("error deleting document")
 	ErrRemoveCollection         = errors.New("error resetting collection")
 	ErrUnsupportedOptions       = errors.New("unsupported options")
+	ErrInvalidFilters           = errors.New("invalid filters")
 )
 
 // Store is a wrapper around the chromaGo API and client.
@@ -39,7 +44,10 @@ type Store struct {
 	includes     []chromatypes.QueryEnum
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.MutableVectorStore = Store{}
+	_ vectorstores.VectorSearcher     = Store{}
+)
 
 // New creates an active client connection to the (specified, or default) collection in the Chroma server
 // and returns the `Store` object needed by the other accessors.
@@ -103,10 +111,119 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	ids := make([]string, len(docs))
+	for docIdx := range docs {
+		ids[docIdx] = uuid.New().String() // TODO (noodnik2): find & use something more meaningful
+	}
+	texts, metadatas := s.getRecords(docs, nameSpace)
+
+	col := s.collection
+	if _, addErr := col.Add(ctx, nil, metadatas, texts, ids); addErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAddDocument, addErr)
+	}
+	return ids, nil
+}
+
+// Upsert adds the text and metadata from the documents to the Chroma collection associated with
+// 'Store' with the ids, replacing the documents with the same ids.
+func (s Store) Upsert(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if opts.Embedder != nil || opts.ScoreThreshold != 0 || opts.Filters != nil {
+		return ErrUnsupportedOptions
+	}
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	nameSpace := s.getNameSpace(opts)
+	if nameSpace != "" && s.nameSpaceKey == "" {
+		return fmt.Errorf("%w: nameSpace without nameSpaceKey", ErrUnsupportedOptions)
+	}
+
+	texts, metadatas := s.getRecords(docs, nameSpace)
+	if _, upsertErr := s.collection.Upsert(ctx, nil, metadatas, texts, ids); upsertErr != nil {
+		return fmt.Errorf("%w: %w", ErrUpsertDocument, upsertErr)
+	}
+	return nil
+}
+
+// GetByIDs returns the documents of the name space with the ids.
+func (s Store) GetByIDs(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	if len(ids) == 0 {
+		return map[string]schema.Document{}, nil
+	}
+	opts := s.getOptions(options...)
+	where, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	gr, getErr := s.collection.Get(ctx, where, nil, ids,
+		[]chromatypes.QueryEnum{chromatypes.IDocuments, chromatypes.IMetadatas})
+	if getErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetDocument, getErr)
+	}
+	if len(gr.Ids) != len(gr.Documents) || len(gr.Ids) != len(gr.Metadatas) {
+		return nil, fmt.Errorf("%w: gr.Ids[%d], gr.Documents[%d], gr.Metadatas[%d]",
+			ErrUnexpectedResponseLength, len(gr.Ids), len(gr.Documents), len(gr.Metadatas))
+	}
+
+	docs := make(map[string]schema.Document, len(gr.Ids))
+	for i, id := range gr.Ids {
+		docs[id] = schema.Document{PageContent: gr.Documents[i], Metadata: gr.Metadatas[i]}
+	}
+	return docs, nil
+}
+
+// Delete removes the documents of the name space with the ids.
+func (s Store) Delete(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	opts := s.getOptions(options...)
+	where, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return err
+	}
+
+	if _, deleteErr := s.collection.Delete(ctx, ids, where, nil); deleteErr != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteDocument, deleteErr)
+	}
+	return nil
+}
+
+// DeleteByFilter removes the documents of the name space matching the filter, a Chroma where
+// filter or a filter.Filter.
+func (s Store) DeleteByFilter(ctx context.Context, filter any, options ...vectorstores.Option) error {
+	if where, ok := filter.(map[string]any); filter == nil || (ok && len(where) == 0) {
+		return vectorstores.ErrEmptyFilter
+	}
+	opts := s.getOptions(append(options, vectorstores.WithFilters(filter))...)
+	where, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return err
+	}
+
+	if _, deleteErr := s.collection.Delete(ctx, nil, where, nil); deleteErr != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteDocument, deleteErr)
+	}
+	return nil
+}
+
+// getRecords returns the texts and the metadatas of the documents, tagged with the name space.
+func (s Store) getRecords(docs []schema.Document, nameSpace string) ([]string, []map[string]any) {
 	texts := make([]string, len(docs))
 	metadatas := make([]map[string]any, len(docs))
 	for docIdx, doc := range docs {
-		ids[docIdx] = uuid.New().String() // TODO (noodnik2): find & use something more meaningful
 		texts[docIdx] = doc.PageContent
 		mc := make(map[string]any, 0)
 		maps.Copy(mc, doc.Metadata)
@@ -115,40 +232,96 @@ func (s Store) AddDocuments(ctx context.Context,
 			metadatas[docIdx][s.nameSpaceKey] = nameSpace
 		}
 	}
-
-	col := s.collection
-	if _, addErr := col.Add(ctx, nil, metadatas, texts, ids); addErr != nil {
-		return nil, fmt.Errorf("%w: %w", ErrAddDocument, addErr)
-	}
-	return ids, nil
+	return texts, metadatas
 }
 
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	docs, _, err := s.query(ctx, s.collection, chromatypes.WithQueryTexts([]string{query}), numDocuments, options...)
+	return docs, err
+}
+
+// SimilaritySearchWithVectors is like SimilaritySearch, and also returns the vectors of the query
+// and of the documents.
+func (s Store) SimilaritySearchWithVectors(ctx context.Context, query string, numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	queryEmbedding, err := s.collection.EmbeddingFunction.EmbedQuery(ctx, query)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	// chroma-go embeds the query texts even if there are none, so the collection is queried with
+	// an embedding function skipping empty calls.
+	collection := *s.collection
+	collection.EmbeddingFunction = queryEmbeddingFunction{collection.EmbeddingFunction}
+	docs, ids, err := s.query(ctx, &collection, chromatypes.WithQueryEmbedding(queryEmbedding), numDocuments, options...)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	if len(ids) == 0 {
+		return vectorstores.VectorSearchResult{QueryVector: getFloat32(queryEmbedding)}, nil
+	}
+
+	// the query results of chroma-go do not include the embeddings
+	gr, getErr := s.collection.Get(ctx, nil, nil, ids, []chromatypes.QueryEnum{chromatypes.IEmbeddings})
+	if getErr != nil {
+		return vectorstores.VectorSearchResult{}, fmt.Errorf("%w: %w", ErrGetDocument, getErr)
+	}
+	if len(gr.Ids) != len(gr.Embeddings) {
+		return vectorstores.VectorSearchResult{}, fmt.Errorf("%w: gr.Ids[%d], gr.Embeddings[%d]",
+			ErrUnexpectedResponseLength, len(gr.Ids), len(gr.Embeddings))
+	}
+	vectorsByID := make(map[string][]float32, len(gr.Ids))
+	for i, id := range gr.Ids {
+		vectorsByID[id] = getFloat32(gr.Embeddings[i])
+	}
+	vectors := make([][]float32, len(ids))
+	for i, id := range ids {
+		vectors[i] = vectorsByID[id]
+	}
+
+	return vectorstores.VectorSearchResult{
+		QueryVector: getFloat32(queryEmbedding),
+		Documents:   docs,
+		Vectors:     vectors,
+	}, nil
+}
+
+// query returns the documents of the name space most similar to the query in the collection, and
+// their ids.
+func (s Store) query(ctx context.Context, collection *chromago.Collection, query chromatypes.CollectionQueryOption,
+	numDocuments int, options ...vectorstores.Option,
+) ([]schema.Document, []string, error) {
 	opts := s.getOptions(options...)
 
 	if opts.Embedder != nil {
 		// embedder is not used by this method, so shouldn't ever be specified
-		return nil, fmt.Errorf("%w: Embedder", ErrUnsupportedOptions)
+		return nil, nil, fmt.Errorf("%w: Embedder", ErrUnsupportedOptions)
 	}
 
 	scoreThreshold, stErr := s.getScoreThreshold(opts)
 	if stErr != nil {
-		return nil, stErr
+		return nil, nil, stErr
 	}
 
-	filter := s.getNamespacedFilter(opts)
-	qr, queryErr := s.collection.Query(ctx, []string{query}, int32(numDocuments), filter, nil, s.includes)
+	where, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	qr, queryErr := collection.QueryWithOptions(ctx, query, chromatypes.WithNResults(int32(numDocuments)),
+		chromatypes.WithWhereMap(where), chromatypes.WithInclude(s.includes...))
 	if queryErr != nil {
-		return nil, queryErr
+		return nil, nil, queryErr
 	}
 
-	if len(qr.Documents) != len(qr.Metadatas) || len(qr.Metadatas) != len(qr.Distances) {
-		return nil, fmt.Errorf("%w: qr.Documents[%d], qr.Metadatas[%d], qr.Distances[%d]",
-			ErrUnexpectedResponseLength, len(qr.Documents), len(qr.Metadatas), len(qr.Distances))
+	if len(qr.Documents) != len(qr.Metadatas) || len(qr.Metadatas) != len(qr.Distances) ||
+		len(qr.Distances) != len(qr.Ids) {
+		return nil, nil, fmt.Errorf("%w: qr.Documents[%d], qr.Metadatas[%d], qr.Distances[%d], qr.Ids[%d]",
+			ErrUnexpectedResponseLength, len(qr.Documents), len(qr.Metadatas), len(qr.Distances), len(qr.Ids))
 	}
 	var sDocs []schema.Document
+	var ids []string
 	for docsI := range qr.Documents {
 		for docI := range qr.Documents[docsI] {
 			if score := 1.0 - qr.Distances[docsI][docI]; score >= scoreThreshold {
@@ -157,11 +330,19 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 					PageContent: qr.Documents[docsI][docI],
 					Score:       score,
 				})
+				ids = append(ids, qr.Ids[docsI][docI])
 			}
 		}
 	}
 
-	return sDocs, nil
+	return sDocs, ids, nil
+}
+
+func getFloat32(embedding *chromatypes.Embedding) []float32 {
+	if embedding == nil || embedding.GetFloat32() == nil {
+		return nil
+	}
+	return *embedding.GetFloat32()
 }
 
 func (s Store) RemoveCollection() error {
@@ -197,18 +378,35 @@ func (s Store) getNameSpace(opts vectorstores.Options) string {
 	return s.nameSpace
 }
 
-func (s Store) getNamespacedFilter(opts vectorstores.Options) map[string]any {
-	filter, _ := opts.Filters.(map[string]any)
+// getNamespacedFilter returns the where filter of the options, a Chroma where filter or a
+// filter.Filter, restricted to the name space.
+func (s Store) getNamespacedFilter(opts vectorstores.Options) (map[string]any, error) {
+	var where map[string]any
+	switch filters := opts.Filters.(type) {
+	case nil:
+	case map[string]any:
+		where = filters
+	case filter.Filter:
+		if err := filters.Validate(); err != nil {
+			return nil, err
+		}
+		var err error
+		if where, err = createWhere(filters); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrInvalidFilters
+	}
 
 	nameSpace := s.getNameSpace(opts)
 	if nameSpace == "" || s.nameSpaceKey == "" {
-		return filter
+		return where, nil
 	}
 
 	nameSpaceFilter := map[string]any{s.nameSpaceKey: nameSpace}
-	if filter == nil {
-		return nameSpaceFilter
+	if where == nil {
+		return nameSpaceFilter, nil
 	}
 
-	return map[string]any{"$and": []map[string]any{nameSpaceFilter, filter}}
+	return map[string]any{"$and": []map[string]any{nameSpaceFilter, where}}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	chromatypes "github.com/amikos-tech/chroma-go/types"
@@ -559,6 +560,112 @@ func TestChromaAsRetrieverWithMetadataFilters(t *testing.T) {
 	require.Contains(t, result, "purple", "expected black in purple")
 }
 
+func TestChromaStoreMutations(t *testing.T) {
+	t.Parallel()
+
+	testChromaURL, openaiAPIKey := getValues(t)
+	llm, err := openai.New()
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	s, err := chroma.New(
+		chroma.WithOpenAIAPIKey(openaiAPIKey),
+		chroma.WithChromaURL(testChromaURL),
+		chroma.WithDistanceFunction(chromatypes.COSINE),
+		chroma.WithNameSpace(getTestNameSpace()),
+		chroma.WithEmbedder(e),
+	)
+	require.NoError(t, err)
+
+	defer cleanupTestArtifacts(t, s)
+
+	ctx := context.Background()
+	ids, err := s.AddDocuments(ctx, []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"type": "city"}},
+		{PageContent: "potato", Metadata: map[string]any{"type": "vegetable"}},
+		{PageContent: "paris", Metadata: map[string]any{"type": "city"}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Upsert(ctx, ids[:1], []schema.Document{
+		{PageContent: "kyoto", Metadata: map[string]any{"type": "city"}},
+	}))
+	docs, err := s.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+
+	require.NoError(t, s.Delete(ctx, ids[2:]))
+	require.NoError(t, s.DeleteByFilter(ctx, map[string]any{"type": "vegetable"}))
+	require.ErrorIs(t, s.DeleteByFilter(ctx, nil), vectorstores.ErrEmptyFilter)
+
+	docs, err = s.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+}
+
+func TestChromaStoreMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+
+	testChromaURL, openaiAPIKey := getValues(t)
+	llm, err := openai.New()
+	require.NoError(t, err)
+	openaiEmbedder, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+	e := &countingEmbedder{Embedder: openaiEmbedder}
+
+	s, err := chroma.New(
+		chroma.WithOpenAIAPIKey(openaiAPIKey),
+		chroma.WithChromaURL(testChromaURL),
+		chroma.WithDistanceFunction(chromatypes.COSINE),
+		chroma.WithNameSpace(getTestNameSpace()),
+		chroma.WithEmbedder(e),
+	)
+	require.NoError(t, err)
+
+	defer cleanupTestArtifacts(t, s)
+
+	ctx := context.Background()
+	_, err = s.AddDocuments(ctx, []schema.Document{
+		{PageContent: "Tokyo is the capital of Japan"},
+		{PageContent: "Tokyo is the capital city of Japan"},
+		{PageContent: "Kyoto is a city in Japan"},
+	})
+	require.NoError(t, err)
+
+	e.calls.Store(0)
+	res, err := s.SimilaritySearchWithVectors(ctx, "capital of Japan", 3)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), e.calls.Load(), "the query is embedded once")
+	require.Len(t, res.Documents, 3)
+	require.Len(t, res.Vectors, 3)
+	require.Len(t, res.Vectors[0], len(res.QueryVector))
+
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, s, "capital of Japan", 2,
+		vectorstores.WithMMR(3, 0.3))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, []string{docs[0].PageContent, docs[1].PageContent}, "Kyoto is a city in Japan")
+}
+
+// countingEmbedder counts its calls.
+type countingEmbedder struct {
+	embeddings.Embedder
+	calls atomic.Int32
+}
+
+func (e *countingEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	e.calls.Add(1)
+	return e.Embedder.EmbedDocuments(ctx, texts)
+}
+
+func (e *countingEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	e.calls.Add(1)
+	return e.Embedder.EmbedQuery(ctx, text)
+}
+
 func getValues(t *testing.T) (string, string) {
 	t.Helper()
 
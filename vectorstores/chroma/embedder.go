@@ -37,3 +37,16 @@ func (e chromaGoEmbedder) EmbedQuery(ctx context.Context, text string) (*chromat
 func (e chromaGoEmbedder) EmbedRecords(ctx context.Context, records []*chromatypes.Record, force bool) error {
 	return chromatypes.EmbedRecordsDefaultImpl(e, ctx, records, force)
 }
+
+// queryEmbeddingFunction wraps the embedding function of a collection queried by embedding, for
+// which chroma-go still embeds the (empty) list of query texts.
+type queryEmbeddingFunction struct {
+	chromatypes.EmbeddingFunction
+}
+
+func (e queryEmbeddingFunction) EmbedDocuments(ctx context.Context, texts []string) ([]*chromatypes.Embedding, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	return e.EmbeddingFunction.EmbedDocuments(ctx, texts)
+}
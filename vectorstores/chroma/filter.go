@@ -0,0 +1,63 @@
+package chroma
+
+import (
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// createWhere returns the Chroma where filter of the filter. Chroma has no
+// negation and cannot filter on whether a document has a key, so OpExists is
+// not supported, nor OpNot of a range.
+func createWhere(f filter.Filter) (map[string]any, error) {
+	return createWhereNode(f.PushDownNot())
+}
+
+//nolint:cyclop
+func createWhereNode(f filter.Filter) (map[string]any, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return map[string]any{f.Key: map[string]any{"$eq": f.Value}}, nil
+	case filter.OpNe:
+		return map[string]any{f.Key: map[string]any{"$ne": f.Value}}, nil
+	case filter.OpIn:
+		return map[string]any{f.Key: map[string]any{"$in": f.Values}}, nil
+	case filter.OpRange:
+		wheres := []map[string]any{}
+		for _, bound := range []struct {
+			operator string
+			value    any
+		}{
+			{"$gt", f.Bounds.Gt},
+			{"$gte", f.Bounds.Gte},
+			{"$lt", f.Bounds.Lt},
+			{"$lte", f.Bounds.Lte},
+		} {
+			if bound.value != nil {
+				wheres = append(wheres, map[string]any{f.Key: map[string]any{bound.operator: bound.value}})
+			}
+		}
+		return combineWheres("$and", wheres), nil
+	case filter.OpAnd, filter.OpOr:
+		wheres := make([]map[string]any, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			where, err := createWhereNode(operand)
+			if err != nil {
+				return nil, err
+			}
+			wheres = append(wheres, where)
+		}
+		return combineWheres("$"+string(f.Op), wheres), nil
+	case filter.OpExists, filter.OpNot:
+		return nil, filter.Unsupported("chroma", filter.OpExists)
+	default:
+		return nil, filter.Unsupported("chroma", f.Op)
+	}
+}
+
+// combineWheres combines the where filters with the operator, which Chroma
+// only accepts with two filters or more.
+func combineWheres(operator string, wheres []map[string]any) map[string]any {
+	if len(wheres) == 1 {
+		return wheres[0]
+	}
+	return map[string]any{operator: wheres}
+}
@@ -0,0 +1,44 @@
+package chroma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestGetNamespacedFilter(t *testing.T) {
+	t.Parallel()
+
+	s := Store{nameSpaceKey: "nameSpace"}
+	where, err := s.getNamespacedFilter(vectorstores.Options{
+		NameSpace: "docs",
+		Filters: filter.Or(
+			filter.Eq("location", "patio"),
+			filter.Not(filter.In("color", "red", "blue")),
+			filter.Range("size", filter.Bounds{Gte: 1, Lt: 3}),
+		),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"$and": []map[string]any{
+		{"nameSpace": "docs"},
+		{"$or": []map[string]any{
+			{"location": map[string]any{"$eq": "patio"}},
+			{"$and": []map[string]any{
+				{"color": map[string]any{"$ne": "red"}},
+				{"color": map[string]any{"$ne": "blue"}},
+			}},
+			{"$and": []map[string]any{
+				{"size": map[string]any{"$gte": 1}},
+				{"size": map[string]any{"$lt": 3}},
+			}},
+		}},
+	}}, where)
+
+	_, err = s.getNamespacedFilter(vectorstores.Options{Filters: filter.Not(filter.Gt("size", 1))})
+	require.ErrorIs(t, err, filter.ErrUnsupportedOperator)
+	_, err = s.getNamespacedFilter(vectorstores.Options{Filters: "location = patio"})
+	require.ErrorIs(t, err, ErrInvalidFilters)
+}
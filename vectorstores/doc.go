@@ -7,6 +7,7 @@ The main components of this package are:
 - VectorStore interface: a common interface for saving and querying vector embeddings of documents.
 - Options: a set of options for similarity search and document addition.
 - Retriever: a retriever for vector stores that implements the schema.Retriever interface.
+- MaxMarginalRelevanceSearch: a search for documents similar to a query but diverse.
 
 The package provides a flexible way to handle different types of vector stores
 by using the VectorStore interface as an abstraction.
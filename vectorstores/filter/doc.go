@@ -0,0 +1,22 @@
+/*
+Package filter contains a metadata filter language shared by the vector stores.
+
+A Filter is a tree of conditions on the metadata of the documents, built with
+Eq, Ne, In, Gt, Gte, Lt, Lte, Range, Exists, And, Or and Not:
+
+	f := filter.And(
+		filter.Eq("source", "handbook.pdf"),
+		filter.Gte("page", 10),
+		filter.Not(filter.In("status", "draft", "archived")),
+	)
+
+	docs, err := store.SimilaritySearch(ctx, query, 4, vectorstores.WithFilters(f))
+
+The vector stores accepting a Filter in vectorstores.WithFilters translate it to
+their native filter syntax, and fail with an error wrapping
+ErrUnsupportedOperator when they cannot express one of its operators. Stores
+without a negation operator rewrite the filter with PushDownNot first. Match
+evaluates a Filter against the metadata of a document, for the stores filtering
+documents themselves.
+*/
+package filter
@@ -0,0 +1,282 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidFilter is returned when a filter is malformed, see Validate.
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrUnsupportedOperator is returned when a vector store cannot translate
+	// an operator of a filter to its native syntax.
+	ErrUnsupportedOperator = errors.New("unsupported filter operator")
+)
+
+// Op is the operator of a filter.
+type Op string
+
+const (
+	// OpEq matches the documents whose value of the key equals the value.
+	OpEq Op = "eq"
+	// OpNe matches the documents whose value of the key differs from the
+	// value, or that do not have the key.
+	OpNe Op = "ne"
+	// OpIn matches the documents whose value of the key is one of the values.
+	OpIn Op = "in"
+	// OpRange matches the documents whose value of the key is within the
+	// bounds.
+	OpRange Op = "range"
+	// OpExists matches the documents that have the key.
+	OpExists Op = "exists"
+	// OpAnd matches the documents matching all the filters.
+	OpAnd Op = "and"
+	// OpOr matches the documents matching any of the filters.
+	OpOr Op = "or"
+	// OpNot matches the documents not matching the filter.
+	OpNot Op = "not"
+)
+
+// Filter is a condition on the metadata of the documents. Build filters with
+// the functions of this package rather than by hand.
+type Filter struct {
+	// Op is the operator of the filter.
+	Op Op
+	// Key is the metadata key of OpEq, OpNe, OpIn, OpRange and OpExists.
+	Key string
+	// Value is the value of OpEq and OpNe: a string, a number or a bool.
+	Value any
+	// Values are the values of OpIn.
+	Values []any
+	// Bounds are the bounds of OpRange.
+	Bounds Bounds
+	// Filters are the operands of OpAnd and OpOr, and the single operand of
+	// OpNot.
+	Filters []Filter
+}
+
+// Bounds are the bounds of a range, numbers. Nil bounds are unbounded.
+type Bounds struct {
+	Gt  any
+	Gte any
+	Lt  any
+	Lte any
+}
+
+// Eq returns a filter matching the documents whose value of the key equals
+// the value.
+func Eq(key string, value any) Filter {
+	return Filter{Op: OpEq, Key: key, Value: value}
+}
+
+// Ne returns a filter matching the documents whose value of the key differs
+// from the value.
+func Ne(key string, value any) Filter {
+	return Filter{Op: OpNe, Key: key, Value: value}
+}
+
+// In returns a filter matching the documents whose value of the key is one of
+// the values.
+func In(key string, values ...any) Filter {
+	return Filter{Op: OpIn, Key: key, Values: values}
+}
+
+// Gt returns a filter matching the documents whose value of the key is
+// greater than the value.
+func Gt(key string, value any) Filter {
+	return Range(key, Bounds{Gt: value})
+}
+
+// Gte returns a filter matching the documents whose value of the key is
+// greater than or equal to the value.
+func Gte(key string, value any) Filter {
+	return Range(key, Bounds{Gte: value})
+}
+
+// Lt returns a filter matching the documents whose value of the key is less
+// than the value.
+func Lt(key string, value any) Filter {
+	return Range(key, Bounds{Lt: value})
+}
+
+// Lte returns a filter matching the documents whose value of the key is less
+// than or equal to the value.
+func Lte(key string, value any) Filter {
+	return Range(key, Bounds{Lte: value})
+}
+
+// Range returns a filter matching the documents whose value of the key is
+// within the bounds.
+func Range(key string, bounds Bounds) Filter {
+	return Filter{Op: OpRange, Key: key, Bounds: bounds}
+}
+
+// Exists returns a filter matching the documents that have the key.
+func Exists(key string) Filter {
+	return Filter{Op: OpExists, Key: key}
+}
+
+// And returns a filter matching the documents matching all the filters.
+func And(filters ...Filter) Filter {
+	return Filter{Op: OpAnd, Filters: filters}
+}
+
+// Or returns a filter matching the documents matching any of the filters.
+func Or(filters ...Filter) Filter {
+	return Filter{Op: OpOr, Filters: filters}
+}
+
+// Not returns a filter matching the documents not matching the filter.
+func Not(filter Filter) Filter {
+	return Filter{Op: OpNot, Filters: []Filter{filter}}
+}
+
+// Validate checks that the filter and its operands are well-formed.
+func (f Filter) Validate() error { //nolint:cyclop
+	switch f.Op {
+	case OpEq, OpNe:
+		if f.Key == "" {
+			return fmt.Errorf("%w: %s without key", ErrInvalidFilter, f.Op)
+		}
+		if !isScalar(f.Value) {
+			return fmt.Errorf("%w: %s value %v (%T) is not a string, a number or a bool",
+				ErrInvalidFilter, f.Op, f.Value, f.Value)
+		}
+	case OpIn:
+		if f.Key == "" {
+			return fmt.Errorf("%w: %s without key", ErrInvalidFilter, f.Op)
+		}
+		if len(f.Values) == 0 {
+			return fmt.Errorf("%w: %s without values", ErrInvalidFilter, f.Op)
+		}
+		for _, v := range f.Values {
+			if !isScalar(v) {
+				return fmt.Errorf("%w: %s value %v (%T) is not a string, a number or a bool",
+					ErrInvalidFilter, f.Op, v, v)
+			}
+		}
+	case OpRange:
+		return f.validateRange()
+	case OpExists:
+		if f.Key == "" {
+			return fmt.Errorf("%w: %s without key", ErrInvalidFilter, f.Op)
+		}
+	case OpAnd, OpOr, OpNot:
+		return f.validateOperands()
+	default:
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, f.Op)
+	}
+	return nil
+}
+
+func (f Filter) validateRange() error {
+	if f.Key == "" {
+		return fmt.Errorf("%w: %s without key", ErrInvalidFilter, f.Op)
+	}
+	b := f.Bounds
+	if b.Gt == nil && b.Gte == nil && b.Lt == nil && b.Lte == nil {
+		return fmt.Errorf("%w: %s without bounds", ErrInvalidFilter, f.Op)
+	}
+	if (b.Gt != nil && b.Gte != nil) || (b.Lt != nil && b.Lte != nil) {
+		return fmt.Errorf("%w: %s with two lower or upper bounds", ErrInvalidFilter, f.Op)
+	}
+	for _, v := range []any{b.Gt, b.Gte, b.Lt, b.Lte} {
+		if _, ok := ToFloat(v); v != nil && !ok {
+			return fmt.Errorf("%w: %s bound %v (%T) is not a number", ErrInvalidFilter, f.Op, v, v)
+		}
+	}
+	return nil
+}
+
+func (f Filter) validateOperands() error {
+	if len(f.Filters) == 0 {
+		return fmt.Errorf("%w: %s without operands", ErrInvalidFilter, f.Op)
+	}
+	if f.Op == OpNot && len(f.Filters) != 1 {
+		return fmt.Errorf("%w: %s with %d operands", ErrInvalidFilter, f.Op, len(f.Filters))
+	}
+	for _, operand := range f.Filters {
+		if err := operand.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unsupported returns an error wrapping ErrUnsupportedOperator for the
+// operator the store cannot translate.
+func Unsupported(store string, op Op) error {
+	return fmt.Errorf("%w: %s does not support %q", ErrUnsupportedOperator, store, op)
+}
+
+func isScalar(v any) bool {
+	switch v.(type) {
+	case string, bool:
+		return true
+	default:
+		_, ok := ToFloat(v)
+		return ok
+	}
+}
+
+// PushDownNot returns an equivalent filter in which Not only applies to
+// Exists filters, for the stores without a negation operator. Not of a range
+// also matches the documents without the key, so it needs Exists.
+func (f Filter) PushDownNot() Filter {
+	switch f.Op { //nolint:exhaustive
+	case OpAnd, OpOr:
+		operands := make([]Filter, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			operands = append(operands, operand.PushDownNot())
+		}
+		return Filter{Op: f.Op, Filters: operands}
+	case OpNot:
+		return negate(f.Filters[0])
+	default:
+		return f
+	}
+}
+
+// negate returns the negation of the filter with Not pushed down.
+func negate(f Filter) Filter { //nolint:cyclop
+	switch f.Op {
+	case OpEq:
+		return Ne(f.Key, f.Value)
+	case OpNe:
+		return Eq(f.Key, f.Value)
+	case OpIn:
+		operands := make([]Filter, 0, len(f.Values))
+		for _, v := range f.Values {
+			operands = append(operands, Ne(f.Key, v))
+		}
+		return And(operands...)
+	case OpRange:
+		operands := []Filter{}
+		if f.Bounds.Gt != nil {
+			operands = append(operands, Lte(f.Key, f.Bounds.Gt))
+		}
+		if f.Bounds.Gte != nil {
+			operands = append(operands, Lt(f.Key, f.Bounds.Gte))
+		}
+		if f.Bounds.Lt != nil {
+			operands = append(operands, Gte(f.Key, f.Bounds.Lt))
+		}
+		if f.Bounds.Lte != nil {
+			operands = append(operands, Gt(f.Key, f.Bounds.Lte))
+		}
+		return Or(append(operands, Not(Exists(f.Key)))...)
+	case OpAnd, OpOr:
+		operands := make([]Filter, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			operands = append(operands, negate(operand))
+		}
+		if f.Op == OpAnd {
+			return Or(operands...)
+		}
+		return And(operands...)
+	case OpNot:
+		return f.Filters[0].PushDownNot()
+	default:
+		return Not(f)
+	}
+}
@@ -0,0 +1,115 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	metadata := map[string]any{
+		"source": "handbook.pdf",
+		"page":   12,
+		"score":  0.5,
+		"public": true,
+	}
+
+	tests := []struct {
+		name   string
+		filter filter.Filter
+		want   bool
+	}{
+		{"eq", filter.Eq("source", "handbook.pdf"), true},
+		{"eq other value", filter.Eq("source", "faq.md"), false},
+		{"eq number of another type", filter.Eq("page", 12.0), true},
+		{"eq missing key", filter.Eq("author", "ann"), false},
+		{"ne", filter.Ne("source", "faq.md"), true},
+		{"ne missing key", filter.Ne("author", "ann"), true},
+		{"in", filter.In("page", 1, 12), true},
+		{"in other values", filter.In("page", 1, 2), false},
+		{"gt", filter.Gt("page", 12), false},
+		{"gte", filter.Gte("page", 12), true},
+		{"lt", filter.Lt("score", 0.6), true},
+		{"lte", filter.Lte("score", 0.4), false},
+		{"range", filter.Range("page", filter.Bounds{Gt: 10, Lte: 12}), true},
+		{"range not a number", filter.Gt("source", 1), false},
+		{"exists", filter.Exists("public"), true},
+		{"exists missing key", filter.Exists("author"), false},
+		{"and", filter.And(filter.Eq("public", true), filter.Gte("page", 10)), true},
+		{"and one false", filter.And(filter.Eq("public", true), filter.Lt("page", 10)), false},
+		{"or", filter.Or(filter.Eq("public", false), filter.Gte("page", 10)), true},
+		{"or all false", filter.Or(filter.Eq("public", false), filter.Lt("page", 10)), false},
+		{"not", filter.Not(filter.In("source", "faq.md")), true},
+	}
+	for _, tt := range tests {
+		require.NoError(t, tt.filter.Validate(), tt.name)
+		assert.Equal(t, tt.want, tt.filter.Match(metadata), tt.name)
+	}
+}
+
+func TestFilterPushDownNot(t *testing.T) {
+	t.Parallel()
+
+	f := filter.And(
+		filter.Exists("source"),
+		filter.Not(filter.Or(
+			filter.Eq("source", "faq.md"),
+			filter.In("page", 1, 2),
+			filter.Range("score", filter.Bounds{Gt: 0.2, Lte: 0.8}),
+			filter.Not(filter.Ne("public", true)),
+		)),
+	)
+	assert.Equal(t, filter.And(
+		filter.Exists("source"),
+		filter.And(
+			filter.Ne("source", "faq.md"),
+			filter.And(filter.Ne("page", 1), filter.Ne("page", 2)),
+			filter.Or(filter.Lte("score", 0.2), filter.Gt("score", 0.8), filter.Not(filter.Exists("score"))),
+			filter.Ne("public", true),
+		),
+	), f.PushDownNot())
+
+	metadatas := []map[string]any{
+		{"source": "faq.md", "page": 3, "score": 0.5, "public": true},
+		{"source": "handbook.pdf", "page": 3, "score": 0.9, "public": false},
+		{"source": "handbook.pdf", "page": 2},
+		{"source": "handbook.pdf", "page": 4, "public": true},
+		{"page": 4},
+	}
+	for _, metadata := range metadatas {
+		assert.Equal(t, f.Match(metadata), f.PushDownNot().Match(metadata), metadata)
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter filter.Filter
+	}{
+		{"unknown operator", filter.Filter{Op: "like", Key: "source"}},
+		{"eq without key", filter.Eq("", "faq.md")},
+		{"eq with a slice", filter.Eq("tags", []string{"a"})},
+		{"in without values", filter.In("page")},
+		{"range without bounds", filter.Range("page", filter.Bounds{})},
+		{"range with two lower bounds", filter.Range("page", filter.Bounds{Gt: 1, Gte: 2})},
+		{"range with a string", filter.Gt("date", "2024-01-01")},
+		{"and without operands", filter.And()},
+		{"not with two operands", filter.Filter{Op: filter.OpNot, Filters: []filter.Filter{
+			filter.Exists("a"), filter.Exists("b"),
+		}}},
+		{"invalid operand", filter.Or(filter.Exists("a"), filter.Exists(""))},
+	}
+	for _, tt := range tests {
+		require.ErrorIs(t, tt.filter.Validate(), filter.ErrInvalidFilter, tt.name)
+	}
+
+	err := filter.Unsupported("store", filter.OpNot)
+	require.ErrorIs(t, err, filter.ErrUnsupportedOperator)
+	assert.Equal(t, `unsupported filter operator: store does not support "not"`, err.Error())
+}
@@ -0,0 +1,95 @@
+package filter
+
+import (
+	"reflect"
+)
+
+// Match reports whether the metadata matches the filter, which must be valid.
+// Numbers are compared by value whatever their type.
+func (f Filter) Match(metadata map[string]any) bool { //nolint:cyclop
+	switch f.Op {
+	case OpEq:
+		got, ok := metadata[f.Key]
+		return ok && valuesEqual(got, f.Value)
+	case OpNe:
+		got, ok := metadata[f.Key]
+		return !ok || !valuesEqual(got, f.Value)
+	case OpIn:
+		got, ok := metadata[f.Key]
+		if !ok {
+			return false
+		}
+		for _, want := range f.Values {
+			if valuesEqual(got, want) {
+				return true
+			}
+		}
+		return false
+	case OpRange:
+		got, ok := ToFloat(metadata[f.Key])
+		return ok && f.Bounds.contains(got)
+	case OpExists:
+		_, ok := metadata[f.Key]
+		return ok
+	case OpAnd:
+		for _, operand := range f.Filters {
+			if !operand.Match(metadata) {
+				return false
+			}
+		}
+		return true
+	case OpOr:
+		for _, operand := range f.Filters {
+			if operand.Match(metadata) {
+				return true
+			}
+		}
+		return false
+	case OpNot:
+		return !f.Filters[0].Match(metadata)
+	default:
+		return false
+	}
+}
+
+func (b Bounds) contains(v float64) bool {
+	if bound, ok := ToFloat(b.Gt); ok && v <= bound {
+		return false
+	}
+	if bound, ok := ToFloat(b.Gte); ok && v < bound {
+		return false
+	}
+	if bound, ok := ToFloat(b.Lt); ok && v >= bound {
+		return false
+	}
+	if bound, ok := ToFloat(b.Lte); ok && v > bound {
+		return false
+	}
+	return true
+}
+
+// valuesEqual reports whether two metadata values are equal, numbers being
+// compared by value whatever their type.
+func valuesEqual(a, b any) bool {
+	if x, ok := ToFloat(a); ok {
+		y, ok := ToFloat(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// ToFloat returns the value of a number of any type as a float64, and false
+// if v is not a number.
+func ToFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
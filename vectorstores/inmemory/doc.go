@@ -0,0 +1,8 @@
+// Package inmemory contains an implementation of the VectorStore interface
+// keeping the documents and their vectors in memory.
+//
+// It needs no external database, which makes it handy for tests, examples and
+// small applications. Documents are searched exhaustively, or with an HNSW
+// index for large stores, see WithHNSW. The content of the store can be saved
+// to and loaded from a file.
+package inmemory
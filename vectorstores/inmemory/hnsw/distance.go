@@ -0,0 +1,42 @@
+package hnsw
+
+import "math"
+
+// Distance is the distance of two vectors of the same length, lower being
+// nearer.
+type Distance func(a, b []float32) float32
+
+// CosineDistance is 1 minus the cosine of the angle between the vectors,
+// between 0 and 2.
+func CosineDistance(a, b []float32) float32 {
+	var ab, aa, bb float64
+	for i := range a {
+		ab += float64(a[i]) * float64(b[i])
+		aa += float64(a[i]) * float64(a[i])
+		bb += float64(b[i]) * float64(b[i])
+	}
+	if aa == 0 || bb == 0 {
+		return 1
+	}
+	return float32(1 - ab/math.Sqrt(aa*bb))
+}
+
+// DotProductDistance is the opposite of the dot product of the vectors, which
+// ranks normalized vectors like CosineDistance.
+func DotProductDistance(a, b []float32) float32 {
+	var ab float64
+	for i := range a {
+		ab += float64(a[i]) * float64(b[i])
+	}
+	return float32(-ab)
+}
+
+// EuclideanDistance is the euclidean distance of the vectors.
+func EuclideanDistance(a, b []float32) float32 {
+	var sum float64
+	for i := range a {
+		d := float64(a[i]) - float64(b[i])
+		sum += d * d
+	}
+	return float32(math.Sqrt(sum))
+}
@@ -0,0 +1,8 @@
+// Package hnsw implements a Hierarchical Navigable Small World graph, an
+// approximate nearest neighbour index searching millions of vectors in a few
+// milliseconds, see https://arxiv.org/abs/1603.09320.
+//
+// The recall of the searches, the share of the true nearest neighbours they
+// find, is traded for speed with the M, efConstruction and efSearch
+// parameters. BenchmarkSearch reports it against an exact search.
+package hnsw
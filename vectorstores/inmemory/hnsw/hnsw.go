@@ -0,0 +1,374 @@
+package hnsw
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// ErrDimensionMismatch is returned when a vector does not have the length of
+// the vectors of the index.
+var ErrDimensionMismatch = errors.New("vector dimension mismatch")
+
+// Result is a vector found by a search.
+type Result struct {
+	ID       string
+	Distance float32
+}
+
+// Index is an HNSW graph of vectors identified by string IDs. It is safe for
+// concurrent use, searches running in parallel.
+//
+// Deleted vectors are only marked as such and keep guiding the searches
+// through the graph, which is rebuilt without them once they outnumber the
+// other vectors.
+type Index struct {
+	m              int
+	efConstruction int
+	efSearch       int
+	distance       Distance
+	seed           int64
+
+	mu        sync.RWMutex
+	rng       *rand.Rand
+	levelMult float64
+	dims      int
+	nodes     []*node
+	ids       map[string]uint32
+	deleted   int
+	// entry is the node the searches start from, on the top layer, or -1 if
+	// the graph is empty.
+	entry    int
+	maxLevel int
+}
+
+type node struct {
+	id     string
+	vector []float32
+	// friends are the neighbours of the node on each of its layers.
+	friends [][]uint32
+	deleted bool
+}
+
+// New creates a new empty Index with options.
+func New(opts ...Option) (*Index, error) {
+	idx, err := applyOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	idx.reset()
+	return idx, nil
+}
+
+func (idx *Index) reset() {
+	idx.rng = rand.New(rand.NewSource(idx.seed)) //nolint:gosec
+	idx.levelMult = 1 / math.Log(float64(idx.m))
+	idx.dims = 0
+	idx.nodes = nil
+	idx.ids = make(map[string]uint32)
+	idx.deleted = 0
+	idx.entry = -1
+	idx.maxLevel = 0
+}
+
+// Len returns the number of vectors in the index.
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.ids)
+}
+
+// Dimensions returns the length of the vectors of the index, or 0 if it was
+// always empty.
+func (idx *Index) Dimensions() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.dims
+}
+
+// Add inserts the vector, replacing the vector with the same ID, if any.
+func (idx *Index) Add(id string, vector []float32) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.dims != 0 && len(vector) != idx.dims {
+		return fmt.Errorf("%w: got %d, want %d", ErrDimensionMismatch, len(vector), idx.dims)
+	}
+	idx.dims = len(vector)
+	idx.delete(id)
+	idx.insert(id, vector)
+	idx.compact()
+	return nil
+}
+
+// Delete removes the vectors with the IDs, and reports whether any was found.
+func (idx *Index) Delete(ids ...string) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	found := false
+	for _, id := range ids {
+		found = idx.delete(id) || found
+	}
+	idx.compact()
+	return found
+}
+
+// Search returns the k vectors nearest to the query, nearest first, among the
+// vectors whose ID passes the filter, if not nil. The search looks at no less
+// than efSearch candidates.
+func (idx *Index) Search(query []float32, k int, filter func(id string) bool) ([]Result, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if idx.entry < 0 || k <= 0 {
+		return []Result{}, nil
+	}
+	if len(query) != idx.dims {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrDimensionMismatch, len(query), idx.dims)
+	}
+
+	eps := idx.descend(query, 0)
+	accept := func(n *node) bool {
+		return !n.deleted && (filter == nil || filter(n.id))
+	}
+	found := idx.searchLayer(query, eps, max(idx.efSearch, k), 0, accept)
+	if len(found) > k {
+		found = found[:k]
+	}
+
+	results := make([]Result, 0, len(found))
+	for _, c := range found {
+		results = append(results, Result{ID: idx.nodes[c.id].id, Distance: c.dist})
+	}
+	return results, nil
+}
+
+// insert links a new node to the graph. The lock must be held.
+func (idx *Index) insert(id string, vector []float32) {
+	level := int(-math.Log(1-idx.rng.Float64()) * idx.levelMult)
+	n := &node{id: id, vector: vector, friends: make([][]uint32, level+1)}
+	slot := uint32(len(idx.nodes))
+	idx.nodes = append(idx.nodes, n)
+	idx.ids[id] = slot
+
+	if idx.entry < 0 {
+		idx.entry = int(slot)
+		idx.maxLevel = level
+		return
+	}
+
+	eps := idx.descend(vector, level)
+	for l := min(level, idx.maxLevel); l >= 0; l-- {
+		found := idx.searchLayer(vector, eps, idx.efConstruction, l, nil)
+		neighbours := idx.selectNeighbours(found, idx.m)
+		n.friends[l] = make([]uint32, 0, len(neighbours))
+		for _, c := range neighbours {
+			n.friends[l] = append(n.friends[l], c.id)
+			idx.link(c.id, slot, l)
+		}
+		eps = found
+	}
+
+	if level > idx.maxLevel {
+		idx.entry = int(slot)
+		idx.maxLevel = level
+	}
+}
+
+// delete marks the node of the ID as deleted. The lock must be held.
+func (idx *Index) delete(id string) bool {
+	slot, ok := idx.ids[id]
+	if !ok {
+		return false
+	}
+	delete(idx.ids, id)
+	idx.nodes[slot].deleted = true
+	idx.deleted++
+	return true
+}
+
+// compact builds the graph again without the deleted nodes once they
+// outnumber the others. The lock must be held.
+func (idx *Index) compact() {
+	if idx.deleted <= len(idx.ids) {
+		return
+	}
+	nodes := idx.nodes
+	dims := idx.dims
+	idx.reset()
+	idx.dims = dims
+	for _, n := range nodes {
+		if !n.deleted {
+			idx.insert(n.id, n.vector)
+		}
+	}
+}
+
+// descend returns the node nearest to the vector on the layer, going down
+// greedily from the entry point.
+func (idx *Index) descend(vector []float32, level int) []candidate {
+	entry := uint32(idx.entry) //nolint:gosec
+	eps := []candidate{{id: entry, dist: idx.distance(vector, idx.nodes[entry].vector)}}
+	for l := idx.maxLevel; l > level; l-- {
+		eps = idx.searchLayer(vector, eps, 1, l, nil)[:1]
+	}
+	return eps
+}
+
+// link adds a neighbour to the node on the layer, keeping its best
+// neighbours if it has too many.
+func (idx *Index) link(from, to uint32, level int) {
+	n := idx.nodes[from]
+	n.friends[level] = append(n.friends[level], to)
+	if len(n.friends[level]) <= idx.maxFriends(level) {
+		return
+	}
+
+	candidates := make([]candidate, 0, len(n.friends[level]))
+	for _, f := range n.friends[level] {
+		candidates = append(candidates, candidate{id: f, dist: idx.distance(n.vector, idx.nodes[f].vector)})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].dist < candidates[j].dist })
+	kept := idx.selectNeighbours(candidates, idx.maxFriends(level))
+	n.friends[level] = n.friends[level][:0]
+	for _, c := range kept {
+		n.friends[level] = append(n.friends[level], c.id)
+	}
+}
+
+func (idx *Index) maxFriends(level int) int {
+	if level == 0 {
+		return 2 * idx.m
+	}
+	return idx.m
+}
+
+// selectNeighbours returns at most m of the candidates, sorted by distance,
+// preferring the candidates nearer to the new node than to the selected ones
+// so that the neighbours point in different directions. Pruned candidates
+// fill the remaining places.
+func (idx *Index) selectNeighbours(candidates []candidate, m int) []candidate {
+	if len(candidates) <= m {
+		return candidates
+	}
+	selected := make([]candidate, 0, m)
+	var pruned []candidate
+	for _, c := range candidates {
+		if len(selected) >= m {
+			break
+		}
+		diverse := true
+		for _, s := range selected {
+			if idx.distance(idx.nodes[c.id].vector, idx.nodes[s.id].vector) < c.dist {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			selected = append(selected, c)
+		} else {
+			pruned = append(pruned, c)
+		}
+	}
+	for _, c := range pruned {
+		if len(selected) >= m {
+			break
+		}
+		selected = append(selected, c)
+	}
+	return selected
+}
+
+// searchLayer returns the ef nodes nearest to the vector on the layer found
+// from the entry points, nearest first. Only the nodes accepted by accept, if
+// not nil, are returned, but all the nodes are traversed.
+func (idx *Index) searchLayer(
+	vector []float32,
+	eps []candidate,
+	ef, level int,
+	accept func(*node) bool,
+) []candidate {
+	visited := make([]uint64, (len(idx.nodes)+63)/64) //nolint:gomnd
+	candidates := &minHeap{}
+	results := &maxHeap{}
+	for _, ep := range eps {
+		visited[ep.id/64] |= 1 << (ep.id % 64)
+		heap.Push(candidates, ep)
+		if accept == nil || accept(idx.nodes[ep.id]) {
+			heap.Push(results, ep)
+		}
+	}
+	for results.Len() > ef {
+		heap.Pop(results)
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate) //nolint:forcetypeassert
+		if results.Len() >= ef && c.dist > (*results)[0].dist {
+			break
+		}
+		for _, f := range idx.nodes[c.id].friends[level] {
+			if visited[f/64]&(1<<(f%64)) != 0 {
+				continue
+			}
+			visited[f/64] |= 1 << (f % 64)
+
+			n := idx.nodes[f]
+			d := idx.distance(vector, n.vector)
+			if results.Len() >= ef && d >= (*results)[0].dist {
+				continue
+			}
+			heap.Push(candidates, candidate{id: f, dist: d})
+			if accept == nil || accept(n) {
+				heap.Push(results, candidate{id: f, dist: d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	found := make([]candidate, results.Len())
+	for i := len(found) - 1; i >= 0; i-- {
+		found[i] = heap.Pop(results).(candidate) //nolint:forcetypeassert
+	}
+	return found
+}
+
+type candidate struct {
+	id   uint32
+	dist float32
+}
+
+// minHeap is a heap of candidates, the nearest first.
+type minHeap []candidate
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(candidate)) } //nolint:forcetypeassert
+func (h *minHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// maxHeap is a heap of candidates, the furthest first.
+type maxHeap []candidate
+
+func (h maxHeap) Len() int           { return len(h) }
+func (h maxHeap) Less(i, j int) bool { return h[i].dist > h[j].dist }
+func (h maxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x any)        { *h = append(*h, x.(candidate)) } //nolint:forcetypeassert
+func (h *maxHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
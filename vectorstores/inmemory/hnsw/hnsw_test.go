@@ -0,0 +1,181 @@
+package hnsw_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores/inmemory/hnsw"
+)
+
+func randomVectors(rng *rand.Rand, n, dims int) [][]float32 {
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dims)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()*2 - 1
+		}
+	}
+	return vectors
+}
+
+func newIndex(t testing.TB, vectors [][]float32, opts ...hnsw.Option) *hnsw.Index {
+	t.Helper()
+	idx, err := hnsw.New(opts...)
+	require.NoError(t, err)
+	for i, vector := range vectors {
+		require.NoError(t, idx.Add(vectorID(i), vector))
+	}
+	return idx
+}
+
+func vectorID(i int) string {
+	return fmt.Sprintf("v%d", i)
+}
+
+// exactSearch returns the IDs of the k vectors nearest to the query.
+func exactSearch(vectors [][]float32, query []float32, k int, distance hnsw.Distance) []string {
+	ids := make([]int, len(vectors))
+	distances := make([]float32, len(vectors))
+	for i := range ids {
+		ids[i] = i
+		distances[i] = distance(query, vectors[i])
+	}
+	sort.Slice(ids, func(i, j int) bool { return distances[ids[i]] < distances[ids[j]] })
+	result := make([]string, 0, k)
+	for _, id := range ids[:k] {
+		result = append(result, vectorID(id))
+	}
+	return result
+}
+
+// recall returns the share of the exact nearest neighbours found by the index.
+func recall(t testing.TB, idx *hnsw.Index, vectors, queries [][]float32, k int) float64 {
+	t.Helper()
+	found := 0
+	for _, query := range queries {
+		want := make(map[string]bool, k)
+		for _, id := range exactSearch(vectors, query, k, hnsw.CosineDistance) {
+			want[id] = true
+		}
+		results, err := idx.Search(query, k, nil)
+		require.NoError(t, err)
+		for _, r := range results {
+			if want[r.ID] {
+				found++
+			}
+		}
+	}
+	return float64(found) / float64(len(queries)*k)
+}
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+	idx := newIndex(t, [][]float32{{1, 0}, {0.9, 0.1}, {0, 1}, {-1, 0}}, hnsw.WithDistance(hnsw.EuclideanDistance))
+	assert.Equal(t, 4, idx.Len())
+	assert.Equal(t, 2, idx.Dimensions())
+
+	results, err := idx.Search([]float32{1, 0}, 2, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "v0", results[0].ID)
+	assert.InDelta(t, 0, results[0].Distance, 1e-6)
+	assert.Equal(t, "v1", results[1].ID)
+
+	results, err = idx.Search([]float32{1, 0}, 10, func(id string) bool { return id != "v0" })
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, "v1", results[0].ID)
+
+	require.NoError(t, idx.Add("v0", []float32{0, -1}))
+	assert.Equal(t, 4, idx.Len())
+	results, err = idx.Search([]float32{0, -1}, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "v0", results[0].ID)
+
+	assert.True(t, idx.Delete("v0", "v9"))
+	assert.False(t, idx.Delete("v0"))
+	results, err = idx.Search([]float32{0, -1}, 10, nil)
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+
+	require.ErrorIs(t, idx.Add("v5", []float32{1, 2, 3}), hnsw.ErrDimensionMismatch)
+	_, err = idx.Search([]float32{1}, 1, nil)
+	require.ErrorIs(t, err, hnsw.ErrDimensionMismatch)
+
+	_, err = hnsw.New(hnsw.WithM(1))
+	require.ErrorIs(t, err, hnsw.ErrInvalidOptions)
+}
+
+func TestIndexRecall(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	vectors := randomVectors(rng, 2000, 32)
+	idx := newIndex(t, vectors)
+
+	assert.Greater(t, recall(t, idx, vectors, randomVectors(rng, 50, 32), 10), 0.95)
+
+	// Deleting most vectors rebuilds the graph, which must still find the
+	// remaining ones.
+	for i := 0; i < 1500; i++ {
+		idx.Delete(vectorID(i))
+	}
+	assert.Equal(t, 500, idx.Len())
+	for i := 1500; i < 2000; i += 50 {
+		results, err := idx.Search(vectors[i], 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, vectorID(i), results[0].ID)
+	}
+}
+
+func TestIndexSaveLoad(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(2))
+	vectors := randomVectors(rng, 300, 8)
+	idx := newIndex(t, vectors, hnsw.WithM(8))
+	idx.Delete("v3")
+
+	var buf bytes.Buffer
+	require.NoError(t, idx.Save(&buf))
+	loaded, err := hnsw.New()
+	require.NoError(t, err)
+	require.NoError(t, loaded.Load(&buf))
+	assert.Equal(t, idx.Len(), loaded.Len())
+
+	for _, query := range randomVectors(rng, 10, 8) {
+		want, err := idx.Search(query, 5, nil)
+		require.NoError(t, err)
+		got, err := loaded.Search(query, 5, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	require.Error(t, loaded.Load(bytes.NewReader([]byte("not an index"))))
+}
+
+func BenchmarkSearch(b *testing.B) {
+	const dims, k = 64, 10
+	rng := rand.New(rand.NewSource(3))
+	for _, n := range []int{1000, 10000} {
+		vectors := randomVectors(rng, n, dims)
+		queries := randomVectors(rng, 100, dims)
+		idx := newIndex(b, vectors)
+
+		b.Run(fmt.Sprintf("hnsw/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = idx.Search(queries[i%len(queries)], k, nil)
+			}
+			b.StopTimer()
+			b.ReportMetric(recall(b, idx, vectors, queries, k), "recall")
+		})
+		b.Run(fmt.Sprintf("exact/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				exactSearch(vectors, queries[i%len(queries)], k, hnsw.CosineDistance)
+			}
+		})
+	}
+}
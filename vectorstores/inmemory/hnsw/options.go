@@ -0,0 +1,87 @@
+package hnsw
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	_defaultM              = 16
+	_defaultEfConstruction = 200
+	_defaultEfSearch       = 64
+	_defaultSeed           = 42
+)
+
+// ErrInvalidOptions is returned when the options given are invalid.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Option is a function type that can be used to modify the index.
+type Option func(*Index)
+
+// WithM is an option for setting the number of neighbours of the nodes of the
+// graph, twice as many in the bottom layer. Higher values improve the recall
+// of high dimensional vectors at the cost of memory and insertion time.
+// Defaults to 16.
+func WithM(m int) Option {
+	return func(idx *Index) {
+		idx.m = m
+	}
+}
+
+// WithEfConstruction is an option for setting the number of candidate
+// neighbours considered when inserting a vector. Higher values build a better
+// graph, more slowly. Defaults to 200.
+func WithEfConstruction(ef int) Option {
+	return func(idx *Index) {
+		idx.efConstruction = ef
+	}
+}
+
+// WithEfSearch is an option for setting the number of candidates considered by
+// the searches, at least the number of results. Higher values improve recall
+// at the cost of speed. Defaults to 64.
+func WithEfSearch(ef int) Option {
+	return func(idx *Index) {
+		idx.efSearch = ef
+	}
+}
+
+// WithDistance is an option for setting the distance of the vectors. Defaults
+// to CosineDistance.
+func WithDistance(distance Distance) Option {
+	return func(idx *Index) {
+		idx.distance = distance
+	}
+}
+
+// WithSeed is an option for setting the seed of the random levels of the
+// nodes, so that the same inserts build the same graph.
+func WithSeed(seed int64) Option {
+	return func(idx *Index) {
+		idx.seed = seed
+	}
+}
+
+func applyOptions(opts ...Option) (*Index, error) {
+	idx := &Index{
+		m:              _defaultM,
+		efConstruction: _defaultEfConstruction,
+		efSearch:       _defaultEfSearch,
+		distance:       CosineDistance,
+		seed:           _defaultSeed,
+	}
+	for _, opt := range opts {
+		opt(idx)
+	}
+
+	if idx.m < 2 { //nolint:gomnd
+		return nil, fmt.Errorf("%w: M must be at least 2", ErrInvalidOptions)
+	}
+	if idx.efConstruction < 1 || idx.efSearch < 1 {
+		return nil, fmt.Errorf("%w: ef must be positive", ErrInvalidOptions)
+	}
+	if idx.distance == nil {
+		return nil, fmt.Errorf("%w: missing distance", ErrInvalidOptions)
+	}
+	return idx, nil
+}
@@ -0,0 +1,113 @@
+package hnsw
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+const _fileVersion = 1
+
+// file is the gob representation of an index.
+type file struct {
+	Version        int
+	M              int
+	EfConstruction int
+	Seed           int64
+	Dims           int
+	Entry          int
+	MaxLevel       int
+	Nodes          []fileNode
+}
+
+type fileNode struct {
+	ID      string
+	Vector  []float32
+	Friends [][]uint32
+	Deleted bool
+}
+
+// Save writes the graph with its vectors, so that it can be restored with
+// Load without inserting the vectors again.
+func (idx *Index) Save(w io.Writer) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	f := file{
+		Version:        _fileVersion,
+		M:              idx.m,
+		EfConstruction: idx.efConstruction,
+		Seed:           idx.seed,
+		Dims:           idx.dims,
+		Entry:          idx.entry,
+		MaxLevel:       idx.maxLevel,
+		Nodes:          make([]fileNode, 0, len(idx.nodes)),
+	}
+	for _, n := range idx.nodes {
+		f.Nodes = append(f.Nodes, fileNode{ID: n.id, Vector: n.vector, Friends: n.friends, Deleted: n.deleted})
+	}
+	return gob.NewEncoder(w).Encode(f)
+}
+
+// Load replaces the graph with the graph written by Save. The graph keeps the
+// M and efConstruction it was built with, but the distance and efSearch of
+// the index are used, so the distance must be the one of the saved index.
+func (idx *Index) Load(r io.Reader) error {
+	var f file
+	if err := gob.NewDecoder(r).Decode(&f); err != nil {
+		return fmt.Errorf("decode index: %w", err)
+	}
+	if f.Version != _fileVersion {
+		return fmt.Errorf("unsupported index version %d", f.Version)
+	}
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.m = f.M
+	idx.efConstruction = f.EfConstruction
+	idx.seed = f.Seed
+	idx.reset()
+	idx.dims = f.Dims
+	idx.entry = f.Entry
+	idx.maxLevel = f.MaxLevel
+	idx.nodes = make([]*node, 0, len(f.Nodes))
+	for i, fn := range f.Nodes {
+		idx.nodes = append(idx.nodes, &node{id: fn.ID, vector: fn.Vector, friends: fn.Friends, deleted: fn.Deleted})
+		if fn.Deleted {
+			idx.deleted++
+		} else {
+			idx.ids[fn.ID] = uint32(i) //nolint:gosec
+		}
+	}
+	return nil
+}
+
+// validate checks that the graph cannot make the searches panic.
+func (f file) validate() error {
+	if f.M < 2 || f.EfConstruction < 1 { //nolint:gomnd
+		return fmt.Errorf("%w: invalid index parameters", ErrInvalidOptions)
+	}
+	if f.Entry < -1 || f.Entry >= len(f.Nodes) || (f.Entry < 0) != (len(f.Nodes) == 0) {
+		return fmt.Errorf("invalid index entry point %d", f.Entry)
+	}
+	if f.Entry >= 0 && len(f.Nodes[f.Entry].Friends) != f.MaxLevel+1 {
+		return fmt.Errorf("invalid index entry point %d", f.Entry)
+	}
+	for i, n := range f.Nodes {
+		if len(n.Vector) != f.Dims || len(n.Friends) == 0 {
+			return fmt.Errorf("invalid index node %d", i)
+		}
+		for level, friends := range n.Friends {
+			for _, friend := range friends {
+				if int(friend) >= len(f.Nodes) || len(f.Nodes[friend].Friends) <= level {
+					return fmt.Errorf("invalid index node %d", i)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,561 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/tmc/langchaingo/vectorstores/inmemory/hnsw"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	ErrEmbedderWrongNumberVectors = errors.New("number of vectors from embedder does not match number of documents")
+	ErrInvalidScoreThreshold      = errors.New("score threshold must be between 0 and 1")
+	ErrInvalidFilters             = errors.New("invalid filters")
+)
+
+// Store is a vector store keeping the documents in memory. It is safe for
+// concurrent use.
+type Store struct {
+	embedder     embeddings.Embedder
+	metric       Metric
+	nameSpace    string
+	indexed      bool
+	indexOptions []hnsw.Option
+
+	mu sync.RWMutex
+	// nameSpaces are the entries of each name space by ID.
+	nameSpaces map[string]map[string]*entry
+	// indexes are the HNSW indexes of the name spaces, if indexed.
+	indexes map[string]*hnsw.Index
+}
+
+// entry is a document stored with its vector.
+type entry struct {
+	id       string
+	content  string
+	metadata map[string]any
+	vector   []float32
+	// norm is the euclidean norm of vector, for the cosine metric.
+	norm float64
+}
+
+var (
+	_ vectorstores.MutableVectorStore = &Store{}
+	_ vectorstores.VectorSearcher     = &Store{}
+)
+
+// New creates a new empty Store with options.
+func New(opts ...Option) (*Store, error) {
+	return applyClientOptions(opts...)
+}
+
+// AddDocuments embeds the documents and adds them to the name space, and
+// returns their IDs.
+func (s *Store) AddDocuments(
+	ctx context.Context,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	opts := s.getOptions(options...)
+	docs = s.deduplicate(ctx, opts, docs)
+	if len(docs) == 0 {
+		return []string{}, nil
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := s.getEmbedder(opts).EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	ids := make([]string, len(docs))
+	entries := make([]*entry, len(docs))
+	for i, doc := range docs {
+		ids[i] = uuid.New().String()
+		entries[i] = newEntry(ids[i], doc.PageContent, doc.Metadata, vectors[i])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.insert(s.getNameSpace(opts), entries...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Upsert embeds the documents and adds them to the name space with the IDs,
+// replacing the documents with the same IDs.
+func (s *Store) Upsert(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	opts := s.getOptions(options...)
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := s.getEmbedder(opts).EmbedDocuments(ctx, texts)
+	if err != nil {
+		return err
+	}
+	if len(vectors) != len(docs) {
+		return ErrEmbedderWrongNumberVectors
+	}
+
+	entries := make([]*entry, len(docs))
+	for i, doc := range docs {
+		entries[i] = newEntry(ids[i], doc.PageContent, doc.Metadata, vectors[i])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insert(s.getNameSpace(opts), entries...)
+}
+
+// GetByIDs returns the documents of the name space with the IDs.
+func (s *Store) GetByIDs(
+	_ context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := s.nameSpaces[s.getNameSpace(opts)]
+	docs := make(map[string]schema.Document, len(ids))
+	for _, id := range ids {
+		if e, ok := entries[id]; ok {
+			docs[id] = e.document(0)
+		}
+	}
+	return docs, nil
+}
+
+// Delete removes the documents of the name space with the IDs.
+func (s *Store) Delete(_ context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(s.getNameSpace(opts), ids)
+	return nil
+}
+
+// DeleteByFilter removes the documents of the name space matching the filter,
+// in the format of SimilaritySearch.
+func (s *Store) DeleteByFilter(_ context.Context, filter any, options ...vectorstores.Option) error {
+	if isEmptyFilter(filter) {
+		return vectorstores.ErrEmptyFilter
+	}
+	opts := s.getOptions(append(options, vectorstores.WithFilters(filter))...)
+	match, err := s.getFilter(opts)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nameSpace := s.getNameSpace(opts)
+	var ids []string
+	for id, e := range s.nameSpaces[nameSpace] {
+		if match(e.metadata) {
+			ids = append(ids, id)
+		}
+	}
+	s.remove(nameSpace, ids)
+	return nil
+}
+
+func isEmptyFilter(filter any) bool {
+	switch filter := filter.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(filter) == 0
+	case func(map[string]any) bool:
+		return filter == nil
+	default:
+		return false
+	}
+}
+
+// SimilaritySearch returns the documents of the name space most similar to
+// the query, best first, with their score. The filters can be a filter.Filter,
+// a map[string]any of metadata values the documents must have, or a
+// func(metadata map[string]any) bool selecting the documents.
+func (s *Store) SimilaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	_, scored, err := s.searchEntries(ctx, query, numDocuments, options...)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]schema.Document, 0, len(scored))
+	for _, se := range scored {
+		docs = append(docs, se.entry.document(se.score))
+	}
+	return docs, nil
+}
+
+// SimilaritySearchWithVectors is like SimilaritySearch, and also returns the
+// vectors of the query and of the documents.
+func (s *Store) SimilaritySearchWithVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	vector, scored, err := s.searchEntries(ctx, query, numDocuments, options...)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	res := vectorstores.VectorSearchResult{
+		QueryVector: vector,
+		Documents:   make([]schema.Document, 0, len(scored)),
+		Vectors:     make([][]float32, 0, len(scored)),
+	}
+	for _, se := range scored {
+		res.Documents = append(res.Documents, se.entry.document(se.score))
+		res.Vectors = append(res.Vectors, slices.Clone(se.entry.vector))
+	}
+	return res, nil
+}
+
+// searchEntries embeds the query, and returns its vector and the best entries
+// of the name space of the options, best first.
+func (s *Store) searchEntries(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]float32, []scoredEntry, error) {
+	opts := s.getOptions(options...)
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter, err := s.getFilter(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	vector, err := s.getEmbedder(opts).EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	nameSpace := s.getNameSpace(opts)
+	if idx, ok := s.indexes[nameSpace]; ok {
+		scored, err := s.searchIndex(idx, nameSpace, vector, numDocuments, scoreThreshold, filter)
+		return vector, scored, err
+	}
+	return vector, s.search(nameSpace, vector, numDocuments, scoreThreshold, filter), nil
+}
+
+// searchIndex returns the best entries of the name space found by its index.
+// The entries are scored like exhaustive searches.
+func (s *Store) searchIndex(
+	idx *hnsw.Index,
+	nameSpace string,
+	vector []float32,
+	numDocuments int,
+	scoreThreshold float32,
+	filter func(map[string]any) bool,
+) ([]scoredEntry, error) {
+	entries := s.nameSpaces[nameSpace]
+	if numDocuments < 0 {
+		numDocuments = len(entries)
+	}
+	results, err := idx.Search(vector, numDocuments, func(id string) bool {
+		e, ok := entries[id]
+		return ok && filter(e.metadata)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := newEntry("", "", nil, vector)
+	scored := make([]scoredEntry, 0, len(results))
+	for _, r := range results {
+		e := entries[r.ID]
+		if score := s.score(query, e); scoreThreshold <= 0 || score >= scoreThreshold {
+			scored = append(scored, scoredEntry{entry: e, score: score})
+		}
+	}
+	return topEntries(scored, numDocuments), nil
+}
+
+// search returns the best entries of the name space exhaustively.
+func (s *Store) search(
+	nameSpace string,
+	vector []float32,
+	numDocuments int,
+	scoreThreshold float32,
+	filter func(map[string]any) bool,
+) []scoredEntry {
+	query := newEntry("", "", nil, vector)
+	scored := make([]scoredEntry, 0)
+	for _, e := range s.nameSpaces[nameSpace] {
+		// Vectors of another embedder cannot be compared.
+		if len(e.vector) != len(vector) || !filter(e.metadata) {
+			continue
+		}
+		score := s.score(query, e)
+		if scoreThreshold > 0 && score < scoreThreshold {
+			continue
+		}
+		scored = append(scored, scoredEntry{entry: e, score: score})
+	}
+	return topEntries(scored, numDocuments)
+}
+
+type scoredEntry struct {
+	entry *entry
+	score float32
+}
+
+// topEntries returns the best n entries, best first. Ties are broken by ID so
+// that the results are stable.
+func topEntries(scored []scoredEntry, n int) []scoredEntry {
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].entry.id < scored[j].entry.id
+	})
+	if n >= 0 && len(scored) > n {
+		scored = scored[:n]
+	}
+	return scored
+}
+
+// score returns the similarity of the vectors of the query and the entry,
+// higher being more similar.
+func (s *Store) score(query, e *entry) float32 {
+	switch s.metric {
+	case DotProduct:
+		return float32(dot(query.vector, e.vector))
+	case Euclidean:
+		var sum float64
+		for i := range query.vector {
+			d := float64(query.vector[i]) - float64(e.vector[i])
+			sum += d * d
+		}
+		return float32(1 / (1 + math.Sqrt(sum)))
+	default:
+		if query.norm == 0 || e.norm == 0 {
+			return 0
+		}
+		return float32(dot(query.vector, e.vector) / (query.norm * e.norm))
+	}
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func newEntry(id, content string, metadata map[string]any, vector []float32) *entry {
+	return &entry{
+		id:       id,
+		content:  content,
+		metadata: copyMetadata(metadata),
+		vector:   vector,
+		norm:     math.Sqrt(dot(vector, vector)),
+	}
+}
+
+// document returns the document of the entry, whose metadata can be changed
+// without affecting the store.
+func (e *entry) document(score float32) schema.Document {
+	return schema.Document{PageContent: e.content, Metadata: copyMetadata(e.metadata), Score: score}
+}
+
+func copyMetadata(metadata map[string]any) map[string]any {
+	if metadata == nil {
+		return nil
+	}
+	copied := make(map[string]any, len(metadata))
+	for key, value := range metadata {
+		copied[key] = value
+	}
+	return copied
+}
+
+// insert adds the entries to the name space and its index. Nothing is added
+// if the vectors do not have the dimension of the index. The lock must be
+// held.
+func (s *Store) insert(nameSpace string, entries ...*entry) error {
+	idx, err := s.getIndex(nameSpace)
+	if err != nil {
+		return err
+	}
+	if idx != nil {
+		dims := idx.Dimensions()
+		for _, e := range entries {
+			if dims == 0 {
+				dims = len(e.vector)
+			}
+			if len(e.vector) != dims {
+				return fmt.Errorf("%w: got %d, want %d", hnsw.ErrDimensionMismatch, len(e.vector), dims)
+			}
+		}
+	}
+
+	ns, ok := s.nameSpaces[nameSpace]
+	if !ok {
+		ns = make(map[string]*entry, len(entries))
+		s.nameSpaces[nameSpace] = ns
+	}
+	for _, e := range entries {
+		ns[e.id] = e
+		if idx != nil {
+			if err := idx.Add(e.id, e.vector); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// remove removes the entries with the IDs from the name space and its index.
+// The lock must be held.
+func (s *Store) remove(nameSpace string, ids []string) {
+	ns := s.nameSpaces[nameSpace]
+	for _, id := range ids {
+		delete(ns, id)
+	}
+	if idx, ok := s.indexes[nameSpace]; ok {
+		idx.Delete(ids...)
+	}
+}
+
+// getIndex returns the index of the name space, created if needed, or nil if
+// the store is not indexed. The lock must be held.
+func (s *Store) getIndex(nameSpace string) (*hnsw.Index, error) {
+	if !s.indexed {
+		return nil, nil //nolint:nilnil
+	}
+	if idx, ok := s.indexes[nameSpace]; ok {
+		return idx, nil
+	}
+	idx, err := s.newIndex()
+	if err != nil {
+		return nil, err
+	}
+	s.indexes[nameSpace] = idx
+	return idx, nil
+}
+
+func (s *Store) newIndex() (*hnsw.Index, error) {
+	opts := append([]hnsw.Option{}, s.indexOptions...)
+	return hnsw.New(append(opts, hnsw.WithDistance(s.metric.distance()))...)
+}
+
+func (s *Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+func (s *Store) getNameSpace(opts vectorstores.Options) string {
+	if opts.NameSpace != "" {
+		return opts.NameSpace
+	}
+	return s.nameSpace
+}
+
+func (s *Store) getEmbedder(opts vectorstores.Options) embeddings.Embedder {
+	if opts.Embedder != nil {
+		return opts.Embedder
+	}
+	return s.embedder
+}
+
+// getScoreThreshold returns the minimum score of the documents, 0 meaning no
+// minimum, as cosines and dot products can be negative. Dot products are not
+// bounded, so any positive threshold is valid with DotProduct.
+func (s *Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
+	if opts.ScoreThreshold < 0 || (opts.ScoreThreshold > 1 && s.metric != DotProduct) {
+		return 0, ErrInvalidScoreThreshold
+	}
+	return opts.ScoreThreshold, nil
+}
+
+// getFilter returns the function selecting the documents matching the
+// filters.
+func (s *Store) getFilter(opts vectorstores.Options) (func(map[string]any) bool, error) {
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return func(map[string]any) bool { return true }, nil
+	case func(map[string]any) bool:
+		return filters, nil
+	case filter.Filter:
+		if err := filters.Validate(); err != nil {
+			return nil, err
+		}
+		return filters.Match, nil
+	case map[string]any:
+		conditions := make([]filter.Filter, 0, len(filters))
+		for key, want := range filters {
+			conditions = append(conditions, filter.Eq(key, want))
+		}
+		return filter.And(conditions...).Match, nil
+	default:
+		return nil, ErrInvalidFilters
+	}
+}
+
+func (s *Store) deduplicate(
+	ctx context.Context,
+	opts vectorstores.Options,
+	docs []schema.Document,
+) []schema.Document {
+	if opts.Deduplicater == nil {
+		return docs
+	}
+
+	filtered := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if !opts.Deduplicater(ctx, doc) {
+			filtered = append(filtered, doc)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,352 @@
+package inmemory_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/tmc/langchaingo/vectorstores/inmemory"
+	"github.com/tmc/langchaingo/vectorstores/inmemory/hnsw"
+)
+
+// testEmbedder embeds the texts with fixed vectors.
+type testEmbedder map[string][]float32
+
+func (e testEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vector, err := e.EmbedQuery(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+func (e testEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	vector, ok := e[text]
+	if !ok {
+		return nil, fmt.Errorf("no vector for %q", text)
+	}
+	return vector, nil
+}
+
+var fruits = testEmbedder{
+	"apple":  {1, 0, 0},
+	"pear":   {0.9, 0.1, 0},
+	"banana": {0.5, 0.5, 0},
+	"carrot": {0, 0, 1},
+	"red":    {1, 0.05, 0},
+	"orange": {0, 0, 2},
+}
+
+func newStore(t *testing.T, opts ...inmemory.Option) *inmemory.Store {
+	t.Helper()
+	store, err := inmemory.New(append([]inmemory.Option{inmemory.WithEmbedder(fruits)}, opts...)...)
+	require.NoError(t, err)
+	ids, err := store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "apple", Metadata: map[string]any{"kind": "fruit", "count": 3}},
+		{PageContent: "pear", Metadata: map[string]any{"kind": "fruit", "count": 1}},
+		{PageContent: "banana", Metadata: map[string]any{"kind": "fruit"}},
+		{PageContent: "carrot", Metadata: map[string]any{"kind": "vegetable"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 4)
+	return store
+}
+
+func contents(docs []schema.Document) []string {
+	result := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, doc.PageContent)
+	}
+	return result
+}
+
+func TestStoreSimilaritySearch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+
+	docs, err := store.SimilaritySearch(ctx, "red", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "pear"}, contents(docs))
+	assert.InDelta(t, 0.9988, docs[0].Score, 1e-4)
+	assert.Equal(t, map[string]any{"kind": "fruit", "count": 3}, docs[0].Metadata)
+
+	docs, err = store.SimilaritySearch(ctx, "red", 10, vectorstores.WithScoreThreshold(0.5))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "pear", "banana"}, contents(docs))
+
+	docs, err = store.SimilaritySearch(ctx, "red", 10,
+		vectorstores.WithFilters(map[string]any{"kind": "fruit", "count": 1.0}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pear"}, contents(docs))
+
+	docs, err = store.SimilaritySearch(ctx, "red", 10, vectorstores.WithFilters(func(metadata map[string]any) bool {
+		return metadata["kind"] == "vegetable"
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carrot"}, contents(docs))
+
+	docs, err = store.SimilaritySearch(ctx, "red", 10, vectorstores.WithFilters(
+		filter.Or(filter.Eq("kind", "vegetable"), filter.Gt("count", 2)),
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "carrot"}, contents(docs))
+
+	_, err = store.SimilaritySearch(ctx, "red", 10, vectorstores.WithFilters("kind = fruit"))
+	require.ErrorIs(t, err, inmemory.ErrInvalidFilters)
+	_, err = store.SimilaritySearch(ctx, "red", 10, vectorstores.WithFilters(filter.And()))
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	_, err = store.SimilaritySearch(ctx, "red", 10, vectorstores.WithScoreThreshold(1.5))
+	require.ErrorIs(t, err, inmemory.ErrInvalidScoreThreshold)
+}
+
+func TestStoreMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+
+	res, err := store.SimilaritySearchWithVectors(ctx, "red", 2)
+	require.NoError(t, err)
+	assert.Equal(t, fruits["red"], res.QueryVector)
+	assert.Equal(t, []string{"apple", "pear"}, contents(res.Documents))
+	assert.Equal(t, [][]float32{fruits["apple"], fruits["pear"]}, res.Vectors)
+
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, store, "red", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "banana"}, contents(docs))
+
+	docs, err = vectorstores.MaxMarginalRelevanceSearch(ctx, store, "red", 2,
+		vectorstores.WithFilters(map[string]any{"kind": "fruit"}), vectorstores.WithMMR(10, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "pear"}, contents(docs))
+}
+
+func TestStoreMetrics(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// "orange" is twice as long as "carrot", which matters to all the metrics
+	// but cosine.
+	docs, err := newStore(t).SimilaritySearch(ctx, "orange", 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, docs[0].Score, 1e-6)
+
+	docs, err = newStore(t, inmemory.WithMetric(inmemory.DotProduct)).SimilaritySearch(ctx, "orange", 1,
+		vectorstores.WithScoreThreshold(1.5))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carrot"}, contents(docs))
+	assert.InDelta(t, 2, docs[0].Score, 1e-6)
+
+	docs, err = newStore(t, inmemory.WithMetric(inmemory.Euclidean)).SimilaritySearch(ctx, "orange", 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, docs[0].Score, 1e-6)
+
+	_, err = inmemory.New(inmemory.WithEmbedder(fruits), inmemory.WithMetric("manhattan"))
+	require.ErrorIs(t, err, inmemory.ErrInvalidOptions)
+	_, err = inmemory.New()
+	require.ErrorIs(t, err, inmemory.ErrInvalidOptions)
+}
+
+func TestStoreNegativeScores(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	directions := testEmbedder{
+		"north": {0, 1, 0},
+		"east":  {1, 0, 0},
+		"south": {0, -1, 0},
+	}
+	for _, opts := range [][]inmemory.Option{
+		{inmemory.WithMetric(inmemory.Cosine)},
+		{inmemory.WithMetric(inmemory.DotProduct)},
+		{inmemory.WithMetric(inmemory.Cosine), inmemory.WithHNSW()},
+	} {
+		store, err := inmemory.New(append(opts, inmemory.WithEmbedder(directions))...)
+		require.NoError(t, err)
+		_, err = store.AddDocuments(ctx, []schema.Document{{PageContent: "east"}, {PageContent: "south"}})
+		require.NoError(t, err)
+
+		docs, err := store.SimilaritySearch(ctx, "north", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"east", "south"}, contents(docs))
+		assert.InDelta(t, -1, docs[1].Score, 1e-6)
+
+		docs, err = store.SimilaritySearch(ctx, "north", 2, vectorstores.WithScoreThreshold(0.5))
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	}
+}
+
+func TestStoreNameSpaces(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t, inmemory.WithNameSpace("food"))
+
+	_, err := store.AddDocuments(ctx, []schema.Document{{PageContent: "orange"}}, vectorstores.WithNameSpace("colors"))
+	require.NoError(t, err)
+
+	docs, err := store.SimilaritySearch(ctx, "orange", 10, vectorstores.WithNameSpace("colors"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orange"}, contents(docs))
+
+	docs, err = store.SimilaritySearch(ctx, "orange", 10, vectorstores.WithScoreThreshold(0.1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carrot"}, contents(docs))
+}
+
+func TestStoreDeduplicater(t *testing.T) {
+	t.Parallel()
+	store := newStore(t)
+
+	ids, err := store.AddDocuments(context.Background(),
+		[]schema.Document{{PageContent: "apple"}, {PageContent: "orange"}},
+		vectorstores.WithDeduplicater(func(_ context.Context, doc schema.Document) bool {
+			return doc.PageContent == "apple"
+		}))
+	require.NoError(t, err)
+	assert.Len(t, ids, 1)
+
+	docs, err := store.SimilaritySearch(context.Background(), "apple", 10, vectorstores.WithScoreThreshold(0.999))
+	require.NoError(t, err)
+	assert.Len(t, docs, 1)
+}
+
+func TestStoreSnapshot(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+	path := filepath.Join(t.TempDir(), "store.json")
+	require.NoError(t, store.SaveFile(path))
+
+	loaded, err := inmemory.New(inmemory.WithEmbedder(fruits))
+	require.NoError(t, err)
+	require.NoError(t, loaded.LoadFile(path))
+
+	want, err := store.SimilaritySearch(ctx, "red", 10)
+	require.NoError(t, err)
+	got, err := loaded.SimilaritySearch(ctx, "red", 10)
+	require.NoError(t, err)
+	assert.Equal(t, contents(want), contents(got))
+
+	// Numbers are float64 once loaded, but still match the filters.
+	docs, err := loaded.SimilaritySearch(ctx, "red", 10, vectorstores.WithFilters(map[string]any{"count": 3}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple"}, contents(docs))
+}
+
+func TestStoreHNSW(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	exact := newStore(t, inmemory.WithMetric(inmemory.Euclidean))
+	store := newStore(t, inmemory.WithHNSW(hnsw.WithM(4)), inmemory.WithMetric(inmemory.Euclidean))
+
+	for _, opts := range [][]vectorstores.Option{
+		nil,
+		{vectorstores.WithScoreThreshold(0.6)},
+		{vectorstores.WithFilters(map[string]any{"kind": "fruit"})},
+	} {
+		want, err := exact.SimilaritySearch(ctx, "red", 3, opts...)
+		require.NoError(t, err)
+		got, err := store.SimilaritySearch(ctx, "red", 3, opts...)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := store.AddDocuments(ctx, []schema.Document{{PageContent: "plum"}},
+		vectorstores.WithEmbedder(testEmbedder{"plum": {1, 0}}))
+	require.ErrorIs(t, err, hnsw.ErrDimensionMismatch)
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Save(&buf))
+	loaded, err := inmemory.New(inmemory.WithEmbedder(fruits), inmemory.WithHNSW(), inmemory.WithMetric(inmemory.Euclidean))
+	require.NoError(t, err)
+	require.NoError(t, loaded.Load(&buf))
+	docs, err := loaded.SimilaritySearch(ctx, "red", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "pear"}, contents(docs))
+
+	// Snapshots of stores without index are indexed when loaded.
+	buf.Reset()
+	require.NoError(t, exact.Save(&buf))
+	require.NoError(t, loaded.Load(&buf))
+	docs, err = loaded.SimilaritySearch(ctx, "orange", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carrot"}, contents(docs))
+}
+
+func TestStoreMutations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for name, opts := range map[string][]inmemory.Option{
+		"exhaustive": nil,
+		"hnsw":       {inmemory.WithHNSW()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			store := newStore(t, opts...)
+
+			require.NoError(t, store.Upsert(ctx, []string{"a", "b"}, []schema.Document{
+				{PageContent: "orange", Metadata: map[string]any{"kind": "color"}},
+				{PageContent: "red", Metadata: map[string]any{"kind": "color"}},
+			}))
+			require.NoError(t, store.Upsert(ctx, []string{"a"}, []schema.Document{
+				{PageContent: "banana", Metadata: map[string]any{"kind": "fruit"}},
+			}))
+			require.ErrorIs(t, store.Upsert(ctx, []string{"a"}, nil), vectorstores.ErrIDsMismatch)
+
+			docs, err := store.GetByIDs(ctx, []string{"a", "b", "c"})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]schema.Document{
+				"a": {PageContent: "banana", Metadata: map[string]any{"kind": "fruit"}},
+				"b": {PageContent: "red", Metadata: map[string]any{"kind": "color"}},
+			}, docs)
+
+			require.NoError(t, store.Delete(ctx, []string{"b", "c"}))
+			require.NoError(t, store.DeleteByFilter(ctx, map[string]any{"kind": "vegetable"}))
+			require.ErrorIs(t, store.DeleteByFilter(ctx, map[string]any{}), vectorstores.ErrEmptyFilter)
+
+			found, err := store.SimilaritySearch(ctx, "red", 10)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"apple", "pear", "banana", "banana"}, contents(found))
+		})
+	}
+}
+
+func TestStoreConcurrency(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newStore(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := store.AddDocuments(ctx, []schema.Document{{PageContent: "banana"}})
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := store.SimilaritySearch(ctx, "red", 3)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	docs, err := store.SimilaritySearch(ctx, "banana", 100, vectorstores.WithScoreThreshold(0.99))
+	require.NoError(t, err)
+	assert.Len(t, docs, 11)
+}
@@ -0,0 +1,103 @@
+package inmemory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/vectorstores/inmemory/hnsw"
+)
+
+// ErrInvalidOptions is returned when the options given are invalid.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Metric is the measure of the similarity of two vectors.
+type Metric string
+
+const (
+	// Cosine scores documents with the cosine of the angle between their
+	// vector and the vector of the query, between -1 and 1.
+	Cosine Metric = "cosine"
+	// DotProduct scores documents with the dot product of their vector and the
+	// vector of the query, which is the cosine for normalized vectors.
+	DotProduct Metric = "dot"
+	// Euclidean scores documents with 1 / (1 + d), d being the euclidean
+	// distance between their vector and the vector of the query, between 0
+	// and 1.
+	Euclidean Metric = "l2"
+)
+
+// Option is a function type that can be used to modify the store.
+type Option func(s *Store)
+
+// WithEmbedder is an option for setting the embedder to use. Must be set.
+func WithEmbedder(embedder embeddings.Embedder) Option {
+	return func(s *Store) {
+		s.embedder = embedder
+	}
+}
+
+// WithMetric is an option for setting the similarity metric of the searches.
+// Defaults to Cosine.
+func WithMetric(metric Metric) Option {
+	return func(s *Store) {
+		s.metric = metric
+	}
+}
+
+// WithNameSpace is an option for setting the name space of the documents
+// added and searched without the vectorstores.WithNameSpace option.
+func WithNameSpace(nameSpace string) Option {
+	return func(s *Store) {
+		s.nameSpace = nameSpace
+	}
+}
+
+// WithHNSW is an option for searching the documents with an HNSW index per
+// name space instead of exhaustively, which is much faster for large stores
+// but may miss some of the most similar documents. The distance of the index
+// is set from the metric of the store.
+func WithHNSW(opts ...hnsw.Option) Option {
+	return func(s *Store) {
+		s.indexed = true
+		s.indexOptions = opts
+	}
+}
+
+// distance returns the HNSW distance ranking the vectors like the metric.
+func (m Metric) distance() hnsw.Distance {
+	switch m {
+	case DotProduct:
+		return hnsw.DotProductDistance
+	case Euclidean:
+		return hnsw.EuclideanDistance
+	default:
+		return hnsw.CosineDistance
+	}
+}
+
+func applyClientOptions(opts ...Option) (*Store, error) {
+	s := &Store{
+		metric:     Cosine,
+		nameSpaces: make(map[string]map[string]*entry),
+		indexes:    make(map[string]*hnsw.Index),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.embedder == nil {
+		return nil, fmt.Errorf("%w: missing embedder", ErrInvalidOptions)
+	}
+	switch s.metric {
+	case Cosine, DotProduct, Euclidean:
+	default:
+		return nil, fmt.Errorf("%w: unknown metric %q", ErrInvalidOptions, s.metric)
+	}
+	if s.indexed {
+		if _, err := s.newIndex(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
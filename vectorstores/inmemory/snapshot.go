@@ -0,0 +1,157 @@
+package inmemory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tmc/langchaingo/vectorstores/inmemory/hnsw"
+)
+
+const _snapshotVersion = 1
+
+// snapshot is the JSON representation of the content of a store.
+type snapshot struct {
+	Version    int                           `json:"version"`
+	NameSpaces map[string][]snapshotDocument `json:"nameSpaces"`
+	// Indexes are the HNSW indexes of the name spaces, as saved by
+	// hnsw.Index.Save, so that they are not built again when loaded.
+	Indexes map[string][]byte `json:"indexes,omitempty"`
+}
+
+type snapshotDocument struct {
+	ID       string         `json:"id"`
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+	Vector   []float32      `json:"vector"`
+}
+
+// Save writes the documents of all the name spaces with their vectors as
+// JSON, so that they can be restored with Load without embedding them again.
+// The HNSW indexes, if any, are saved too.
+func (s *Store) Save(w io.Writer) error {
+	snap := snapshot{Version: _snapshotVersion, NameSpaces: make(map[string][]snapshotDocument)}
+
+	s.mu.RLock()
+	for nameSpace, idx := range s.indexes {
+		var buf bytes.Buffer
+		if err := idx.Save(&buf); err != nil {
+			s.mu.RUnlock()
+			return err
+		}
+		if snap.Indexes == nil {
+			snap.Indexes = make(map[string][]byte, len(s.indexes))
+		}
+		snap.Indexes[nameSpace] = buf.Bytes()
+	}
+	for nameSpace, entries := range s.nameSpaces {
+		docs := make([]snapshotDocument, 0, len(entries))
+		for _, e := range entries {
+			docs = append(docs, snapshotDocument{ID: e.id, Content: e.content, Metadata: e.metadata, Vector: e.vector})
+		}
+		sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+		snap.NameSpaces[nameSpace] = docs
+	}
+	// The entries are never modified in place, so they can be encoded once
+	// the lock is released.
+	s.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// Load replaces the content of the store with the documents written by Save.
+// If the store is indexed, the saved indexes are loaded, or built if the
+// snapshot has none. The metric of the store must then be the one of the
+// saved indexes.
+func (s *Store) Load(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+	if snap.Version != _snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+
+	nameSpaces := make(map[string]map[string]*entry, len(snap.NameSpaces))
+	for nameSpace, docs := range snap.NameSpaces {
+		entries := make(map[string]*entry, len(docs))
+		for _, doc := range docs {
+			entries[doc.ID] = newEntry(doc.ID, doc.Content, doc.Metadata, doc.Vector)
+		}
+		nameSpaces[nameSpace] = entries
+	}
+	indexes, err := s.loadIndexes(snap, nameSpaces)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nameSpaces = nameSpaces
+	s.indexes = indexes
+	return nil
+}
+
+// loadIndexes returns the indexes of the name spaces of the snapshot, if the
+// store is indexed.
+func (s *Store) loadIndexes(snap snapshot, nameSpaces map[string]map[string]*entry) (map[string]*hnsw.Index, error) {
+	indexes := make(map[string]*hnsw.Index)
+	if !s.indexed {
+		return indexes, nil
+	}
+
+	for nameSpace, docs := range snap.NameSpaces {
+		idx, err := s.newIndex()
+		if err != nil {
+			return nil, err
+		}
+		if data, ok := snap.Indexes[nameSpace]; ok {
+			if err := idx.Load(bytes.NewReader(data)); err != nil {
+				return nil, err
+			}
+			if idx.Len() != len(nameSpaces[nameSpace]) {
+				return nil, fmt.Errorf("index of name space %q does not match its documents", nameSpace)
+			}
+		} else {
+			for _, doc := range docs {
+				if err := idx.Add(doc.ID, doc.Vector); err != nil {
+					return nil, err
+				}
+			}
+		}
+		indexes[nameSpace] = idx
+	}
+	return indexes, nil
+}
+
+// SaveFile saves the store to a file with Save. The file is replaced
+// atomically, so that a crash while saving keeps the previous snapshot.
+func (s *Store) SaveFile(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := s.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadFile loads the store from a file written by SaveFile.
+func (s *Store) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.Load(f)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 )
 
 // Store is a wrapper around the milvus client.
@@ -39,12 +40,18 @@ type Store struct {
 }
 
 var (
-	_ vectorstores.VectorStore = Store{}
+	_ vectorstores.MutableVectorStore = Store{}
 
 	ErrEmbedderWrongNumberVectors = errors.New(
 		"number of vectors from embedder does not match number of documents",
 	)
 	ErrColumnNotFound = errors.New("invalid field")
+	ErrInvalidID      = errors.New("invalid id")
+	ErrInvalidFilters = errors.New("invalid filters")
+	// ErrUpsertRequiresExplicitIDs is returned by Upsert when Milvus generates
+	// the primary keys of the collection, as for the collections created by
+	// the store, since the documents could not keep their primary keys.
+	ErrUpsertRequiresExplicitIDs = errors.New("upsert requires a collection without auto generated primary keys")
 )
 
 // New creates an active client connection to the (specified, or default) collection in the Milvus server
@@ -190,10 +197,130 @@ func (s *Store) load(ctx context.Context) error {
 }
 
 // AddDocuments adds the text and metadata from the documents to the Milvus collection associated with 'Store'.
-// and returns the ids of the added documents.
+// and returns the primary keys of the added documents.
 func (s Store) AddDocuments(ctx context.Context, docs []schema.Document,
 	_ ...vectorstores.Option,
 ) ([]string, error) {
+	columns, err := s.getDocumentColumns(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.client.Insert(ctx, s.collectionName, s.partitionName, columns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return getColumnStrings(ids)
+}
+
+// Upsert adds the documents to the Milvus collection associated with 'Store' with the primary keys,
+// replacing the documents with the same primary keys. It fails with ErrUpsertRequiresExplicitIDs
+// when Milvus generates the primary keys of the collection, as it does for the collections created
+// by the store: create the collection beforehand to upsert documents.
+func (s Store) Upsert(ctx context.Context, ids []string, docs []schema.Document,
+	_ ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	primaryField, err := s.getPrimaryField(ctx)
+	if err != nil {
+		return err
+	}
+	if primaryField.AutoID {
+		return ErrUpsertRequiresExplicitIDs
+	}
+	idCol, err := newIDColumn(primaryField, ids)
+	if err != nil {
+		return err
+	}
+	columns, err := s.getDocumentColumns(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Upsert(ctx, s.collectionName, s.partitionName, append(columns, idCol)...)
+	return err
+}
+
+// GetByIDs returns the documents with the primary keys by primary key.
+func (s Store) GetByIDs(ctx context.Context, ids []string,
+	_ ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	if len(ids) == 0 {
+		return map[string]schema.Document{}, nil
+	}
+
+	primaryField, err := s.getPrimaryField(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idCol, err := newIDColumn(primaryField, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.QueryByPks(ctx, s.collectionName, s.getPartitions(), idCol,
+		[]string{primaryField.Name, s.textField, s.metaField},
+		client.WithSearchQueryConsistencyLevel(s.consistencyLevel),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resIDs, err := getColumnStrings(res.GetColumn(primaryField.Name))
+	if err != nil {
+		return nil, err
+	}
+	resDocs, err := s.convertColumnsToDocuments(res, len(resIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]schema.Document, len(resDocs))
+	for i, doc := range resDocs {
+		docs[resIDs[i]] = doc
+	}
+	return docs, nil
+}
+
+// Delete deletes the documents with the primary keys.
+func (s Store) Delete(ctx context.Context, ids []string, _ ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	primaryField, err := s.getPrimaryField(ctx)
+	if err != nil {
+		return err
+	}
+	idCol, err := newIDColumn(primaryField, ids)
+	if err != nil {
+		return err
+	}
+	return s.client.DeleteByPks(ctx, s.collectionName, s.partitionName, idCol)
+}
+
+// DeleteByFilter deletes the documents matching the filter, a Milvus boolean expression
+// (eg: pk in [1, 2]).
+func (s Store) DeleteByFilter(ctx context.Context, filter any, _ ...vectorstores.Option) error {
+	expr, err := s.getFilters(vectorstores.Options{Filters: filter})
+	if err != nil {
+		return err
+	}
+	if expr == "" {
+		return vectorstores.ErrEmptyFilter
+	}
+	return s.client.Delete(ctx, s.collectionName, s.partitionName, expr)
+}
+
+// getDocumentColumns embeds the documents and returns their text, metadata and vector columns.
+func (s Store) getDocumentColumns(ctx context.Context, docs []schema.Document) ([]entity.Column, error) {
 	texts := make([]string, 0, len(docs))
 	metadatas := make([]string, 0, len(docs))
 	for _, doc := range docs {
@@ -219,12 +346,84 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document,
 	textCol := entity.NewColumnVarChar(s.textField, texts)
 	metaCol := entity.NewColumnVarChar(s.metaField, metadatas)
 	vectorCol := entity.NewColumnFloatVector(s.vectorField, len(vectors[0]), vectors)
-	_, err = s.client.Insert(ctx, s.collectionName, s.partitionName, vectorCol, metaCol, textCol)
-	if err != nil {
-		return nil, err
+	return []entity.Column{vectorCol, metaCol, textCol}, nil
+}
+
+// getPrimaryField returns the primary key field of the collection.
+func (s Store) getPrimaryField(ctx context.Context) (*entity.Field, error) {
+	collectionSchema := s.schema
+	if collectionSchema == nil {
+		collection, err := s.client.DescribeCollection(ctx, s.collectionName)
+		if err != nil {
+			return nil, err
+		}
+		collectionSchema = collection.Schema
 	}
+	for _, f := range collectionSchema.Fields {
+		if f.PrimaryKey {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: primary key missing", ErrColumnNotFound)
+}
+
+// newIDColumn returns the column of the primary key field with the ids.
+func newIDColumn(primaryField *entity.Field, ids []string) (entity.Column, error) {
+	switch primaryField.DataType { //nolint:exhaustive
+	case entity.FieldTypeInt64:
+		pks := make([]int64, 0, len(ids))
+		for _, id := range ids {
+			pk, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s is not an int64 primary key", ErrInvalidID, id)
+			}
+			pks = append(pks, pk)
+		}
+		return entity.NewColumnInt64(primaryField.Name, pks), nil
+	case entity.FieldTypeVarChar:
+		return entity.NewColumnVarChar(primaryField.Name, ids), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported primary key type %v", ErrColumnNotFound, primaryField.DataType)
+	}
+}
+
+// getColumnStrings returns the values of a primary key column as strings.
+func getColumnStrings(col entity.Column) ([]string, error) {
+	switch col := col.(type) {
+	case *entity.ColumnInt64:
+		ids := make([]string, 0, col.Len())
+		for _, pk := range col.Data() {
+			ids = append(ids, strconv.FormatInt(pk, 10))
+		}
+		return ids, nil
+	case *entity.ColumnVarChar:
+		return col.Data(), nil
+	default:
+		return nil, fmt.Errorf("%w: primary key column missing", ErrColumnNotFound)
+	}
+}
+
+// getFilters returns the filters, a Milvus boolean expression. The metadata of the documents is
+// stored as text, which Milvus cannot filter, so filter.Filter is not supported.
+func (s Store) getFilters(opts vectorstores.Options) (string, error) {
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return filters, nil
+	case filter.Filter:
+		return "", fmt.Errorf("%w: milvus stores the metadata as text", filter.ErrUnsupportedOperator)
+	default:
+		return "", fmt.Errorf("%w: %T is not a boolean expression", ErrInvalidFilters, opts.Filters)
+	}
+}
 
-	return nil, nil
+func (s Store) getPartitions() []string {
+	partitions := []string{}
+	if s.partitionName != "" {
+		partitions = append(partitions, s.partitionName)
+	}
+	return partitions
 }
 
 func (s *Store) getSearchFields() []string {
@@ -248,46 +447,65 @@ func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 
 func (s Store) convertResultToDocument(searchResult []client.SearchResult) ([]schema.Document, error) {
 	docs := []schema.Document{}
-	var err error
 
 	for _, res := range searchResult {
 		if res.ResultCount == 0 {
 			continue
 		}
-		textcol, ok := res.Fields.GetColumn(s.textField).(*entity.ColumnVarChar)
-		if !ok {
-			return nil, fmt.Errorf("%w: text column missing", ErrColumnNotFound)
+		resDocs, err := s.convertColumnsToDocuments(res.Fields, res.ResultCount)
+		if err != nil {
+			return nil, err
 		}
-		metacol, ok := res.Fields.GetColumn(s.metaField).(*entity.ColumnVarChar)
-		if !ok {
-			return nil, fmt.Errorf("%w: metadata column missing", ErrColumnNotFound)
+		for i := range resDocs {
+			resDocs[i].Score = res.Scores[i]
 		}
-		for i := 0; i < res.ResultCount; i++ {
-			doc := schema.Document{}
+		docs = append(docs, resDocs...)
+	}
+	return docs, nil
+}
 
-			doc.PageContent, err = textcol.ValueByIdx(i)
-			if err != nil {
-				return nil, err
-			}
-			metaStr, err := metacol.ValueByIdx(i)
-			if err != nil {
-				return nil, err
-			}
+func (s Store) convertColumnsToDocuments(fields client.ResultSet, count int) ([]schema.Document, error) {
+	textcol, ok := fields.GetColumn(s.textField).(*entity.ColumnVarChar)
+	if !ok {
+		return nil, fmt.Errorf("%w: text column missing", ErrColumnNotFound)
+	}
+	metacol, ok := fields.GetColumn(s.metaField).(*entity.ColumnVarChar)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata column missing", ErrColumnNotFound)
+	}
 
-			if err := json.Unmarshal([]byte(metaStr), &doc.Metadata); err != nil {
-				return nil, err
-			}
-			doc.Score = res.Scores[i]
-			docs = append(docs, doc)
+	docs := make([]schema.Document, 0, count)
+	for i := 0; i < count; i++ {
+		doc := schema.Document{}
+
+		var err error
+		doc.PageContent, err = textcol.ValueByIdx(i)
+		if err != nil {
+			return nil, err
+		}
+		metaStr, err := metacol.ValueByIdx(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(metaStr), &doc.Metadata); err != nil {
+			return nil, err
 		}
+		docs = append(docs, doc)
 	}
 	return docs, nil
 }
 
+// SimilaritySearch returns the documents most similar to the query. The filters are a Milvus
+// boolean expression.
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
+	expr, err := s.getFilters(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
@@ -299,18 +517,14 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	vectors := []entity.Vector{
 		entity.FloatVector(vector),
 	}
-	partitions := []string{}
-	if s.partitionName != "" {
-		partitions = append(partitions, s.partitionName)
-	}
 	sp := s.searchParameters
 	if opts.ScoreThreshold > 0 {
 		sp.AddRadius(float64(opts.ScoreThreshold))
 	}
 
 	searchResult, err := s.client.Search(ctx, s.collectionName,
-		partitions,
-		"",
+		s.getPartitions(),
+		expr,
 		s.getSearchFields(),
 		vectors,
 		s.vectorField,
@@ -108,3 +108,39 @@ func TestMilvusConnection(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, euRes, 10)
 }
+
+func TestMilvusMutations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	storer, err := getNewStore(t, WithDropOld(), WithCollectionName("LangChainGoMutations"))
+	require.NoError(t, err)
+
+	ids, err := storer.AddDocuments(ctx, []schema.Document{
+		{PageContent: "Tokyo", Metadata: map[string]any{"country": "japan"}},
+		{PageContent: "Paris", Metadata: map[string]any{"country": "france"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	docs, err := storer.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "Tokyo", docs[ids[0]].PageContent)
+	require.Equal(t, "france", docs[ids[1]].Metadata["country"])
+
+	require.NoError(t, storer.Delete(ctx, ids[:1]))
+	docs, err = storer.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	require.NoError(t, storer.DeleteByFilter(ctx, "pk in ["+ids[1]+"]"))
+	docs, err = storer.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+
+	require.ErrorIs(t, storer.DeleteByFilter(ctx, ""), vectorstores.ErrEmptyFilter)
+	require.ErrorIs(t, storer.Upsert(ctx, ids, nil), vectorstores.ErrIDsMismatch)
+	// the collection created by the store has auto generated primary keys.
+	require.ErrorIs(t, storer.Upsert(ctx, ids[:1], []schema.Document{{PageContent: "Tokyo"}}),
+		ErrUpsertRequiresExplicitIDs)
+}
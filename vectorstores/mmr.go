@@ -0,0 +1,210 @@
+package vectorstores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	defaultMMRFetchK = 20
+	defaultMMRLambda = 0.5
+)
+
+var (
+	// ErrInvalidMMROptions is returned when the options of a maximal marginal
+	// relevance search are invalid.
+	ErrInvalidMMROptions = errors.New("invalid maximal marginal relevance options")
+	// ErrMissingEmbedder is returned by MaxMarginalRelevanceSearch when the
+	// store does not return the vectors of the documents and no embedder is
+	// given with WithEmbedder.
+	ErrMissingEmbedder = errors.New("missing embedder")
+)
+
+// MMROptions are the options of a maximal marginal relevance search.
+type MMROptions struct {
+	// FetchK is the number of most similar documents to select the documents
+	// from. It is raised to the number of documents if lower.
+	FetchK int
+	// Lambda is between 0 and 1, and weights the similarity of the documents
+	// to the query against their dissimilarity to the documents already
+	// selected: 1 selects the most similar documents, 0 the most diverse ones.
+	Lambda float32
+}
+
+// WithMMR returns an Option for searching with MaxMarginalRelevanceSearch in
+// retrievers, and setting its options.
+func WithMMR(fetchK int, lambda float32) Option {
+	return func(o *Options) {
+		o.MMR = &MMROptions{FetchK: fetchK, Lambda: lambda}
+	}
+}
+
+// VectorSearchResult is the result of a similarity search returning the
+// vectors of the query and of the documents.
+type VectorSearchResult struct {
+	QueryVector []float32
+	Documents   []schema.Document
+	// Vectors are the stored vectors of the documents, in the same order.
+	Vectors [][]float32
+}
+
+// VectorSearcher is the interface of the vector stores whose similarity search
+// can return the stored vectors of the documents, which
+// MaxMarginalRelevanceSearch uses rather than embedding the documents again.
+type VectorSearcher interface {
+	SimilaritySearchWithVectors(ctx context.Context, query string, numDocuments int, options ...Option) (VectorSearchResult, error) //nolint:lll
+}
+
+// MaxMarginalRelevanceSearch returns documents similar to the query but
+// diverse, selected among the most similar documents by maximal marginal
+// relevance, see WithMMR. The vectors of the documents are those of the store
+// if it implements VectorSearcher, or else the documents are embedded again
+// with the embedder given with WithEmbedder.
+func MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	store VectorStore,
+	query string,
+	numDocuments int,
+	options ...Option,
+) ([]schema.Document, error) {
+	opts := Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	mmr := MMROptions{FetchK: defaultMMRFetchK, Lambda: defaultMMRLambda}
+	if opts.MMR != nil {
+		mmr = *opts.MMR
+	}
+	if mmr.FetchK <= 0 || mmr.Lambda < 0 || mmr.Lambda > 1 {
+		return nil, fmt.Errorf("%w: fetch k %d, lambda %v", ErrInvalidMMROptions, mmr.FetchK, mmr.Lambda)
+	}
+	fetchK := max(mmr.FetchK, numDocuments)
+
+	var res VectorSearchResult
+	var err error
+	if searcher, ok := store.(VectorSearcher); ok {
+		res, err = searcher.SimilaritySearchWithVectors(ctx, query, fetchK, options...)
+	} else {
+		res, err = embedSimilaritySearch(ctx, store, query, fetchK, opts.Embedder, options...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	selected := MaxMarginalRelevance(res.QueryVector, res.Vectors, numDocuments, mmr.Lambda)
+	docs := make([]schema.Document, 0, len(selected))
+	for _, i := range selected {
+		docs = append(docs, res.Documents[i])
+	}
+	return docs, nil
+}
+
+// embedSimilaritySearch searches the store, and embeds the query and the
+// documents found with the embedder.
+func embedSimilaritySearch(
+	ctx context.Context,
+	store VectorStore,
+	query string,
+	numDocuments int,
+	embedder embeddings.Embedder,
+	options ...Option,
+) (VectorSearchResult, error) {
+	if embedder == nil {
+		return VectorSearchResult{}, ErrMissingEmbedder
+	}
+
+	docs, err := store.SimilaritySearch(ctx, query, numDocuments, options...)
+	if err != nil {
+		return VectorSearchResult{}, err
+	}
+	queryVector, err := embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return VectorSearchResult{}, err
+	}
+	if len(docs) == 0 {
+		return VectorSearchResult{QueryVector: queryVector}, nil
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return VectorSearchResult{}, err
+	}
+	if len(vectors) != len(docs) {
+		return VectorSearchResult{}, fmt.Errorf("embedded %d documents into %d vectors", len(docs), len(vectors))
+	}
+	return VectorSearchResult{QueryVector: queryVector, Documents: docs, Vectors: vectors}, nil
+}
+
+// MaxMarginalRelevance returns the indexes of at most k vectors selected by
+// maximal marginal relevance: one after the other, the vector maximizing
+// lambda times its cosine similarity to the query minus 1-lambda times its
+// highest cosine similarity to the vectors already selected.
+func MaxMarginalRelevance(query []float32, vectors [][]float32, k int, lambda float32) []int {
+	k = min(k, len(vectors))
+	if k <= 0 {
+		return nil
+	}
+
+	querySimilarities := make([]float32, len(vectors))
+	for i, v := range vectors {
+		querySimilarities[i] = cosineSimilarity(query, v)
+	}
+	// redundancies are the highest similarities of the vectors to the
+	// vectors already selected.
+	redundancies := make([]float32, len(vectors))
+	for i := range redundancies {
+		redundancies[i] = float32(math.Inf(-1))
+	}
+	isSelected := make([]bool, len(vectors))
+
+	selected := make([]int, 0, k)
+	for len(selected) < k {
+		best, bestScore := -1, float32(math.Inf(-1))
+		for i := range vectors {
+			if isSelected[i] {
+				continue
+			}
+			score := querySimilarities[i]
+			if len(selected) > 0 {
+				score = lambda*querySimilarities[i] - (1-lambda)*redundancies[i]
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		selected = append(selected, best)
+		isSelected[best] = true
+		for i, v := range vectors {
+			if !isSelected[i] {
+				redundancies[i] = max(redundancies[i], cosineSimilarity(vectors[best], v))
+			}
+		}
+	}
+	return selected
+}
+
+func cosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
@@ -0,0 +1,144 @@
+package vectorstores_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// vectors are two near-duplicate documents about apples, and one about pears
+// slightly less similar to the query.
+var vectors = map[string][]float32{ //nolint:gochecknoglobals
+	"fruit":        {1, 0, 0},
+	"apple":        {1, 1, 0},
+	"apple, again": {1, 1.05, 0},
+	"pear":         {1, 0, 1.1},
+}
+
+type testEmbedder struct{}
+
+func (testEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	result := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		result = append(result, vectors[text])
+	}
+	return result, nil
+}
+
+func (testEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return vectors[text], nil
+}
+
+// testStore returns the documents in the order of its contents.
+type testStore struct {
+	contents   []string
+	numFetched int
+}
+
+func (s *testStore) AddDocuments(context.Context, []schema.Document, ...vectorstores.Option) ([]string, error) {
+	return nil, nil
+}
+
+func (s *testStore) SimilaritySearch(
+	_ context.Context,
+	_ string,
+	numDocuments int,
+	_ ...vectorstores.Option,
+) ([]schema.Document, error) {
+	s.numFetched = numDocuments
+	docs := []schema.Document{}
+	for _, content := range s.contents[:min(numDocuments, len(s.contents))] {
+		docs = append(docs, schema.Document{PageContent: content})
+	}
+	return docs, nil
+}
+
+// testVectorStore returns the vectors of the documents with a query vector
+// closer to pears than the one of the embedder.
+type testVectorStore struct {
+	testStore
+}
+
+func (s *testVectorStore) SimilaritySearchWithVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	docs, err := s.SimilaritySearch(ctx, query, numDocuments, options...)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	res := vectorstores.VectorSearchResult{QueryVector: []float32{0, 0, 1}, Documents: docs}
+	for _, doc := range docs {
+		res.Vectors = append(res.Vectors, vectors[doc.PageContent])
+	}
+	return res, nil
+}
+
+func TestMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+
+	query := vectors["fruit"]
+	candidates := [][]float32{vectors["apple"], vectors["apple, again"], vectors["pear"]}
+	assert.Equal(t, []int{0, 1}, vectorstores.MaxMarginalRelevance(query, candidates, 2, 1))
+	assert.Equal(t, []int{0, 2}, vectorstores.MaxMarginalRelevance(query, candidates, 2, 0.5))
+	assert.Equal(t, []int{0, 2, 1}, vectorstores.MaxMarginalRelevance(query, candidates, 5, 0.5))
+	assert.Empty(t, vectorstores.MaxMarginalRelevance(query, nil, 2, 0.5))
+}
+
+func TestMaxMarginalRelevanceSearch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	store := &testStore{contents: []string{"apple", "apple, again", "pear"}}
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, store, "fruit", 2,
+		vectorstores.WithEmbedder(testEmbedder{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "pear"}, contents(docs))
+	assert.Equal(t, 20, store.numFetched)
+
+	docs, err = vectorstores.MaxMarginalRelevanceSearch(ctx, store, "fruit", 2,
+		vectorstores.WithEmbedder(testEmbedder{}), vectorstores.WithMMR(2, 0.5))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "apple, again"}, contents(docs))
+
+	_, err = vectorstores.MaxMarginalRelevanceSearch(ctx, store, "fruit", 2)
+	require.ErrorIs(t, err, vectorstores.ErrMissingEmbedder)
+	_, err = vectorstores.MaxMarginalRelevanceSearch(ctx, store, "fruit", 2, vectorstores.WithMMR(10, 2))
+	require.ErrorIs(t, err, vectorstores.ErrInvalidMMROptions)
+
+	// the vectors of the store, where pear is the most similar to the query
+	vectorStore := &testVectorStore{testStore{contents: []string{"apple", "apple, again", "pear"}}}
+	docs, err = vectorstores.MaxMarginalRelevanceSearch(ctx, vectorStore, "fruit", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pear", "apple, again"}, contents(docs))
+}
+
+func TestRetrieverWithMMR(t *testing.T) {
+	t.Parallel()
+
+	store := &testStore{contents: []string{"apple", "apple, again", "pear"}}
+	retriever := vectorstores.ToRetriever(store, 2,
+		vectorstores.WithEmbedder(testEmbedder{}), vectorstores.WithMMR(3, 0.5))
+	docs, err := retriever.GetRelevantDocuments(context.Background(), "fruit")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "pear"}, contents(docs))
+	assert.Equal(t, 3, store.numFetched)
+
+	docs, err = vectorstores.ToRetriever(store, 2).GetRelevantDocuments(context.Background(), "fruit")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "apple, again"}, contents(docs))
+}
+
+func contents(docs []schema.Document) []string {
+	result := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, doc.PageContent)
+	}
+	return result
+}
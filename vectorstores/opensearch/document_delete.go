@@ -0,0 +1,37 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+)
+
+func (s *Store) documentsDeleteByQuery(
+	ctx context.Context,
+	indexName string,
+	query map[string]interface{},
+) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]interface{}{"query": query}); err != nil {
+		return fmt.Errorf("error encoding delete query to json buffer %w", err)
+	}
+
+	refresh := true
+	deleteByQuery := opensearchapi.DeleteByQueryRequest{
+		Index:   []string{indexName},
+		Body:    buf,
+		Refresh: &refresh,
+	}
+	res, err := deleteByQuery.Do(ctx, s.client)
+	if err != nil {
+		return fmt.Errorf("deleteByQuery.Do err: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("%w: %s", ErrDeletingDocuments, res.String())
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func (s *Store) documentsGet(
+	ctx context.Context,
+	indexName string,
+	ids []string,
+) (map[string]schema.Document, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]interface{}{"ids": ids}); err != nil {
+		return nil, fmt.Errorf("error encoding mget ids to json buffer %w", err)
+	}
+
+	mget := opensearchapi.MgetRequest{
+		Index: indexName,
+		Body:  buf,
+	}
+	res, err := mget.Do(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("mget.Do err: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("%w: %s", ErrGettingDocuments, res.String())
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mget response body: %w", err)
+	}
+	results := mgetResults{}
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("error unmarshalling mget response body: %w %s", err, body)
+	}
+
+	docs := make(map[string]schema.Document, len(results.Docs))
+	for _, doc := range results.Docs {
+		if !doc.Found {
+			continue
+		}
+		docs[doc.ID] = schema.Document{
+			PageContent: doc.Source.FieldsContent,
+			Metadata:    doc.Source.FieldsMetadata,
+		}
+	}
+	return docs, nil
+}
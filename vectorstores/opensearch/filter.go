@@ -0,0 +1,56 @@
+package opensearch
+
+import (
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// createQuery returns the opensearch query of the filter on the metadata of
+// the documents. Strings are matched against the keyword fields the dynamic
+// mapping adds to the text fields.
+func createQuery(f filter.Filter) map[string]interface{} {
+	field := "metadata." + f.Key
+	switch f.Op {
+	case filter.OpEq:
+		if _, ok := f.Value.(string); ok {
+			field += ".keyword"
+		}
+		return map[string]interface{}{"term": map[string]interface{}{field: f.Value}}
+	case filter.OpNe:
+		return boolQuery("must_not", []interface{}{createQuery(filter.Eq(f.Key, f.Value))})
+	case filter.OpIn:
+		queries := make([]interface{}, 0, len(f.Values))
+		for _, v := range f.Values {
+			queries = append(queries, createQuery(filter.Eq(f.Key, v)))
+		}
+		return boolQuery("should", queries)
+	case filter.OpRange:
+		bounds := map[string]interface{}{}
+		for name, bound := range map[string]any{
+			"gt": f.Bounds.Gt, "gte": f.Bounds.Gte, "lt": f.Bounds.Lt, "lte": f.Bounds.Lte,
+		} {
+			if bound != nil {
+				bounds[name] = bound
+			}
+		}
+		return map[string]interface{}{"range": map[string]interface{}{field: bounds}}
+	case filter.OpExists:
+		return map[string]interface{}{"exists": map[string]interface{}{"field": field}}
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		queries := make([]interface{}, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			queries = append(queries, createQuery(operand))
+		}
+		occur := map[filter.Op]string{filter.OpAnd: "filter", filter.OpOr: "should", filter.OpNot: "must_not"}[f.Op]
+		return boolQuery(occur, queries)
+	default:
+		return nil
+	}
+}
+
+func boolQuery(occur string, queries []interface{}) map[string]interface{} {
+	query := map[string]interface{}{occur: queries}
+	if occur == "should" {
+		query["minimum_should_match"] = 1
+	}
+	return map[string]interface{}{"bool": query}
+}
@@ -0,0 +1,46 @@
+package opensearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestGetFilters(t *testing.T) {
+	t.Parallel()
+
+	s := Store{}
+	got, err := s.getFilters(vectorstores.Options{Filters: filter.And(
+		filter.In("source", "a.txt", "b.txt"),
+		filter.Ne("page", 1),
+		filter.Not(filter.Or(filter.Gte("score", 0.5), filter.Exists("draft"))),
+	)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"bool": map[string]interface{}{"filter": []interface{}{
+		map[string]interface{}{"bool": map[string]interface{}{
+			"should": []interface{}{
+				map[string]interface{}{"term": map[string]interface{}{"metadata.source.keyword": "a.txt"}},
+				map[string]interface{}{"term": map[string]interface{}{"metadata.source.keyword": "b.txt"}},
+			},
+			"minimum_should_match": 1,
+		}},
+		map[string]interface{}{"bool": map[string]interface{}{"must_not": []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"metadata.page": 1}},
+		}}},
+		map[string]interface{}{"bool": map[string]interface{}{"must_not": []interface{}{
+			map[string]interface{}{"bool": map[string]interface{}{
+				"should": []interface{}{
+					map[string]interface{}{"range": map[string]interface{}{"metadata.score": map[string]interface{}{"gte": 0.5}}},
+					map[string]interface{}{"exists": map[string]interface{}{"field": "metadata.draft"}},
+				},
+				"minimum_should_match": 1,
+			}},
+		}}},
+	}}}, got)
+
+	_, err = s.getFilters(vectorstores.Options{Filters: "source:a.txt"})
+	require.ErrorIs(t, err, ErrInvalidFilters)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 )
 
 // Store is a wrapper around the chromaGo API and client.
@@ -32,6 +33,14 @@ var (
 	ErrAssertingMetadata = errors.New(
 		"couldn't assert metadata to map",
 	)
+	// ErrIndexingDocument when opensearch fails to index a document.
+	ErrIndexingDocument = errors.New("error indexing document")
+	// ErrGettingDocuments when opensearch fails to get documents.
+	ErrGettingDocuments = errors.New("error getting documents")
+	// ErrDeletingDocuments when opensearch fails to delete documents.
+	ErrDeletingDocuments = errors.New("error deleting documents")
+	// ErrInvalidFilters when the filters are neither an opensearch query nor a filter.Filter.
+	ErrInvalidFilters = errors.New("invalid filters")
 )
 
 // New creates and returns a vectorstore object for Opensearch
@@ -48,7 +57,10 @@ func New(client *opensearchgo.Client, opts ...Option) (Store, error) {
 	return s, nil
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.MutableVectorStore = Store{}
+	_ vectorstores.VectorSearcher     = Store{}
+)
 
 // AddDocuments adds the text and metadata from the documents to the Chroma collection associated with 'Store'.
 // and returns the ids of the added documents.
@@ -57,72 +69,186 @@ func (s Store) AddDocuments(
 	docs []schema.Document,
 	options ...vectorstores.Option,
 ) ([]string, error) {
-	opts := s.getOptions(options...)
-	ids := []string{}
-	texts := []string{}
+	ids := make([]string, 0, len(docs))
+	for range docs {
+		ids = append(ids, uuid.NewString())
+	}
 
+	if err := s.indexDocuments(ctx, ids, docs, s.getOptions(options...).NameSpace); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Upsert adds the documents to the index of the namespace with the ids,
+// replacing the documents with the same ids.
+func (s Store) Upsert(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	return s.indexDocuments(ctx, ids, docs, s.getOptions(options...).NameSpace)
+}
+
+// GetByIDs returns the documents with the ids from the index of the namespace by id.
+func (s Store) GetByIDs(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	if len(ids) == 0 {
+		return map[string]schema.Document{}, nil
+	}
+
+	return s.documentsGet(ctx, s.getOptions(options...).NameSpace, ids)
+}
+
+// Delete deletes the documents with the ids from the index of the namespace.
+func (s Store) Delete(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := map[string]interface{}{
+		"ids": map[string]interface{}{
+			"values": ids,
+		},
+	}
+	return s.documentsDeleteByQuery(ctx, s.getOptions(options...).NameSpace, query)
+}
+
+// DeleteByFilter deletes the documents matching the filter, an opensearch query
+// (eg: {"term": {"metadata.source.keyword": "a.txt"}}) or a filter.Filter, from the index
+// of the namespace.
+func (s Store) DeleteByFilter(
+	ctx context.Context,
+	filter any,
+	options ...vectorstores.Option,
+) error {
+	query, err := s.getFilters(vectorstores.Options{Filters: filter})
+	if err != nil {
+		return err
+	}
+	if len(query) == 0 {
+		return vectorstores.ErrEmptyFilter
+	}
+
+	return s.documentsDeleteByQuery(ctx, s.getOptions(options...).NameSpace, query)
+}
+
+// indexDocuments embeds the documents and indexes them with the ids.
+func (s Store) indexDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	indexName string,
+) error {
+	texts := make([]string, 0, len(docs))
 	for _, doc := range docs {
 		texts = append(texts, doc.PageContent)
 	}
 
 	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
 	if err != nil {
-		return ids, err
+		return err
 	}
 
 	if len(vectors) != len(docs) {
-		return ids, ErrNumberOfVectorDoesNotMatch
+		return ErrNumberOfVectorDoesNotMatch
 	}
 
 	for i, doc := range docs {
-		id := uuid.NewString()
-		_, err := s.documentIndexing(ctx, id, opts.NameSpace, doc.PageContent, vectors[i], doc.Metadata)
+		res, err := s.documentIndexing(ctx, ids[i], indexName, doc.PageContent, vectors[i], doc.Metadata)
 		if err != nil {
-			return ids, err
+			return err
+		}
+		if res.IsError() {
+			return fmt.Errorf("%w: %s", ErrIndexingDocument, res.String())
 		}
-		ids = append(ids, id)
+		res.Body.Close()
 	}
 
-	return ids, nil
+	return nil
 }
 
 // SimilaritySearch creates a vector embedding from the query using the embedder
-// and queries to find the most similar documents.
+// and queries to find the most similar documents. The filters, an opensearch query
+// or a filter.Filter, are applied to the nearest neighbors found.
 func (s Store) SimilaritySearch(
 	ctx context.Context,
 	query string,
 	numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	res, err := s.SimilaritySearchWithVectors(ctx, query, numDocuments, options...)
+	return res.Documents, err
+}
+
+// SimilaritySearchWithVectors is like SimilaritySearch, and also returns the
+// vectors of the query and of the documents.
+func (s Store) SimilaritySearchWithVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
 	opts := s.getOptions(options...)
 
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
 	queryVector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
-	searchPayload := map[string]interface{}{
-		"size": numDocuments,
-		"query": map[string]interface{}{
-			"knn": map[string]interface{}{
-				"contentVector": map[string]interface{}{
-					"vector": queryVector,
-					"k":      numDocuments,
-				},
+	knnQuery := map[string]interface{}{
+		"knn": map[string]interface{}{
+			"contentVector": map[string]interface{}{
+				"vector": queryVector,
+				"k":      numDocuments,
 			},
 		},
 	}
+	if len(filters) > 0 {
+		knnQuery = map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   []interface{}{knnQuery},
+				"filter": []interface{}{filters},
+			},
+		}
+	}
+
+	searchPayload := map[string]interface{}{
+		"size":  numDocuments,
+		"query": knnQuery,
+	}
 
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(searchPayload); err != nil {
-		return nil, fmt.Errorf("error encoding index schema to json buffer %w", err)
+		return vectorstores.VectorSearchResult{}, fmt.Errorf("error encoding index schema to json buffer %w", err)
 	}
 
 	search := opensearchapi.SearchRequest{
 		Index: []string{opts.NameSpace},
 		Body:  buf,
 	}
-	output := []schema.Document{}
+	output := vectorstores.VectorSearchResult{QueryVector: queryVector, Documents: []schema.Document{}}
 	searchResponse, err := search.Do(ctx, s.client)
 	if err != nil {
 		return output, fmt.Errorf("search.Do err: %w", err)
@@ -142,12 +268,31 @@ func (s Store) SimilaritySearch(
 			continue
 		}
 
-		output = append(output, schema.Document{
+		output.Documents = append(output.Documents, schema.Document{
 			PageContent: hit.Source.FieldsContent,
 			Metadata:    hit.Source.FieldsMetadata,
 			Score:       hit.Score,
 		})
+		output.Vectors = append(output.Vectors, hit.Source.FieldsContentVector)
 	}
 
 	return output, nil
 }
+
+// getFilters returns the opensearch query of the filters, an opensearch query or
+// a filter.Filter.
+func (s Store) getFilters(opts vectorstores.Options) (map[string]interface{}, error) {
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return filters, nil
+	case filter.Filter:
+		if err := filters.Validate(); err != nil {
+			return nil, err
+		}
+		return createQuery(filters), nil
+	default:
+		return nil, fmt.Errorf("%w: %T is not an opensearch query", ErrInvalidFilters, opts.Filters)
+	}
+}
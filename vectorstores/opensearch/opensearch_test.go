@@ -285,3 +285,92 @@ func TestOpensearchAsRetrieverWithScoreThreshold(t *testing.T) {
 	require.Contains(t, result, "black", "expected black in result")
 	require.Contains(t, result, "beige", "expected beige in result")
 }
+
+func TestOpensearchStoreMutations(t *testing.T) {
+	t.Parallel()
+	opensearchEndpoint, opensearchUser, opensearchPassword := getEnvVariables(t)
+	indexName := uuid.New().String()
+	llm := setLLM(t)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	storer, err := opensearch.New(
+		setOpensearchClient(t, opensearchEndpoint, opensearchUser, opensearchPassword),
+		opensearch.WithEmbedder(e),
+	)
+	require.NoError(t, err)
+
+	setIndex(t, storer, indexName)
+	defer removeIndex(t, storer, indexName)
+
+	ctx := context.Background()
+	err = storer.Upsert(ctx, []string{"tokyo", "paris"}, []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"country": "japan"}},
+		{PageContent: "paris", Metadata: map[string]any{"country": "france"}},
+	}, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+
+	err = storer.Upsert(ctx, []string{"tokyo"}, []schema.Document{
+		{PageContent: "tokyo, japan", Metadata: map[string]any{"country": "japan"}},
+	}, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+
+	docs, err := storer.GetByIDs(ctx, []string{"tokyo", "paris", "london"}, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "tokyo, japan", docs["tokyo"].PageContent)
+
+	require.NoError(t, storer.Delete(ctx, []string{"tokyo"}, vectorstores.WithNameSpace(indexName)))
+	docs, err = storer.GetByIDs(ctx, []string{"tokyo", "paris"}, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	err = storer.DeleteByFilter(ctx, map[string]any{
+		"match": map[string]any{"metadata.country": "france"},
+	}, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+	docs, err = storer.GetByIDs(ctx, []string{"paris"}, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+	require.Empty(t, docs)
+
+	require.ErrorIs(t, storer.DeleteByFilter(ctx, map[string]any{}), vectorstores.ErrEmptyFilter)
+}
+
+func TestOpensearchStoreMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+	opensearchEndpoint, opensearchUser, opensearchPassword := getEnvVariables(t)
+	indexName := uuid.New().String()
+	llm := setLLM(t)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	storer, err := opensearch.New(
+		setOpensearchClient(t, opensearchEndpoint, opensearchUser, opensearchPassword),
+		opensearch.WithEmbedder(e),
+	)
+	require.NoError(t, err)
+
+	setIndex(t, storer, indexName)
+	defer removeIndex(t, storer, indexName)
+
+	ctx := context.Background()
+	_, err = storer.AddDocuments(ctx, []schema.Document{
+		{PageContent: "Tokyo is the capital of Japan"},
+		{PageContent: "Tokyo is the capital city of Japan"},
+		{PageContent: "Kyoto is a city in Japan"},
+	}, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+	time.Sleep(time.Second)
+
+	res, err := storer.SimilaritySearchWithVectors(ctx, "capital of Japan", 3, vectorstores.WithNameSpace(indexName))
+	require.NoError(t, err)
+	require.Len(t, res.Documents, 3)
+	require.Len(t, res.Vectors, 3)
+	require.Len(t, res.Vectors[0], len(res.QueryVector))
+
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, storer, "capital of Japan", 2,
+		vectorstores.WithNameSpace(indexName), vectorstores.WithMMR(3, 0.3))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, []string{docs[0].PageContent, docs[1].PageContent}, "Kyoto is a city in Japan")
+}
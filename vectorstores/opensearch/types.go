@@ -25,3 +25,14 @@ type searchResultsHit struct {
 	Score  float32  `json:"_score"`
 	Source document `json:"_source"`
 }
+
+type mgetResults struct {
+	Docs []mgetResultsDoc `json:"docs"`
+}
+
+type mgetResultsDoc struct {
+	Index  string   `json:"_index"`
+	ID     string   `json:"_id"`
+	Found  bool     `json:"found"`
+	Source document `json:"_source"`
+}
@@ -17,6 +17,7 @@ type Options struct {
 	Filters        any
 	Embedder       embeddings.Embedder
 	Deduplicater   func(context.Context, schema.Document) bool
+	MMR            *MMROptions
 }
 
 // WithNameSpace returns an Option for setting the name space.
@@ -36,6 +37,9 @@ func WithScoreThreshold(scoreThreshold float32) Option {
 // filters retrieve exactly the number of nearest-neighbors results that match the filters. In
 // most cases the search latency will be lower than unfiltered searches
 // See https://docs.pinecone.io/docs/metadata-filtering
+//
+// The filters are either in the native syntax of the store, or a filter.Filter,
+// which the stores supporting it translate to their native syntax.
 func WithFilters(filters any) Option {
 	return func(o *Options) {
 		o.Filters = filters
@@ -0,0 +1,130 @@
+package pgvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// whereBuilder builds the SQL conditions of the metadata filters of a query,
+// whose arguments follow the ones the query already has.
+type whereBuilder struct {
+	column string
+	args   []any
+}
+
+func newWhereBuilder(column string, args ...any) *whereBuilder {
+	return &whereBuilder{column: column, args: args}
+}
+
+// arg adds an argument to the query and returns its placeholder.
+func (b *whereBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// conditions returns the SQL conditions of the filters, see getFilters.
+func (b *whereBuilder) conditions(filters any) ([]string, error) {
+	switch filters := filters.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		conditions := make([]string, 0, len(filters))
+		for k, v := range filters {
+			conditions = append(conditions, fmt.Sprintf("(%s ->> %s) = %s", b.column, b.arg(k), b.arg(fmt.Sprint(v))))
+		}
+		return conditions, nil
+	case filter.Filter:
+		condition, err := b.filter(filters)
+		if err != nil {
+			return nil, err
+		}
+		return []string{condition}, nil
+	default:
+		return nil, ErrInvalidFilters
+	}
+}
+
+// metadata returns the metadata column, a json one, as jsonb.
+func (b *whereBuilder) metadata() string {
+	return "(" + b.column + "::jsonb)"
+}
+
+// filter returns the SQL condition of a filter. Values are compared as jsonb,
+// so that 1 equals 1.0 but not "1", and conditions on missing keys are false
+// rather than null, so that Not matches the documents without the key.
+//
+//nolint:cyclop
+func (b *whereBuilder) filter(f filter.Filter) (string, error) {
+	switch f.Op {
+	case filter.OpEq, filter.OpNe:
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return "", err
+		}
+		operator := "="
+		if f.Op == filter.OpNe {
+			operator = "IS DISTINCT FROM"
+		}
+		return fmt.Sprintf("COALESCE((%s -> %s) %s (%s::text)::jsonb, FALSE)",
+			b.metadata(), b.arg(f.Key), operator, b.arg(string(value))), nil
+	case filter.OpIn:
+		values := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			value, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, string(value))
+		}
+		return fmt.Sprintf("COALESCE((%s -> %s) = ANY((%s::text[])::jsonb[]), FALSE)",
+			b.metadata(), b.arg(f.Key), b.arg(values)), nil
+	case filter.OpRange:
+		return b.rangeCondition(f), nil
+	case filter.OpExists:
+		return fmt.Sprintf("COALESCE(%s ? %s, FALSE)", b.metadata(), b.arg(f.Key)), nil
+	case filter.OpAnd, filter.OpOr:
+		conditions := make([]string, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			condition, err := b.filter(operand)
+			if err != nil {
+				return "", err
+			}
+			conditions = append(conditions, condition)
+		}
+		return "(" + strings.Join(conditions, " "+strings.ToUpper(string(f.Op))+" ") + ")", nil
+	case filter.OpNot:
+		condition, err := b.filter(f.Filters[0])
+		if err != nil {
+			return "", err
+		}
+		return "(NOT " + condition + ")", nil
+	default:
+		return "", filter.Unsupported("pgvector", f.Op)
+	}
+}
+
+// rangeCondition returns the SQL condition of a range filter, which the
+// values of the key that are not numbers do not match.
+func (b *whereBuilder) rangeCondition(f filter.Filter) string {
+	key := b.arg(f.Key)
+	value := fmt.Sprintf("(CASE WHEN jsonb_typeof(%s -> %s) = 'number' THEN (%s ->> %s)::float8 END)",
+		b.metadata(), key, b.metadata(), key)
+	conditions := []string{}
+	for _, bound := range []struct {
+		operator string
+		value    any
+	}{
+		{">", f.Bounds.Gt},
+		{">=", f.Bounds.Gte},
+		{"<", f.Bounds.Lt},
+		{"<=", f.Bounds.Lte},
+	} {
+		if v, ok := filter.ToFloat(bound.value); ok {
+			conditions = append(conditions, fmt.Sprintf("%s %s %s::float8", value, bound.operator, b.arg(v)))
+		}
+	}
+	return "COALESCE(" + strings.Join(conditions, " AND ") + ", FALSE)"
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 )
 
 const (
@@ -71,7 +72,10 @@ type HNSWIndex struct {
 	distanceFunction string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.MutableVectorStore = Store{}
+	_ vectorstores.VectorSearcher     = Store{}
+)
 
 // New creates a new Store with options.
 func New(ctx context.Context, opts ...Option) (Store, error) {
@@ -268,22 +272,145 @@ func (s Store) AddDocuments(
 	return ids, s.conn.SendBatch(ctx, b).Close()
 }
 
-//nolint:cyclop
+// Upsert adds the documents to the Postgres collection associated with 'Store' with the ids,
+// which must be UUIDs, replacing the documents with the same ids.
+func (s Store) Upsert(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if opts.ScoreThreshold != 0 || opts.Filters != nil || opts.NameSpace != "" {
+		return ErrUnsupportedOptions
+	}
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	embedder := s.embedder
+	if opts.Embedder != nil {
+		embedder = opts.Embedder
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	if len(vectors) != len(docs) {
+		return ErrEmbedderWrongNumberVectors
+	}
+
+	b := &pgx.Batch{}
+	sql := fmt.Sprintf(`INSERT INTO %s (uuid, document, embedding, cmetadata, collection_id)
+		VALUES($1, $2, $3, $4, $5) ON CONFLICT (uuid) DO
+		UPDATE SET document = $2, embedding = $3, cmetadata = $4, collection_id = $5`, s.embeddingTableName)
+	for docIdx, doc := range docs {
+		b.Queue(sql, ids[docIdx], doc.PageContent, pgvector.NewVector(vectors[docIdx]), doc.Metadata, s.collectionUUID)
+	}
+	return s.conn.SendBatch(ctx, b).Close()
+}
+
+// GetByIDs returns the documents of the Postgres collection associated with 'Store' with the ids.
+func (s Store) GetByIDs(
+	ctx context.Context,
+	ids []string,
+	_ ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	sql := fmt.Sprintf(`SELECT uuid::text, document, cmetadata FROM %s
+		WHERE collection_id = $1 AND uuid = ANY($2::uuid[])`, s.embeddingTableName)
+	rows, err := s.conn.Query(ctx, sql, s.collectionUUID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	docs := make(map[string]schema.Document, len(ids))
+	for rows.Next() {
+		var id string
+		doc := schema.Document{}
+		if err := rows.Scan(&id, &doc.PageContent, &doc.Metadata); err != nil {
+			return nil, err
+		}
+		docs[id] = doc
+	}
+	return docs, rows.Err()
+}
+
+// Delete removes the documents of the Postgres collection associated with 'Store' with the ids.
+func (s Store) Delete(ctx context.Context, ids []string, _ ...vectorstores.Option) error {
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE collection_id = $1 AND uuid = ANY($2::uuid[])`, s.embeddingTableName)
+	_, err := s.conn.Exec(ctx, sql, s.collectionUUID, ids)
+	return err
+}
+
+// DeleteByFilter removes the documents of the Postgres collection associated with 'Store' matching
+// the filter, see SimilaritySearch.
+func (s Store) DeleteByFilter(ctx context.Context, filter any, _ ...vectorstores.Option) error {
+	filters, err := s.getFilters(vectorstores.Options{Filters: filter})
+	if err != nil {
+		return err
+	}
+	if m, ok := filters.(map[string]any); filters == nil || (ok && len(m) == 0) {
+		return vectorstores.ErrEmptyFilter
+	}
+
+	where := newWhereBuilder("cmetadata", s.collectionUUID)
+	whereQuerys, err := where.conditions(filters)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(`DELETE FROM %s WHERE collection_id = $1 AND %s`,
+		s.embeddingTableName, strings.Join(whereQuerys, " AND "))
+	_, err = s.conn.Exec(ctx, sql, where.args...)
+	return err
+}
+
+// SimilaritySearch returns the documents of the collection most similar to the query. The filters
+// can be a filter.Filter, or a map[string]any of metadata values the documents must have.
 func (s Store) SimilaritySearch(
 	ctx context.Context,
 	query string,
 	numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	res, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return res.Documents, err
+}
+
+// SimilaritySearchWithVectors is like SimilaritySearch, and also returns the vectors of the query
+// and of the documents.
+func (s Store) SimilaritySearchWithVectors(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+//nolint:cyclop,funlen
+func (s Store) similaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	withVectors bool,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
 	opts := s.getOptions(options...)
 	collectionName := s.getNameSpace(opts)
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
-	filter, err := s.getFilters(opts)
+	filters, err := s.getFilters(opts)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 	embedder := s.embedder
 	if opts.Embedder != nil {
@@ -291,20 +418,25 @@ func (s Store) SimilaritySearch(
 	}
 	embedderData, err := embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
+	}
+	dims := len(embedderData)
+	where := newWhereBuilder("data.cmetadata", dims, pgvector.NewVector(embedderData), numDocuments)
+	whereQuerys, err := where.conditions(filters)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
 	}
-	whereQuerys := make([]string, 0)
 	if scoreThreshold != 0 {
 		whereQuerys = append(whereQuerys, fmt.Sprintf("data.distance < %f", 1-scoreThreshold))
 	}
-	for k, v := range filter {
-		whereQuerys = append(whereQuerys, fmt.Sprintf("(data.cmetadata ->> '%s') = '%s'", k, v))
+	embeddingColumn := ""
+	if withVectors {
+		embeddingColumn = ",\n\tdata.embedding"
 	}
 	whereQuery := strings.Join(whereQuerys, " AND ")
 	if len(whereQuery) == 0 {
 		whereQuery = "TRUE"
 	}
-	dims := len(embedderData)
 	sql := fmt.Sprintf(`WITH filtered_embedding_dims AS MATERIALIZED (
     SELECT
         *
@@ -318,7 +450,7 @@ func (s Store) SimilaritySearch(
 SELECT
 	data.document,
 	data.cmetadata,
-	data.distance
+	data.distance%s
 FROM (
 	SELECT
 		filtered_embedding_dims.*,
@@ -329,24 +461,32 @@ FROM (
 WHERE %s
 ORDER BY
 	data.distance
-LIMIT $3`, s.embeddingTableName,
+LIMIT $3`, s.embeddingTableName, embeddingColumn,
 		s.collectionTableName, s.collectionTableName, s.collectionTableName, collectionName,
 		whereQuery)
-	rows, err := s.conn.Query(ctx, sql, dims, pgvector.NewVector(embedderData), numDocuments)
+	rows, err := s.conn.Query(ctx, sql, where.args...)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 	defer rows.Close()
 
-	docs := make([]schema.Document, 0)
+	res := vectorstores.VectorSearchResult{QueryVector: embedderData, Documents: make([]schema.Document, 0)}
 	for rows.Next() {
 		doc := schema.Document{}
-		if err := rows.Scan(&doc.PageContent, &doc.Metadata, &doc.Score); err != nil {
-			return nil, err
+		dest := []any{&doc.PageContent, &doc.Metadata, &doc.Score}
+		var vector pgvector.Vector
+		if withVectors {
+			dest = append(dest, &vector)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return vectorstores.VectorSearchResult{}, err
+		}
+		res.Documents = append(res.Documents, doc)
+		if withVectors {
+			res.Vectors = append(res.Vectors, vector.Slice())
 		}
-		docs = append(docs, doc)
 	}
-	return docs, rows.Err()
+	return res, rows.Err()
 }
 
 //nolint:cyclop
@@ -357,13 +497,14 @@ func (s Store) Search(
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
 	collectionName := s.getNameSpace(opts)
-	filter, err := s.getFilters(opts)
+	filters, err := s.getFilters(opts)
 	if err != nil {
 		return nil, err
 	}
-	whereQuerys := make([]string, 0)
-	for k, v := range filter {
-		whereQuerys = append(whereQuerys, fmt.Sprintf("(%s.cmetadata ->> '%s') = '%s'", s.embeddingTableName, k, v))
+	where := newWhereBuilder(s.embeddingTableName+".cmetadata", numDocuments)
+	whereQuerys, err := where.conditions(filters)
+	if err != nil {
+		return nil, err
 	}
 	whereQuery := strings.Join(whereQuerys, " AND ")
 	if len(whereQuery) == 0 {
@@ -378,7 +519,7 @@ WHERE %s.name='%s' AND %s
 LIMIT $1`, s.embeddingTableName, s.embeddingTableName, s.embeddingTableName,
 		s.collectionTableName, s.embeddingTableName, s.collectionTableName, s.collectionTableName, collectionName,
 		whereQuery)
-	rows, err := s.conn.Query(ctx, sql, numDocuments)
+	rows, err := s.conn.Query(ctx, sql, where.args...)
 	if err != nil {
 		return nil, err
 	}
@@ -445,16 +586,19 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-// getFilters return metadata filters, now only support map[key]value pattern
-// TODO: should support more types like {"key1": {"key2":"values2"}} or {"key": ["value1", "values2"]}.
-func (s Store) getFilters(opts vectorstores.Options) (map[string]any, error) {
-	if opts.Filters != nil {
-		if filters, ok := opts.Filters.(map[string]any); ok {
-			return filters, nil
+// getFilters return metadata filters, a filter.Filter or a map[key]value pattern.
+func (s Store) getFilters(opts vectorstores.Options) (any, error) {
+	switch filters := opts.Filters.(type) {
+	case nil, map[string]any:
+		return filters, nil
+	case filter.Filter:
+		if err := filters.Validate(); err != nil {
+			return nil, err
 		}
+		return filters, nil
+	default:
 		return nil, ErrInvalidFilters
 	}
-	return map[string]any{}, nil
 }
 
 func (s Store) deduplicate(
@@ -20,6 +20,7 @@ import (
 	"github.com/tmc/langchaingo/llms/openai"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"github.com/tmc/langchaingo/vectorstores/pgvector"
 )
 
@@ -695,3 +696,161 @@ func TestWithAllOptions(t *testing.T) {
 	require.Equal(t, "tokyo", docs[0].PageContent)
 	require.Equal(t, "japan", docs[0].Metadata["country"])
 }
+
+func TestPgvectorStoreMutations(t *testing.T) {
+	t.Parallel()
+	pgvectorURL := preCheckEnvSetting(t)
+	ctx := context.Background()
+
+	llm, err := openai.New(
+		openai.WithEmbeddingModel("text-embedding-ada-002"),
+	)
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	conn, err := pgx.Connect(ctx, pgvectorURL)
+	require.NoError(t, err)
+
+	store, err := pgvector.New(
+		ctx,
+		pgvector.WithConn(conn),
+		pgvector.WithEmbedder(e),
+		pgvector.WithPreDeleteCollection(true),
+		pgvector.WithCollectionName(makeNewCollectionName()),
+	)
+	require.NoError(t, err)
+
+	defer cleanupTestArtifacts(ctx, t, store, pgvectorURL)
+
+	ids, err := store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"type": "city"}},
+		{PageContent: "potato", Metadata: map[string]any{"type": "vegetable"}},
+		{PageContent: "paris", Metadata: map[string]any{"type": "city"}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Upsert(ctx, ids[:1], []schema.Document{
+		{PageContent: "kyoto", Metadata: map[string]any{"type": "city"}},
+	}))
+	docs, err := store.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+
+	require.NoError(t, store.Delete(ctx, ids[2:]))
+	require.NoError(t, store.DeleteByFilter(ctx, map[string]any{"type": "vegetable"}))
+	require.ErrorIs(t, store.DeleteByFilter(ctx, nil), vectorstores.ErrEmptyFilter)
+
+	docs, err = store.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+}
+
+func TestPgvectorStoreWithFilter(t *testing.T) {
+	t.Parallel()
+	pgvectorURL := preCheckEnvSetting(t)
+	ctx := context.Background()
+
+	llm, err := openai.New(
+		openai.WithEmbeddingModel("text-embedding-ada-002"),
+	)
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	conn, err := pgx.Connect(ctx, pgvectorURL)
+	require.NoError(t, err)
+
+	store, err := pgvector.New(
+		ctx,
+		pgvector.WithConn(conn),
+		pgvector.WithEmbedder(e),
+		pgvector.WithPreDeleteCollection(true),
+		pgvector.WithCollectionName(makeNewCollectionName()),
+	)
+	require.NoError(t, err)
+
+	defer cleanupTestArtifacts(ctx, t, store, pgvectorURL)
+
+	_, err = store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"country": "japan", "population": 9.7}},
+		{PageContent: "kyoto", Metadata: map[string]any{"country": "japan", "population": 1.46}},
+		{PageContent: "paris", Metadata: map[string]any{"country": "france", "population": 11}},
+		{PageContent: "nowhere"},
+	})
+	require.NoError(t, err)
+
+	search := func(f filter.Filter) []string {
+		t.Helper()
+		docs, err := store.Search(ctx, 10, vectorstores.WithFilters(f))
+		require.NoError(t, err)
+		contents := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			contents = append(contents, doc.PageContent)
+		}
+		return contents
+	}
+
+	require.ElementsMatch(t, []string{"tokyo", "kyoto"}, search(filter.Eq("country", "japan")))
+	require.ElementsMatch(t, []string{"paris", "nowhere"}, search(filter.Ne("country", "japan")))
+	require.ElementsMatch(t, []string{"tokyo", "paris"}, search(filter.Gt("population", 5)))
+	require.ElementsMatch(t, []string{"paris"}, search(filter.And(
+		filter.In("country", "france", "spain"),
+		filter.Range("population", filter.Bounds{Gte: 11, Lt: 12}),
+	)))
+	require.ElementsMatch(t, []string{"kyoto", "nowhere"}, search(filter.Or(
+		filter.Not(filter.Exists("country")),
+		filter.Lte("population", 2),
+	)))
+
+	require.NoError(t, store.DeleteByFilter(ctx, filter.Eq("country", "japan")))
+	require.ElementsMatch(t, []string{"paris", "nowhere"}, search(filter.Not(filter.Eq("country", "japan"))))
+}
+
+func TestPgvectorStoreMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+	pgvectorURL := preCheckEnvSetting(t)
+	ctx := context.Background()
+
+	llm, err := openai.New(
+		openai.WithEmbeddingModel("text-embedding-ada-002"),
+	)
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	conn, err := pgx.Connect(ctx, pgvectorURL)
+	require.NoError(t, err)
+
+	store, err := pgvector.New(
+		ctx,
+		pgvector.WithConn(conn),
+		pgvector.WithEmbedder(e),
+		pgvector.WithPreDeleteCollection(true),
+		pgvector.WithCollectionName(makeNewCollectionName()),
+	)
+	require.NoError(t, err)
+
+	defer cleanupTestArtifacts(ctx, t, store, pgvectorURL)
+
+	_, err = store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "Tokyo is the capital of Japan"},
+		{PageContent: "Tokyo is the capital city of Japan"},
+		{PageContent: "Kyoto is a city in Japan"},
+	})
+	require.NoError(t, err)
+
+	res, err := store.SimilaritySearchWithVectors(ctx, "capital of Japan", 3)
+	require.NoError(t, err)
+	require.Len(t, res.Documents, 3)
+	require.Len(t, res.Vectors, 3)
+	require.Len(t, res.Vectors[0], len(res.QueryVector))
+
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, store, "capital of Japan", 2,
+		vectorstores.WithMMR(3, 0.3))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, []string{docs[0].PageContent, docs[1].PageContent}, "Kyoto is a city in Japan")
+}
@@ -0,0 +1,45 @@
+package pinecone
+
+import (
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// createMetadataFilter returns the Pinecone metadata filter of the filter.
+// Pinecone has no negation, which is pushed down to the conditions.
+func createMetadataFilter(f filter.Filter) map[string]any {
+	return createMetadataFilterNode(f.PushDownNot())
+}
+
+func createMetadataFilterNode(f filter.Filter) map[string]any {
+	switch f.Op {
+	case filter.OpEq:
+		return map[string]any{f.Key: map[string]any{"$eq": f.Value}}
+	case filter.OpNe:
+		return map[string]any{f.Key: map[string]any{"$ne": f.Value}}
+	case filter.OpIn:
+		return map[string]any{f.Key: map[string]any{"$in": f.Values}}
+	case filter.OpRange:
+		bounds := map[string]any{}
+		for operator, bound := range map[string]any{
+			"$gt": f.Bounds.Gt, "$gte": f.Bounds.Gte, "$lt": f.Bounds.Lt, "$lte": f.Bounds.Lte,
+		} {
+			if bound != nil {
+				bounds[operator] = bound
+			}
+		}
+		return map[string]any{f.Key: bounds}
+	case filter.OpExists:
+		return map[string]any{f.Key: map[string]any{"$exists": true}}
+	case filter.OpNot:
+		// Not only applies to Exists once pushed down.
+		return map[string]any{f.Filters[0].Key: map[string]any{"$exists": false}}
+	case filter.OpAnd, filter.OpOr:
+		operands := make([]any, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			operands = append(operands, createMetadataFilterNode(operand))
+		}
+		return map[string]any{"$" + string(f.Op): operands}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package pinecone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestCreateProtoStructFilter(t *testing.T) {
+	t.Parallel()
+
+	s := Store{}
+	protoFilterStruct, err := s.createProtoStructFilter(filter.And(
+		filter.Eq("genre", "drama"),
+		filter.Not(filter.Or(
+			filter.In("year", 2019, 2020),
+			filter.Range("rating", filter.Bounds{Gt: 4}),
+		)),
+	))
+	require.NoError(t, err)
+	got, err := protojson.Marshal(protoFilterStruct)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"$and": [
+		{"genre": {"$eq": "drama"}},
+		{"$and": [
+			{"$and": [{"year": {"$ne": 2019}}, {"year": {"$ne": 2020}}]},
+			{"$or": [{"rating": {"$lte": 4}}, {"rating": {"$exists": false}}]}
+		]}
+	]}`, string(got))
+
+	_, err = s.createProtoStructFilter(filter.In("year"))
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+}
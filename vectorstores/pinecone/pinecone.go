@@ -10,6 +10,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -39,6 +40,11 @@ type Store struct {
 	nameSpace string
 }
 
+var (
+	_ vectorstores.MutableVectorStore = Store{}
+	_ vectorstores.VectorSearcher     = Store{}
+)
+
 // New creates a new Store with options. Options for WithAPIKey, WithHost and WithEmbedder must be set.
 func New(opts ...Option) (Store, error) {
 	s, err := applyClientOptions(opts...)
@@ -70,6 +76,119 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 	defer indexConn.Close()
 
+	ids := make([]string, len(docs))
+	for i := range ids {
+		ids[i] = uuid.New().String()
+	}
+
+	if err := s.upsert(ctx, indexConn, ids, docs); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Upsert creates vector embeddings from the documents using the embedder
+// and upsert the vectors to the pinecone index with the ids.
+func (s Store) Upsert(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+	opts := s.getOptions(options...)
+
+	indexConn, err := s.client.IndexWithNamespace(s.host, s.getNameSpace(opts))
+	if err != nil {
+		return err
+	}
+	defer indexConn.Close()
+
+	return s.upsert(ctx, indexConn, ids, docs)
+}
+
+// GetByIDs fetches the vectors of the name space with the ids and returns
+// their documents.
+func (s Store) GetByIDs(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	if len(ids) == 0 {
+		return map[string]schema.Document{}, nil
+	}
+	opts := s.getOptions(options...)
+
+	indexConn, err := s.client.IndexWithNamespace(s.host, s.getNameSpace(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer indexConn.Close()
+
+	fetchResult, err := indexConn.FetchVectors(&ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]schema.Document, len(fetchResult.Vectors))
+	for id, vector := range fetchResult.Vectors {
+		doc, err := s.getDocument(vector)
+		if err != nil {
+			return nil, err
+		}
+		docs[id] = doc
+	}
+	return docs, nil
+}
+
+// Delete deletes the vectors of the name space with the ids.
+func (s Store) Delete(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	opts := s.getOptions(options...)
+
+	indexConn, err := s.client.IndexWithNamespace(s.host, s.getNameSpace(opts))
+	if err != nil {
+		return err
+	}
+	defer indexConn.Close()
+
+	return indexConn.DeleteVectorsById(&ctx, ids)
+}
+
+// DeleteByFilter deletes the vectors of the name space matching the metadata
+// filter. Serverless indexes do not support it.
+func (s Store) DeleteByFilter(ctx context.Context, filter any, options ...vectorstores.Option) error {
+	if filter == nil {
+		return vectorstores.ErrEmptyFilter
+	}
+	protoFilterStruct, err := s.createProtoStructFilter(filter)
+	if err != nil {
+		return err
+	}
+	if len(protoFilterStruct.GetFields()) == 0 {
+		return vectorstores.ErrEmptyFilter
+	}
+	opts := s.getOptions(options...)
+
+	indexConn, err := s.client.IndexWithNamespace(s.host, s.getNameSpace(opts))
+	if err != nil {
+		return err
+	}
+	defer indexConn.Close()
+
+	return indexConn.DeleteVectorsByFilter(&ctx, protoFilterStruct)
+}
+
+// upsert creates vector embeddings from the documents using the embedder
+// and upsert the vectors with the ids.
+func (s Store) upsert(ctx context.Context,
+	indexConn *pinecone.IndexConnection,
+	ids []string,
+	docs []schema.Document,
+) error {
 	texts := make([]string, 0, len(docs))
 	for _, doc := range docs {
 		texts = append(texts, doc.PageContent)
@@ -77,11 +196,11 @@ func (s Store) AddDocuments(ctx context.Context,
 
 	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(vectors) != len(docs) {
-		return nil, ErrEmbedderWrongNumberVectors
+		return ErrEmbedderWrongNumberVectors
 	}
 
 	metadatas := make([]map[string]any, 0, len(docs))
@@ -96,20 +215,16 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	pineconeVectors := make([]*pinecone.Vector, 0, len(vectors))
-
-	ids := make([]string, len(vectors))
 	for i := 0; i < len(vectors); i++ {
 		metadataStruct, err := structpb.NewStruct(metadatas[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		id := uuid.New().String()
-		ids[i] = id
 		pineconeVectors = append(
 			pineconeVectors,
 			&pinecone.Vector{
-				Id:       id,
+				Id:       ids[i],
 				Values:   vectors[i],
 				Metadata: metadataStruct,
 			},
@@ -117,22 +232,37 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	_, err = indexConn.UpsertVectors(&ctx, pineconeVectors)
-	if err != nil {
-		return nil, err
-	}
-
-	return ids, nil
+	return err
 }
 
 // SimilaritySearch creates a vector embedding from the query using the embedder
 // and queries to find the most similar documents.
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
+	res, err := s.similaritySearch(ctx, query, numDocuments, false, options...)
+	return res.Documents, err
+}
+
+// SimilaritySearchWithVectors is like SimilaritySearch, and also returns the vectors of the query
+// and of the documents.
+func (s Store) SimilaritySearchWithVectors(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) (vectorstores.VectorSearchResult, error) { //nolint:lll
+	return s.similaritySearch(ctx, query, numDocuments, true, options...)
+}
+
+// similaritySearch queries the documents most similar to the query. The values of the vectors of
+// the documents are only requested with withVectors, since they make the response much larger.
+func (s Store) similaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	withVectors bool,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
 	opts := s.getOptions(options...)
 
 	nameSpace := s.getNameSpace(opts)
 	indexConn, err := s.client.IndexWithNamespace(s.host, nameSpace)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 	defer indexConn.Close()
 
@@ -141,18 +271,18 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	if filters != nil {
 		protoFilterStruct, err = s.createProtoStructFilter(filters)
 		if err != nil {
-			return nil, err
+			return vectorstores.VectorSearchResult{}, err
 		}
 	}
 
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
 	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
 	queryResult, err := indexConn.QueryByVectorValues(
@@ -162,44 +292,63 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 			TopK:            uint32(numDocuments),
 			Filter:          protoFilterStruct,
 			IncludeMetadata: true,
-			IncludeValues:   true,
+			IncludeValues:   withVectors,
 		},
 	)
 	if err != nil {
-		return nil, err
+		return vectorstores.VectorSearchResult{}, err
 	}
 
 	if len(queryResult.Matches) == 0 {
-		return nil, ErrEmptyResponse
+		return vectorstores.VectorSearchResult{}, ErrEmptyResponse
 	}
 
-	return s.getDocumentsFromMatches(queryResult, scoreThreshold)
+	docs, vectors, err := s.getDocumentsFromMatches(queryResult, scoreThreshold)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	res := vectorstores.VectorSearchResult{QueryVector: vector, Documents: docs}
+	if withVectors {
+		res.Vectors = vectors
+	}
+	return res, nil
 }
 
-func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsResponse, scoreThreshold float32) ([]schema.Document, error) {
+func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsResponse, scoreThreshold float32) ([]schema.Document, [][]float32, error) { //nolint:lll
 	resultDocuments := make([]schema.Document, 0)
+	resultVectors := make([][]float32, 0)
 	for _, match := range queryResult.Matches {
-		metadata := match.Vector.Metadata.AsMap()
-		pageContent, ok := metadata[s.textKey].(string)
-		if !ok {
-			return nil, ErrMissingTextKey
-		}
-		delete(metadata, s.textKey)
-
-		doc := schema.Document{
-			PageContent: pageContent,
-			Metadata:    metadata,
-			Score:       match.Score,
+		doc, err := s.getDocument(match.Vector)
+		if err != nil {
+			return nil, nil, err
 		}
+		doc.Score = match.Score
 
 		// If scoreThreshold is not 0, we only return matches with a score above the threshold.
 		if scoreThreshold != 0 && match.Score >= scoreThreshold {
 			resultDocuments = append(resultDocuments, doc)
+			resultVectors = append(resultVectors, match.Vector.Values)
 		} else if scoreThreshold == 0 { // If scoreThreshold is 0, we return all matches.
 			resultDocuments = append(resultDocuments, doc)
+			resultVectors = append(resultVectors, match.Vector.Values)
 		}
 	}
-	return resultDocuments, nil
+	return resultDocuments, resultVectors, nil
+}
+
+// getDocument returns the document stored in the metadata of the vector.
+func (s Store) getDocument(vector *pinecone.Vector) (schema.Document, error) {
+	metadata := vector.Metadata.AsMap()
+	pageContent, ok := metadata[s.textKey].(string)
+	if !ok {
+		return schema.Document{}, ErrMissingTextKey
+	}
+	delete(metadata, s.textKey)
+
+	return schema.Document{
+		PageContent: pageContent,
+		Metadata:    metadata,
+	}, nil
 }
 
 func (s Store) getNameSpace(opts vectorstores.Options) string {
@@ -231,8 +380,17 @@ func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	return opts
 }
 
-func (s Store) createProtoStructFilter(filter any) (*structpb.Struct, error) {
-	filterBytes, err := json.Marshal(filter)
+// createProtoStructFilter returns the proto struct of the filters, a Pinecone metadata filter or a
+// filter.Filter.
+func (s Store) createProtoStructFilter(filters any) (*structpb.Struct, error) {
+	if f, ok := filters.(filter.Filter); ok {
+		if err := f.Validate(); err != nil {
+			return nil, err
+		}
+		filters = createMetadataFilter(f)
+	}
+
+	filterBytes, err := json.Marshal(filters)
 	if err != nil {
 		return nil, err
 	}
@@ -551,3 +551,76 @@ func TestPineconeAsRetrieverWithMetadataFilters(t *testing.T) {
 
 	require.Contains(t, result, "purple", "expected black in purple")
 }
+
+func TestPineconeStoreMutations(t *testing.T) {
+	t.Parallel()
+
+	apiKey, host := getValues(t)
+
+	llm, err := openai.New(openai.WithEmbeddingModel("text-embedding-ada-002"))
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	storer, err := pinecone.New(
+		pinecone.WithAPIKey(apiKey),
+		pinecone.WithHost(host),
+		pinecone.WithEmbedder(e),
+		pinecone.WithNameSpace(uuid.New().String()),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ids, err := storer.AddDocuments(ctx, []schema.Document{
+		{PageContent: "tokyo"},
+		{PageContent: "potato"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, storer.Upsert(ctx, ids[:1], []schema.Document{{PageContent: "kyoto"}}))
+	require.NoError(t, storer.Delete(ctx, ids[1:]))
+
+	docs, err := storer.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+}
+
+func TestPineconeStoreMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+
+	apiKey, host := getValues(t)
+
+	llm, err := openai.New(openai.WithEmbeddingModel("text-embedding-ada-002"))
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	storer, err := pinecone.New(
+		pinecone.WithAPIKey(apiKey),
+		pinecone.WithHost(host),
+		pinecone.WithEmbedder(e),
+		pinecone.WithNameSpace(uuid.New().String()),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = storer.AddDocuments(ctx, []schema.Document{
+		{PageContent: "Tokyo is the capital of Japan"},
+		{PageContent: "Tokyo is the capital city of Japan"},
+		{PageContent: "Kyoto is a city in Japan"},
+	})
+	require.NoError(t, err)
+
+	res, err := storer.SimilaritySearchWithVectors(ctx, "capital of Japan", 3)
+	require.NoError(t, err)
+	require.Len(t, res.Documents, 3)
+	require.Len(t, res.Vectors, 3)
+	require.Len(t, res.Vectors[0], len(res.QueryVector))
+
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, storer, "capital of Japan", 2,
+		vectorstores.WithMMR(3, 0.3))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, []string{docs[0].PageContent, docs[1].PageContent}, "Kyoto is a city in Japan")
+}
@@ -0,0 +1,96 @@
+package qdrant
+
+import (
+	"encoding/json"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// createFilter returns the Qdrant filter of the filter.
+func createFilter(f filter.Filter) map[string]any {
+	condition := createCondition(f)
+	switch f.Op { //nolint:exhaustive
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		return condition
+	default:
+		return map[string]any{"must": []any{condition}}
+	}
+}
+
+// createCondition returns the Qdrant condition of the filter, a nested filter
+// for the logical operators. Numbers are matched with ranges, since Qdrant
+// matches integers but not floats by value.
+func createCondition(f filter.Filter) map[string]any {
+	switch f.Op {
+	case filter.OpEq:
+		if v, ok := filter.ToFloat(f.Value); ok {
+			return map[string]any{"key": f.Key, "range": map[string]any{"gte": v, "lte": v}}
+		}
+		return map[string]any{"key": f.Key, "match": map[string]any{"value": f.Value}}
+	case filter.OpNe:
+		return map[string]any{"must_not": []any{createCondition(filter.Eq(f.Key, f.Value))}}
+	case filter.OpIn:
+		values := make([]any, 0, len(f.Values))
+		conditions := make([]any, 0, len(f.Values))
+		for _, v := range f.Values {
+			if _, ok := v.(string); ok {
+				values = append(values, v)
+			} else {
+				conditions = append(conditions, createCondition(filter.Eq(f.Key, v)))
+			}
+		}
+		if len(values) > 0 {
+			conditions = append(conditions, map[string]any{"key": f.Key, "match": map[string]any{"any": values}})
+		}
+		return map[string]any{"should": conditions}
+	case filter.OpRange:
+		bounds := map[string]any{}
+		for name, bound := range map[string]any{
+			"gt": f.Bounds.Gt, "gte": f.Bounds.Gte, "lt": f.Bounds.Lt, "lte": f.Bounds.Lte,
+		} {
+			if v, ok := filter.ToFloat(bound); ok {
+				bounds[name] = v
+			}
+		}
+		return map[string]any{"key": f.Key, "range": bounds}
+	case filter.OpExists:
+		return map[string]any{"must_not": []any{map[string]any{"is_empty": map[string]any{"key": f.Key}}}}
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		conditions := make([]any, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			conditions = append(conditions, createCondition(operand))
+		}
+		clause := map[filter.Op]string{filter.OpAnd: "must", filter.OpOr: "should", filter.OpNot: "must_not"}[f.Op]
+		return map[string]any{clause: conditions}
+	default:
+		return nil
+	}
+}
+
+// isEmptyFilter reports whether the Qdrant filter has no conditions.
+func isEmptyFilter(filter any) (bool, error) {
+	b, err := json.Marshal(filter)
+	if err != nil {
+		return false, err
+	}
+	var clauses map[string]any
+	if err := json.Unmarshal(b, &clauses); err != nil {
+		return false, err
+	}
+	for _, conditions := range clauses {
+		switch conditions := conditions.(type) {
+		case nil:
+		case []any:
+			if len(conditions) > 0 {
+				return false, nil
+			}
+		case map[string]any:
+			if len(conditions) > 0 {
+				return false, nil
+			}
+		default:
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,68 @@
+package qdrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestGetFilters(t *testing.T) {
+	t.Parallel()
+
+	s := Store{}
+	got, err := s.getFilters(vectorstores.Options{Filters: filter.And(
+		filter.Eq("city", "Tokyo"),
+		filter.Ne("area", 622),
+		filter.In("tags", "a", 1),
+		filter.Range("population", filter.Bounds{Gt: 1, Lte: 10}),
+		filter.Not(filter.Exists("deleted")),
+	)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"must": []any{
+		map[string]any{"key": "city", "match": map[string]any{"value": "Tokyo"}},
+		map[string]any{"must_not": []any{
+			map[string]any{"key": "area", "range": map[string]any{"gte": 622.0, "lte": 622.0}},
+		}},
+		map[string]any{"should": []any{
+			map[string]any{"key": "tags", "range": map[string]any{"gte": 1.0, "lte": 1.0}},
+			map[string]any{"key": "tags", "match": map[string]any{"any": []any{"a"}}},
+		}},
+		map[string]any{"key": "population", "range": map[string]any{"gt": 1.0, "lte": 10.0}},
+		map[string]any{"must_not": []any{
+			map[string]any{"must_not": []any{map[string]any{"is_empty": map[string]any{"key": "deleted"}}}},
+		}},
+	}}, got)
+
+	got, err = s.getFilters(vectorstores.Options{Filters: filter.Eq("public", true)})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"must": []any{
+		map[string]any{"key": "public", "match": map[string]any{"value": true}},
+	}}, got)
+
+	_, err = s.getFilters(vectorstores.Options{Filters: filter.Or()})
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+}
+
+func TestDeleteByEmptyFilter(t *testing.T) {
+	t.Parallel()
+
+	s := Store{}
+	for _, f := range []any{
+		nil,
+		map[string]any{},
+		map[string]any{"must": []any{}, "should": nil},
+		map[string]any{"must_not": []map[string]any{}},
+	} {
+		require.ErrorIs(t, s.DeleteByFilter(context.Background(), f), vectorstores.ErrEmptyFilter)
+	}
+
+	empty, err := isEmptyFilter(map[string]any{"must": []map[string]any{
+		{"key": "city", "match": map[string]any{"value": "Tokyo"}},
+	}})
+	require.NoError(t, err)
+	assert.False(t, empty)
+}
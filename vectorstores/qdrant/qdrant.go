@@ -5,9 +5,11 @@ import (
 	"errors"
 	"net/url"
 
+	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 )
 
 type Store struct {
@@ -18,7 +20,10 @@ type Store struct {
 	contentKey     string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.MutableVectorStore = Store{}
+	_ vectorstores.VectorSearcher     = Store{}
+)
 
 func New(opts ...Option) (Store, error) {
 	s, err := applyClientOptions(opts...)
@@ -32,6 +37,98 @@ func (s Store) AddDocuments(ctx context.Context,
 	docs []schema.Document,
 	_ ...vectorstores.Option,
 ) ([]string, error) {
+	vectors,
+		payloads,
+		err := s.embedDocuments(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(vectors))
+	for i := range ids {
+		ids[i] = uuid.NewString()
+	}
+
+	if err := s.upsertPoints(ctx, &s.qdrantURL, ids, vectors, payloads); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Upsert adds the documents with the ids, which must be UUIDs, replacing the points with
+// the same ids.
+func (s Store) Upsert(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	_ ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+
+	vectors,
+		payloads,
+		err := s.embedDocuments(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	return s.upsertPoints(ctx, &s.qdrantURL, ids, vectors, payloads)
+}
+
+// GetByIDs returns the documents of the points with the ids.
+func (s Store) GetByIDs(ctx context.Context,
+	ids []string,
+	_ ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	if len(ids) == 0 {
+		return map[string]schema.Document{}, nil
+	}
+
+	return s.retrievePoints(ctx, &s.qdrantURL, ids)
+}
+
+// Delete removes the points with the ids.
+func (s Store) Delete(ctx context.Context,
+	ids []string,
+	_ ...vectorstores.Option,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.deletePoints(ctx, &s.qdrantURL, deleteBody{Points: ids})
+}
+
+// DeleteByFilter removes the points matching the filter, a Qdrant filter or a filter.Filter.
+func (s Store) DeleteByFilter(ctx context.Context,
+	filter any,
+	_ ...vectorstores.Option,
+) error {
+	if filter == nil {
+		return vectorstores.ErrEmptyFilter
+	}
+
+	filters, err := s.getFilters(vectorstores.Options{Filters: filter})
+	if err != nil {
+		return err
+	}
+	// Qdrant matches all the points with a filter without conditions.
+	empty, err := isEmptyFilter(filters)
+	if err != nil {
+		return err
+	}
+	if empty {
+		return vectorstores.ErrEmptyFilter
+	}
+
+	return s.deletePoints(ctx, &s.qdrantURL, deleteBody{Filter: filters})
+}
+
+// embedDocuments returns the vectors and the payloads of the documents.
+func (s Store) embedDocuments(ctx context.Context,
+	docs []schema.Document,
+) ([][]float32, []map[string]interface{}, error) {
 	texts := make([]string, 0, len(docs))
 	for _, doc := range docs {
 		texts = append(texts, doc.PageContent)
@@ -40,11 +137,11 @@ func (s Store) AddDocuments(ctx context.Context,
 	vectors,
 		err := s.embedder.EmbedDocuments(ctx, texts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(vectors) != len(docs) {
-		return nil, errors.New("number of vectors from embedder does not match number of documents")
+		return nil, nil, errors.New("number of vectors from embedder does not match number of documents")
 	}
 
 	metadatas := make([]map[string]interface{}, 0, len(docs))
@@ -58,7 +155,7 @@ func (s Store) AddDocuments(ctx context.Context,
 		metadatas = append(metadatas, metadata)
 	}
 
-	return s.upsertPoints(ctx, &s.qdrantURL, vectors, metadatas)
+	return vectors, metadatas, nil
 }
 
 func (s Store) SimilaritySearch(ctx context.Context,
@@ -67,7 +164,10 @@ func (s Store) SimilaritySearch(ctx context.Context,
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
 
-	filters := s.getFilters(opts)
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	scoreThreshold,
 		err := s.getScoreThreshold(opts)
@@ -81,7 +181,38 @@ func (s Store) SimilaritySearch(ctx context.Context,
 		return nil, err
 	}
 
-	return s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters)
+	docs, _, err := s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters, false)
+	return docs, err
+}
+
+// SimilaritySearchWithVectors is like SimilaritySearch, and also returns the vectors of the
+// query and of the documents.
+func (s Store) SimilaritySearchWithVectors(ctx context.Context,
+	query string, numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	opts := s.getOptions(options...)
+
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	vector, err := s.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+
+	docs, vectors, err := s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters, true)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	return vectorstores.VectorSearchResult{QueryVector: vector, Documents: docs, Vectors: vectors}, nil
 }
 
 func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
@@ -91,12 +222,17 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-func (s Store) getFilters(opts vectorstores.Options) any {
-	if opts.Filters != nil {
-		return opts.Filters
+// getFilters returns the Qdrant filter of the options, given as is or as a
+// filter.Filter.
+func (s Store) getFilters(opts vectorstores.Options) (any, error) {
+	if f, ok := opts.Filters.(filter.Filter); ok {
+		if err := f.Validate(); err != nil {
+			return nil, err
+		}
+		return createFilter(f), nil
 	}
 
-	return nil
+	return opts.Filters, nil
 }
 
 func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
@@ -356,6 +356,96 @@ func TestQdrantRetrieverFilter(t *testing.T) {
 	require.Contains(t, result, "yellow", "expected yellow in result")
 }
 
+func TestQdrantStoreMutations(t *testing.T) {
+	t.Parallel()
+
+	qdrantURL, apiKey, dimension, distance := getValues(t)
+	collectionName := setupCollection(t, qdrantURL, apiKey, dimension, distance)
+
+	llm, err := openai.New(openai.WithEmbeddingModel("text-embedding-ada-002"))
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	url, err := url.Parse(qdrantURL)
+	require.NoError(t, err)
+	store, err := qdrant.New(
+		qdrant.WithURL(*url),
+		qdrant.WithAPIKey(apiKey),
+		qdrant.WithCollectionName(collectionName),
+		qdrant.WithEmbedder(e),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ids, err := store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"type": "city"}},
+		{PageContent: "potato", Metadata: map[string]any{"type": "vegetable"}},
+		{PageContent: "paris", Metadata: map[string]any{"type": "city"}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Upsert(ctx, ids[:1], []schema.Document{
+		{PageContent: "kyoto", Metadata: map[string]any{"type": "city"}},
+	}))
+	docs, err := store.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+
+	require.NoError(t, store.Delete(ctx, ids[2:]))
+	require.NoError(t, store.DeleteByFilter(ctx, map[string]any{
+		"must": []map[string]any{{"key": "type", "match": map[string]any{"value": "vegetable"}}},
+	}))
+
+	docs, err = store.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+}
+
+func TestQdrantStoreMaxMarginalRelevance(t *testing.T) {
+	t.Parallel()
+
+	qdrantURL, apiKey, dimension, distance := getValues(t)
+	collectionName := setupCollection(t, qdrantURL, apiKey, dimension, distance)
+
+	llm, err := openai.New(openai.WithEmbeddingModel("text-embedding-ada-002"))
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	url, err := url.Parse(qdrantURL)
+	require.NoError(t, err)
+	store, err := qdrant.New(
+		qdrant.WithURL(*url),
+		qdrant.WithAPIKey(apiKey),
+		qdrant.WithCollectionName(collectionName),
+		qdrant.WithEmbedder(e),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "Tokyo is the capital of Japan"},
+		{PageContent: "Tokyo is the capital city of Japan"},
+		{PageContent: "Kyoto is a city in Japan"},
+	})
+	require.NoError(t, err)
+
+	res, err := store.SimilaritySearchWithVectors(ctx, "capital of Japan", 3)
+	require.NoError(t, err)
+	require.Len(t, res.Documents, 3)
+	require.Len(t, res.Vectors, 3)
+	require.Len(t, res.Vectors[0], dimension)
+
+	docs, err := vectorstores.MaxMarginalRelevanceSearch(ctx, store, "capital of Japan", 2,
+		vectorstores.WithMMR(3, 0.3))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, []string{docs[0].PageContent, docs[1].PageContent}, "Kyoto is a city in Japan")
+}
+
 func getValues(t *testing.T) (string, string, int, string) {
 	t.Helper()
 
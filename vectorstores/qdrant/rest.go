@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"net/url"
 
-	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/schema"
 )
 
@@ -17,14 +16,10 @@ import (
 func (s Store) upsertPoints(
 	ctx context.Context,
 	baseURL *url.URL,
+	ids []string,
 	vectors [][]float32,
 	payloads []map[string]interface{},
-) ([]string, error) {
-	ids := make([]string, len(vectors))
-	for i := range ids {
-		ids[i] = uuid.NewString()
-	}
-
+) error {
 	payload := upsertBody{
 		Batch: upsertBatch{
 			IDs:      ids,
@@ -42,20 +37,95 @@ func (s Store) upsertPoints(
 		http.MethodPut,
 		payload,
 	)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if status == http.StatusOK {
+		return nil
+	}
+
+	return newAPIError("upserting vectors", body)
+}
+
+// retrievePoints returns the documents of the points of the Qdrant collection with the ids.
+func (s Store) retrievePoints(
+	ctx context.Context,
+	baseURL *url.URL,
+	ids []string,
+) (map[string]schema.Document, error) {
+	payload := retrieveBody{
+		IDs:         ids,
+		WithPayload: true,
+	}
+
+	url := baseURL.JoinPath("collections", s.collectionName, "points")
+	body,
+		status,
+		err := DoRequest(
+		ctx, *url,
+		s.apiKey,
+		http.MethodPost,
+		payload,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if status != http.StatusOK {
+		return nil, newAPIError("retrieving points", body)
+	}
+
+	var response retrieveResponse
+
+	decoder := json.NewDecoder(body)
+	err = decoder.Decode(&response)
 	if err != nil {
 		return nil, err
 	}
+	docs := make(map[string]schema.Document, len(response.Result))
+	for _, point := range response.Result {
+		doc, err := s.payloadDocument(point.Payload)
+		if err != nil {
+			return nil, err
+		}
+		docs[fmt.Sprint(point.ID)] = doc
+	}
+
+	return docs, nil
+}
+
+// deletePoints deletes the points of the Qdrant collection selected by the body.
+func (s Store) deletePoints(
+	ctx context.Context,
+	baseURL *url.URL,
+	payload deleteBody,
+) error {
+	url := baseURL.JoinPath("collections", s.collectionName, "points", "delete")
+	body,
+		status,
+		err := DoRequest(
+		ctx, *url,
+		s.apiKey,
+		http.MethodPost,
+		payload,
+	)
+	if err != nil {
+		return err
+	}
 	defer body.Close()
 
 	if status == http.StatusOK {
-		return ids, nil
+		return nil
 	}
 
-	return nil,
-		newAPIError("upserting vectors", body)
+	return newAPIError("deleting points", body)
 }
 
-// searchPoints queries the Qdrant collection for points based on the provided parameters.
+// searchPoints queries the Qdrant collection for points based on the provided parameters,
+// and returns the vectors of the points with withVector.
 func (s Store) searchPoints(
 	ctx context.Context,
 	baseURL *url.URL,
@@ -63,10 +133,11 @@ func (s Store) searchPoints(
 	numVectors int,
 	scoreThreshold float32,
 	filter any,
-) ([]schema.Document, error) {
+	withVector bool,
+) ([]schema.Document, [][]float32, error) {
 	payload := searchBody{
 		WithPayload: true,
-		WithVector:  false,
+		WithVector:  withVector,
 		Vector:      vector,
 		Limit:       numVectors,
 		Filter:      filter,
@@ -86,12 +157,12 @@ func (s Store) searchPoints(
 		payload,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer body.Close()
 
 	if statusCode != http.StatusOK {
-		return nil, newAPIError("querying collection", body)
+		return nil, nil, newAPIError("querying collection", body)
 	}
 
 	var response searchResponse
@@ -99,26 +170,41 @@ func (s Store) searchPoints(
 	decoder := json.NewDecoder(body)
 	err = decoder.Decode(&response)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	docs := make([]schema.Document, len(response.Result))
+	var vectors [][]float32
+	if withVector {
+		vectors = make([][]float32, len(response.Result))
+	}
 	for i, match := range response.Result {
-		pageContent, ok := match.Payload[s.contentKey].(string)
-		if !ok {
-			return nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
+		doc, err := s.payloadDocument(match.Payload)
+		if err != nil {
+			return nil, nil, err
 		}
-		delete(match.Payload, s.contentKey)
+		doc.Score = match.Score
 
-		doc := schema.Document{
-			PageContent: pageContent,
-			Metadata:    match.Payload,
-			Score:       match.Score,
+		docs[i] = doc
+		if withVector {
+			vectors[i] = match.Vector
 		}
+	}
 
-		docs[i] = doc
+	return docs, vectors, nil
+}
+
+// payloadDocument returns the document stored in the payload of a point.
+func (s Store) payloadDocument(payload map[string]interface{}) (schema.Document, error) {
+	pageContent, ok := payload[s.contentKey].(string)
+	if !ok {
+		return schema.Document{}, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
 	}
+	delete(payload, s.contentKey)
 
-	return docs, nil
+	return schema.Document{
+		PageContent: pageContent,
+		Metadata:    payload,
+	}, nil
 }
 
 // doRequest performs an HTTP request to the Qdrant API.
@@ -18,6 +18,7 @@ type upsertBody struct {
 type result struct {
 	Score   float32                `json:"score"`
 	Payload map[string]interface{} `json:"payload"`
+	Vector  []float32              `json:"vector"`
 }
 
 type searchResponse struct {
@@ -32,3 +33,23 @@ type searchBody struct {
 	WithVector     bool      `json:"with_vector"`
 	WithPayload    bool      `json:"with_payload"`
 }
+
+type retrieveBody struct {
+	IDs         []string `json:"ids"`
+	WithPayload bool     `json:"with_payload"`
+}
+
+type point struct {
+	// ID is a UUID string or an unsigned integer.
+	ID      any                    `json:"id"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+type retrieveResponse struct {
+	Result []point `json:"result"`
+}
+
+type deleteBody struct {
+	Points []string `json:"points,omitempty"`
+	Filter any      `json:"filter,omitempty"`
+}
@@ -0,0 +1,102 @@
+package redisvector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+// queryEscaper escapes the characters of tag values redis search would
+// otherwise parse as query syntax.
+var queryEscaper = strings.NewReplacer( //nolint:gochecknoglobals
+	",", `\,`, ".", `\.`, "<", `\<`, ">", `\>`, "{", `\{`, "}", `\}`, "[", `\[`, "]", `\]`,
+	`"`, `\"`, "'", `\'`, ":", `\:`, ";", `\;`, "!", `\!`, "@", `\@`, "#", `\#`, "$", `\$`,
+	"%", `\%`, "^", `\^`, "&", `\&`, "*", `\*`, "(", `\(`, ")", `\)`, "-", `\-`, "+", `\+`,
+	"=", `\=`, "~", `\~`, "|", `\|`, "/", `\/`, `\`, `\\`, " ", `\ `,
+)
+
+// createFilterQuery returns the redis search query of the filter. Numbers are
+// matched against numeric fields, and strings against the tag fields of the
+// index schema, or else against text fields as phrases. Redis search cannot
+// query whether a document has a field, so OpExists is not supported.
+//
+//nolint:cyclop
+func (s Store) createFilterQuery(f filter.Filter) (string, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return s.createValueQuery(f.Key, f.Value), nil
+	case filter.OpNe:
+		return "-" + s.createValueQuery(f.Key, f.Value), nil
+	case filter.OpIn:
+		queries := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			queries = append(queries, s.createValueQuery(f.Key, v))
+		}
+		return "(" + strings.Join(queries, " | ") + ")", nil
+	case filter.OpRange:
+		lower, upper := "-inf", "+inf"
+		if v, ok := filter.ToFloat(f.Bounds.Gt); ok {
+			lower = "(" + formatNumber(v)
+		} else if v, ok := filter.ToFloat(f.Bounds.Gte); ok {
+			lower = formatNumber(v)
+		}
+		if v, ok := filter.ToFloat(f.Bounds.Lt); ok {
+			upper = "(" + formatNumber(v)
+		} else if v, ok := filter.ToFloat(f.Bounds.Lte); ok {
+			upper = formatNumber(v)
+		}
+		return fmt.Sprintf("@%s:[%s %s]", f.Key, lower, upper), nil
+	case filter.OpAnd, filter.OpOr:
+		queries := make([]string, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			query, err := s.createFilterQuery(operand)
+			if err != nil {
+				return "", err
+			}
+			queries = append(queries, query)
+		}
+		separator := " "
+		if f.Op == filter.OpOr {
+			separator = " | "
+		}
+		return "(" + strings.Join(queries, separator) + ")", nil
+	case filter.OpNot:
+		query, err := s.createFilterQuery(f.Filters[0])
+		if err != nil {
+			return "", err
+		}
+		return "-(" + query + ")", nil
+	default:
+		return "", filter.Unsupported("redis", f.Op)
+	}
+}
+
+// createValueQuery returns the redis search query matching the value of the key.
+func (s Store) createValueQuery(key string, value any) string {
+	if v, ok := filter.ToFloat(value); ok {
+		return fmt.Sprintf("@%s:[%s %s]", key, formatNumber(v), formatNumber(v))
+	}
+	str := fmt.Sprint(value)
+	if s.isTagField(key) {
+		return fmt.Sprintf("@%s:{%s}", key, queryEscaper.Replace(str))
+	}
+	return fmt.Sprintf(`@%s:"%s"`, key, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(str))
+}
+
+func (s Store) isTagField(key string) bool {
+	if s.indexSchema == nil {
+		return false
+	}
+	for _, field := range s.indexSchema.Tag {
+		if field.Name == key {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
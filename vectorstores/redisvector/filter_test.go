@@ -0,0 +1,42 @@
+package redisvector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+)
+
+func TestCreateFilterQuery(t *testing.T) {
+	t.Parallel()
+
+	s := Store{indexSchema: &IndexSchema{Tag: []TagField{{Name: "tags"}}}}
+
+	tests := []struct {
+		filter filter.Filter
+		want   string
+	}{
+		{filter.Eq("city", `New "York"`), `@city:"New \"York\""`},
+		{filter.Eq("tags", "sci-fi"), `@tags:{sci\-fi}`},
+		{filter.Ne("area", 622), `-@area:[622 622]`},
+		{filter.In("tags", "a b", "c"), `(@tags:{a\ b} | @tags:{c})`},
+		{filter.Range("population", filter.Bounds{Gt: 1.5, Lte: 10}), `@population:[(1.5 10]`},
+		{filter.Lt("population", 2), `@population:[-inf (2]`},
+		{
+			filter.And(filter.Eq("city", "Tokyo"), filter.Or(filter.Gte("area", 600), filter.Not(filter.Eq("tags", "x")))),
+			`(@city:"Tokyo" (@area:[600 +inf] | -(@tags:{x})))`,
+		},
+	}
+	for _, tt := range tests {
+		got, err := s.getFilters(vectorstores.Options{Filters: tt.filter})
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := s.getFilters(vectorstores.Options{Filters: filter.Exists("city")})
+	require.ErrorIs(t, err, filter.ErrUnsupportedOperator)
+	_, err = s.getFilters(vectorstores.Options{Filters: filter.In("city")})
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+}
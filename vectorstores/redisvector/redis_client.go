@@ -22,6 +22,14 @@ type RedisClient interface {
 	CreateIndexIfNotExists(ctx context.Context, index string, schema *IndexSchema) error
 	AddDocWithHash(ctx context.Context, prefix string, doc schema.Document) (string, error)
 	AddDocsWithHash(ctx context.Context, prefix string, docs []schema.Document) ([]string, error)
+	// ReplaceDocsWithHash replaces the hashes of the keys with the documents.
+	ReplaceDocsWithHash(ctx context.Context, keys []string, docs []schema.Document) error
+	// GetDocsWithHash returns the documents of the hashes of the keys by key.
+	GetDocsWithHash(ctx context.Context, keys []string) (map[string]schema.Document, error)
+	// DeleteDocs deletes the keys.
+	DeleteDocs(ctx context.Context, keys []string) error
+	// SearchKeys returns the keys of at most limit documents of the index matching the query.
+	SearchKeys(ctx context.Context, index, query string, limit int) ([]string, error)
 	// TODO AddDocsWithJSON
 	Search(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, error)
 }
@@ -99,6 +107,66 @@ func (c RueidisClient) AddDocsWithHash(ctx context.Context, prefix string, docs
 	return docIDs, errors.Join(errs...)
 }
 
+func (c RueidisClient) ReplaceDocsWithHash(ctx context.Context, keys []string, docs []schema.Document) error {
+	cmds := make([]rueidis.Completed, 0, 2*len(docs))
+	for i, doc := range docs {
+		// delete the hash first, so that metadata missing from the new document does not remain
+		cmds = append(cmds, c.client.B().Del().Key(keys[i]).Build(), c.generateHSetCMDWithKey(keys[i], doc))
+	}
+	errs := make([]error, 0, len(docs))
+	for _, res := range c.client.DoMulti(ctx, cmds...) {
+		if res.Error() != nil {
+			errs = append(errs, res.Error())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c RueidisClient) GetDocsWithHash(ctx context.Context, keys []string) (map[string]schema.Document, error) {
+	cmds := make([]rueidis.Completed, 0, len(keys))
+	for _, key := range keys {
+		cmds = append(cmds, c.client.B().Hgetall().Key(key).Build())
+	}
+	hashes := make([]rueidis.FtSearchDoc, 0, len(keys))
+	for i, res := range c.client.DoMulti(ctx, cmds...) {
+		hash, err := res.AsStrMap()
+		if err != nil {
+			return nil, err
+		}
+		// missing keys have empty hashes
+		if len(hash) > 0 {
+			hashes = append(hashes, rueidis.FtSearchDoc{Key: keys[i], Doc: hash})
+		}
+	}
+
+	docs := make(map[string]schema.Document, len(hashes))
+	for i, doc := range convertFTSearchResIntoDocSchema(hashes) {
+		docs[hashes[i].Key] = doc
+	}
+	return docs, nil
+}
+
+func (c RueidisClient) DeleteDocs(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Do(ctx, c.client.B().Del().Key(keys...).Build()).Error()
+}
+
+func (c RueidisClient) SearchKeys(ctx context.Context, index, query string, limit int) ([]string, error) {
+	cmd := c.client.B().Arbitrary("FT.SEARCH").Keys(index).
+		Args(query, "RETURN", "1", defaultContentFieldKey, "LIMIT", "0", strconv.Itoa(limit)).Build()
+	_, docs, err := c.client.Do(ctx, cmd).AsFtSearch()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		keys = append(keys, doc.Key)
+	}
+	return keys, nil
+}
+
 func (c RueidisClient) Search(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, error) {
 	cmds := search.AsCommand()
 	// fmt.Println(strings.Join(cmds, " "))
@@ -111,6 +179,11 @@ func (c RueidisClient) Search(ctx context.Context, search IndexVectorSearch) (in
 }
 
 func (c RueidisClient) generateHSetCMD(prefix string, doc schema.Document) (string, rueidis.Completed) {
+	docID := getDocIDWithMetaData(prefix, doc.Metadata)
+	return docID, c.generateHSetCMDWithKey(docID, doc)
+}
+
+func (c RueidisClient) generateHSetCMDWithKey(docID string, doc schema.Document) rueidis.Completed {
 	kvs := make([]string, 0, len(maps.Keys(doc.Metadata))*2)
 	for k, v := range doc.Metadata {
 		kvs = append(kvs, k)
@@ -127,8 +200,7 @@ func (c RueidisClient) generateHSetCMD(prefix string, doc schema.Document) (stri
 			kvs = append(kvs, fmt.Sprintf("%v", v))
 		}
 	}
-	docID := getDocIDWithMetaData(prefix, doc.Metadata)
-	return docID, c.client.B().Arbitrary("Hmset").Keys(docID).Args(kvs...).Build()
+	return c.client.B().Arbitrary("Hmset").Keys(docID).Args(kvs...).Build()
 }
 
 // getPrefix get prefix with index name.
@@ -3,14 +3,19 @@ package redisvector
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"golang.org/x/exp/maps"
 )
 
 const (
+	// deleteByFilterBatchSize is the number of documents DeleteByFilter searches for at once.
+	deleteByFilterBatchSize = 1000
+
 	// same as langchain python version.
 	defaultContentFieldKey       = "content"        // page_content
 	defaultContentVectorFieldKey = "content_vector" // vector
@@ -36,7 +41,7 @@ type Store struct {
 	schemaGenerator        *schemaGenerator
 }
 
-var _ vectorstores.VectorStore = &Store{}
+var _ vectorstores.MutableVectorStore = &Store{}
 
 // New creates a new Store with options.
 func New(ctx context.Context, opts ...Option) (*Store, error) {
@@ -82,27 +87,126 @@ func (s *Store) AddDocuments(ctx context.Context, docs []schema.Document, _ ...v
 		return nil, err
 	}
 
-	indexSchema, err := generateSchemaWithMetadata(docs[0].Metadata)
+	if err := s.ensureIndex(ctx, docs); err != nil {
+		return nil, err
+	}
+
+	docIDs, err := s.client.AddDocsWithHash(ctx, getPrefix(s.indexName), docs)
 	if err != nil {
 		return nil, err
 	}
 
+	return docIDs, nil
+}
+
+// Upsert adds the documents to the redis associated with 'Store' with the ids, replacing the
+// documents with the same ids. The ids are the keys returned by AddDocuments, ids without the
+// `doc:{index_name}` prefix are prefixed with it.
+func (s *Store) Upsert(ctx context.Context, ids []string, docs []schema.Document, _ ...vectorstores.Option) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	// copy the documents, whose metadata gets the content and the vector
+	docs = append([]schema.Document(nil), docs...)
+	for i := range docs {
+		docs[i].Metadata = maps.Clone(docs[i].Metadata)
+	}
+	if err := s.appendDocumentsWithVectors(ctx, docs); err != nil {
+		return err
+	}
+
+	if err := s.ensureIndex(ctx, docs); err != nil {
+		return err
+	}
+
+	return s.client.ReplaceDocsWithHash(ctx, s.getKeys(ids), docs)
+}
+
+// GetByIDs returns the documents with the ids, see Upsert, by id.
+func (s *Store) GetByIDs(ctx context.Context, ids []string, _ ...vectorstores.Option) (map[string]schema.Document, error) {
+	if len(ids) == 0 {
+		return map[string]schema.Document{}, nil
+	}
+
+	keys := s.getKeys(ids)
+	docsByKey, err := s.client.GetDocsWithHash(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]schema.Document, len(docsByKey))
+	for i, key := range keys {
+		if doc, ok := docsByKey[key]; ok {
+			docs[ids[i]] = doc
+		}
+	}
+	return docs, nil
+}
+
+// Delete deletes the documents with the ids, see Upsert.
+func (s *Store) Delete(ctx context.Context, ids []string, _ ...vectorstores.Option) error {
+	return s.client.DeleteDocs(ctx, s.getKeys(ids))
+}
+
+// DeleteByFilter deletes the documents of the index matching the filter, a redis search query
+// (eg: @title:Dune).
+func (s *Store) DeleteByFilter(ctx context.Context, filter any, _ ...vectorstores.Option) error {
+	query, err := s.getFilters(vectorstores.Options{Filters: filter})
+	if err != nil {
+		return err
+	}
+	if query == "" || query == "*" {
+		return vectorstores.ErrEmptyFilter
+	}
+
+	for {
+		keys, err := s.client.SearchKeys(ctx, s.indexName, query, deleteByFilterBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := s.client.DeleteDocs(ctx, keys); err != nil {
+			return err
+		}
+	}
+}
+
+// ensureIndex creates the index from the metadata of the documents, if needed.
+func (s *Store) ensureIndex(ctx context.Context, docs []schema.Document) error {
+	indexSchema, err := generateSchemaWithMetadata(docs[0].Metadata)
+	if err != nil {
+		return err
+	}
+
 	if s.indexSchema == nil {
 		s.indexSchema = indexSchema
 	}
 
 	if s.createIndexIfNotExists && !s.client.CheckIndexExists(ctx, s.indexName) {
 		if err := s.client.CreateIndexIfNotExists(ctx, s.indexName, indexSchema); err != nil {
-			return nil, err
+			return err
 		}
 	}
+	return nil
+}
 
-	docIDs, err := s.client.AddDocsWithHash(ctx, getPrefix(s.indexName), docs)
-	if err != nil {
-		return nil, err
+// getKeys returns the keys of the ids, prefixed with `doc:{index_name}` if needed.
+func (s Store) getKeys(ids []string) []string {
+	prefix := getPrefix(s.indexName) + ":"
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !strings.HasPrefix(id, prefix) {
+			id = prefix + id
+		}
+		keys = append(keys, id)
 	}
-
-	return docIDs, nil
+	return keys
 }
 
 // SimilaritySearch similarity search docs with `ScoreThreshold` `Filters` `Embedder`
@@ -110,6 +214,7 @@ func (s *Store) AddDocuments(ctx context.Context, docs []schema.Document, _ ...v
 //
 //	WithScoreThreshold:
 //	WithFilters: filter string should match redis search pre-filter query pattern.(eg: @title:Dune)
+//		or a filter.Filter, translated to such a query
 //		ref: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#pre-filter-query-attributes-hybrid-approach
 //	WithEmbedder: if set, it will embed query string with this embedder; otherwise embed with vector's embedder
 //
@@ -176,15 +281,22 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-// getFilters return metadata filters.
+// getFilters return metadata filters, a redis search query, or a filter.Filter
+// translated to one.
 func (s Store) getFilters(opts vectorstores.Options) (string, error) {
-	if opts.Filters != nil {
-		if filters, ok := opts.Filters.(string); ok {
-			return filters, nil
+	switch filters := opts.Filters.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return filters, nil
+	case filter.Filter:
+		if err := filters.Validate(); err != nil {
+			return "", err
 		}
+		return s.createFilterQuery(filters)
+	default:
 		return "", ErrInvalidFilters
 	}
-	return "", nil
 }
 
 // append content & content_vector into doc.Metadata.
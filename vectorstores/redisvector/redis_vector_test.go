@@ -252,6 +252,55 @@ func TestAddDocuments(t *testing.T) {
 	})
 }
 
+func TestRedisVectorMutations(t *testing.T) {
+	t.Parallel()
+
+	redisURL, ollamaURL := getValues(t)
+	_, e := getEmbedding(ollamaModel, ollamaURL)
+	ctx := context.Background()
+
+	index := "test_mutations"
+	store, err := redisvector.New(ctx,
+		redisvector.WithConnectionURL(redisURL),
+		redisvector.WithIndexName(index, true),
+		redisvector.WithEmbedder(e),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.DropIndex(ctx, index, true))
+	})
+
+	err = store.Upsert(ctx, []string{"tokyo", "kyoto"}, []schema.Document{
+		{PageContent: "Tokyo", Metadata: map[string]any{"country": "japan"}},
+		{PageContent: "Kyoto", Metadata: map[string]any{"country": "japan"}},
+	})
+	require.NoError(t, err)
+
+	err = store.Upsert(ctx, []string{"tokyo"}, []schema.Document{
+		{PageContent: "Tokyo, Japan", Metadata: map[string]any{"country": "japan"}},
+	})
+	require.NoError(t, err)
+
+	docs, err := store.GetByIDs(ctx, []string{"tokyo", "kyoto", "paris"})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "Tokyo, Japan", docs["tokyo"].PageContent)
+	assert.Equal(t, "japan", docs["kyoto"].Metadata["country"])
+
+	require.NoError(t, store.Delete(ctx, []string{"tokyo"}))
+	docs, err = store.GetByIDs(ctx, []string{"tokyo", "kyoto"})
+	require.NoError(t, err)
+	assert.Len(t, docs, 1)
+
+	require.NoError(t, store.DeleteByFilter(ctx, "@country:(japan)"))
+	docs, err = store.GetByIDs(ctx, []string{"kyoto"})
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+
+	err = store.Upsert(ctx, []string{"tokyo"}, nil)
+	require.ErrorIs(t, err, vectorstores.ErrIDsMismatch)
+}
+
 func TestSimilaritySearch(t *testing.T) {
 	t.Parallel()
 
@@ -2,6 +2,7 @@ package vectorstores
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/schema"
@@ -14,6 +15,34 @@ type VectorStore interface {
 	SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...Option) ([]schema.Document, error) //nolint:lll
 }
 
+var (
+	// ErrEmptyFilter is returned by DeleteByFilter when the filter is empty,
+	// to avoid deleting all the documents by mistake.
+	ErrEmptyFilter = errors.New("empty filter")
+	// ErrIDsMismatch is returned by Upsert when the number of IDs does not
+	// match the number of documents.
+	ErrIDsMismatch = errors.New("number of IDs does not match number of documents")
+)
+
+// MutableVectorStore is the interface of the vector stores whose documents can
+// be retrieved, replaced and deleted by the IDs returned by AddDocuments, so
+// that changed documents do not leave stale ones behind. Check whether a
+// VectorStore implements it with a type assertion.
+type MutableVectorStore interface {
+	VectorStore
+	// Upsert adds the documents with the IDs, replacing the documents with the
+	// same IDs. The IDs must have the format of the IDs of the store.
+	Upsert(ctx context.Context, ids []string, docs []schema.Document, options ...Option) error
+	// GetByIDs returns the documents with the IDs by ID. Unknown IDs are
+	// ignored.
+	GetByIDs(ctx context.Context, ids []string, options ...Option) (map[string]schema.Document, error)
+	// Delete removes the documents with the IDs. Unknown IDs are ignored.
+	Delete(ctx context.Context, ids []string, options ...Option) error
+	// DeleteByFilter removes the documents matching the filter, in the format
+	// of WithFilters. It fails with ErrEmptyFilter if the filter is empty.
+	DeleteByFilter(ctx context.Context, filter any, options ...Option) error
+}
+
 // Retriever is a retriever for vector stores.
 type Retriever struct {
 	CallbacksHandler callbacks.Handler
@@ -30,7 +59,18 @@ func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]sc
 		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
 	}
 
-	docs, err := r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	opts := Options{}
+	for _, opt := range r.options {
+		opt(&opts)
+	}
+
+	var docs []schema.Document
+	var err error
+	if opts.MMR != nil {
+		docs, err = MaxMarginalRelevanceSearch(ctx, r.v, query, r.numDocs, r.options...)
+	} else {
+		docs, err = r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +83,8 @@ func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]sc
 }
 
 // ToRetriever takes a vector store and returns a retriever using the
-// vector store to retrieve documents.
+// vector store to retrieve documents, by maximal marginal relevance with
+// WithMMR.
 func ToRetriever(vectorStore VectorStore, numDocuments int, options ...Option) Retriever {
 	return Retriever{
 		v:       vectorStore,
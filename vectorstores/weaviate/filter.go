@@ -0,0 +1,88 @@
+package weaviate
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+// createWhereFilter returns the where filter of the filter. Weaviate has no
+// negation, which is pushed down to the conditions. OpExists is translated to
+// IsNull, which needs the class to index the null state of the properties.
+func createWhereFilter(f filter.Filter) *filters.WhereBuilder {
+	return createWhereFilterNode(f.PushDownNot())
+}
+
+//nolint:cyclop
+func createWhereFilterNode(f filter.Filter) *filters.WhereBuilder {
+	switch f.Op {
+	case filter.OpEq:
+		return withValue(filters.Where().WithPath([]string{f.Key}).WithOperator(filters.Equal), f.Value)
+	case filter.OpNe:
+		return withValue(filters.Where().WithPath([]string{f.Key}).WithOperator(filters.NotEqual), f.Value)
+	case filter.OpIn:
+		operands := make([]*filters.WhereBuilder, 0, len(f.Values))
+		for _, v := range f.Values {
+			operands = append(operands, createWhereFilterNode(filter.Eq(f.Key, v)))
+		}
+		return combineWhereFilters(filters.Or, operands)
+	case filter.OpRange:
+		operands := []*filters.WhereBuilder{}
+		for _, bound := range []struct {
+			operator filters.WhereOperator
+			value    any
+		}{
+			{filters.GreaterThan, f.Bounds.Gt},
+			{filters.GreaterThanEqual, f.Bounds.Gte},
+			{filters.LessThan, f.Bounds.Lt},
+			{filters.LessThanEqual, f.Bounds.Lte},
+		} {
+			if bound.value != nil {
+				operands = append(operands,
+					withValue(filters.Where().WithPath([]string{f.Key}).WithOperator(bound.operator), bound.value))
+			}
+		}
+		return combineWhereFilters(filters.And, operands)
+	case filter.OpExists:
+		return filters.Where().WithPath([]string{f.Key}).WithOperator(filters.IsNull).WithValueBoolean(false)
+	case filter.OpNot:
+		// Not only applies to Exists once pushed down.
+		return filters.Where().WithPath([]string{f.Filters[0].Key}).WithOperator(filters.IsNull).WithValueBoolean(true)
+	case filter.OpAnd, filter.OpOr:
+		operands := make([]*filters.WhereBuilder, 0, len(f.Filters))
+		for _, operand := range f.Filters {
+			operands = append(operands, createWhereFilterNode(operand))
+		}
+		operator := filters.And
+		if f.Op == filter.OpOr {
+			operator = filters.Or
+		}
+		return combineWhereFilters(operator, operands)
+	default:
+		return nil
+	}
+}
+
+// withValue sets the value of the where filter, with the type of the value.
+// Numbers are matched as Weaviate numbers, the type its auto-schema gives them.
+func withValue(where *filters.WhereBuilder, value any) *filters.WhereBuilder {
+	if v, ok := filter.ToFloat(value); ok {
+		return where.WithValueNumber(v)
+	}
+	switch v := value.(type) {
+	case bool:
+		return where.WithValueBoolean(v)
+	case string:
+		return where.WithValueText(v)
+	default:
+		return where.WithValueText(fmt.Sprint(v))
+	}
+}
+
+func combineWhereFilters(operator filters.WhereOperator, operands []*filters.WhereBuilder) *filters.WhereBuilder {
+	if len(operands) == 1 {
+		return operands[0]
+	}
+	return filters.Where().WithOperator(operator).WithOperands(operands)
+}
@@ -0,0 +1,58 @@
+package weaviate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+func TestCreateWhereBuilder(t *testing.T) {
+	t.Parallel()
+
+	s := Store{nameSpaceKey: "nameSpace"}
+	got, err := s.createWhereBuilder("docs", filter.Or(
+		filter.In("location", "patio", "kitchen"),
+		filter.Not(filter.Range("size", filter.Bounds{Gte: 1, Lt: 3})),
+		filter.And(filter.Eq("public", true), filter.Exists("author")),
+	))
+	require.NoError(t, err)
+
+	want := filters.Where().WithOperator(filters.And).WithOperands([]*filters.WhereBuilder{
+		filters.Where().WithPath([]string{"nameSpace"}).WithOperator(filters.Equal).WithValueString("docs"),
+		filters.Where().WithOperator(filters.Or).WithOperands([]*filters.WhereBuilder{
+			filters.Where().WithOperator(filters.Or).WithOperands([]*filters.WhereBuilder{
+				filters.Where().WithPath([]string{"location"}).WithOperator(filters.Equal).WithValueText("patio"),
+				filters.Where().WithPath([]string{"location"}).WithOperator(filters.Equal).WithValueText("kitchen"),
+			}),
+			filters.Where().WithOperator(filters.Or).WithOperands([]*filters.WhereBuilder{
+				filters.Where().WithPath([]string{"size"}).WithOperator(filters.LessThan).WithValueNumber(1),
+				filters.Where().WithPath([]string{"size"}).WithOperator(filters.GreaterThanEqual).WithValueNumber(3),
+				filters.Where().WithPath([]string{"size"}).WithOperator(filters.IsNull).WithValueBoolean(true),
+			}),
+			filters.Where().WithOperator(filters.And).WithOperands([]*filters.WhereBuilder{
+				filters.Where().WithPath([]string{"public"}).WithOperator(filters.Equal).WithValueBoolean(true),
+				filters.Where().WithPath([]string{"author"}).WithOperator(filters.IsNull).WithValueBoolean(false),
+			}),
+		}),
+	})
+	assert.Equal(t, want.String(), got.String())
+
+	_, err = s.createWhereBuilder("docs", filter.Eq("", "patio"))
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+	_, err = s.createWhereBuilder("docs", map[string]any{"location": "patio"})
+	require.ErrorIs(t, err, ErrInvalidFilter)
+}
+
+func TestDeleteByEmptyFilter(t *testing.T) {
+	t.Parallel()
+
+	s := Store{}
+	require.ErrorIs(t, s.DeleteByFilter(context.Background(), nil), vectorstores.ErrEmptyFilter)
+	var where *filters.WhereBuilder
+	require.ErrorIs(t, s.DeleteByFilter(context.Background(), where), vectorstores.ErrEmptyFilter)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
+	"github.com/tmc/langchaingo/vectorstores/filter"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/auth"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
@@ -61,7 +62,7 @@ type Store struct {
 	additionalFields []string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var _ vectorstores.MutableVectorStore = Store{}
 
 // New creates a new Store with options.
 // When using weaviate,
@@ -104,6 +105,106 @@ func (s Store) AddDocuments(ctx context.Context,
 		return nil, nil
 	}
 
+	ids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = uuid.New().String()
+	}
+	if err := s.upsertObjects(ctx, opts, nameSpace, ids, docs); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Upsert creates vector embeddings from the documents using the embedder
+// and upsert the vectors to the weaviate index with the ids, which must be
+// UUIDs.
+func (s Store) Upsert(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsMismatch
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	opts := s.getOptions(options...)
+	return s.upsertObjects(ctx, opts, s.getNameSpace(opts), ids, docs)
+}
+
+// GetByIDs returns the documents of the name space with the ids.
+func (s Store) GetByIDs(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	if len(ids) == 0 {
+		return map[string]schema.Document{}, nil
+	}
+	opts := s.getOptions(options...)
+	whereBuilder, err := s.createWhereBuilder(s.getNameSpace(opts), s.createIDsFilter(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := s.createFields()
+	additional := &fields[len(fields)-1]
+	additional.Fields = append(additional.Fields, graphql.Field{Name: "id"})
+	res, err := s.client.GraphQL().
+		Get().
+		WithWhere(whereBuilder).
+		WithClassName(s.indexName).
+		WithLimit(len(ids)).
+		WithFields(fields...).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := s.parseDocumentsByGraphQLResponse(res)
+	if errors.Is(err, ErrEmptyResponse) {
+		return map[string]schema.Document{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	docsByID := make(map[string]schema.Document, len(docs))
+	for _, doc := range docs {
+		additional, _ := doc.Metadata["_additional"].(map[string]any)
+		id, ok := additional["id"].(string)
+		if !ok {
+			return nil, ErrInvalidResponse
+		}
+		docsByID[id] = doc
+	}
+	return docsByID, nil
+}
+
+// Delete deletes the objects of the name space with the ids.
+func (s Store) Delete(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.deleteObjects(ctx, s.createIDsFilter(ids), options...)
+}
+
+// DeleteByFilter deletes the objects of the name space matching the filter, a
+// *filters.WhereBuilder or a filter.Filter.
+func (s Store) DeleteByFilter(ctx context.Context, filter any, options ...vectorstores.Option) error {
+	if where, ok := filter.(*filters.WhereBuilder); filter == nil || (ok && where == nil) {
+		return vectorstores.ErrEmptyFilter
+	}
+	return s.deleteObjects(ctx, filter, options...)
+}
+
+// upsertObjects creates vector embeddings from the documents using the embedder
+// and upsert the vectors to the weaviate index with the ids.
+func (s Store) upsertObjects(ctx context.Context,
+	opts vectorstores.Options,
+	nameSpace string,
+	ids []string,
+	docs []schema.Document,
+) error {
 	texts := make([]string, 0, len(docs))
 	for _, doc := range docs {
 		texts = append(texts, doc.PageContent)
@@ -111,11 +212,11 @@ func (s Store) AddDocuments(ctx context.Context,
 
 	vectors, err := opts.Embedder.EmbedDocuments(ctx, texts)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(vectors) != len(docs) {
-		return nil, ErrEmbedderWrongNumberVectors
+		return ErrEmbedderWrongNumberVectors
 	}
 
 	metadatas := make([]map[string]any, 0, len(docs))
@@ -131,21 +232,36 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	objects := make([]*models.Object, 0, len(docs))
-	ids := make([]string, len(docs))
 	for i := range docs {
-		id := strfmt.UUID(uuid.New().String())
-		ids[i] = id.String()
 		objects = append(objects, &models.Object{
 			Class:      s.indexName,
-			ID:         id,
+			ID:         strfmt.UUID(ids[i]),
 			Vector:     vectors[i],
 			Properties: metadatas[i],
 		})
 	}
-	if _, err := s.client.Batch().ObjectsBatcher().WithObjects(objects...).Do(ctx); err != nil {
-		return nil, err
+	_, err = s.client.Batch().ObjectsBatcher().WithObjects(objects...).Do(ctx)
+	return err
+}
+
+// deleteObjects deletes the objects of the name space matching the filter.
+func (s Store) deleteObjects(ctx context.Context, filter any, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	whereBuilder, err := s.createWhereBuilder(s.getNameSpace(opts), filter)
+	if err != nil {
+		return err
 	}
-	return ids, nil
+
+	_, err = s.client.Batch().
+		ObjectsBatchDeleter().
+		WithClassName(s.indexName).
+		WithWhere(whereBuilder).
+		Do(ctx)
+	return err
+}
+
+func (s Store) createIDsFilter(ids []string) *filters.WhereBuilder {
+	return filters.Where().WithPath([]string{"id"}).WithOperator(filters.ContainsAny).WithValueText(ids...)
 }
 
 func (s Store) SimilaritySearch(
@@ -189,8 +305,8 @@ func (s Store) SimilaritySearch(
 }
 
 // MetadataSearch searches weaviate based on metadata rather than based on similarity.
-// Use `vectorstores.WithFilter(*filters.WhereBuilder)` to provide a where condition
-// as an option.
+// Use `vectorstores.WithFilter(*filters.WhereBuilder)` or `vectorstores.WithFilter(filter.Filter)`
+// to provide a where condition as an option.
 func (s Store) MetadataSearch(
 	ctx context.Context,
 	numDocuments int,
@@ -311,13 +427,23 @@ func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	return opts
 }
 
-func (s Store) createWhereBuilder(namespace string, filter any) (*filters.WhereBuilder, error) {
-	if filter == nil {
+// createWhereBuilder returns the where filter of the name space and the filter, a
+// *filters.WhereBuilder or a filter.Filter.
+func (s Store) createWhereBuilder(namespace string, where any) (*filters.WhereBuilder, error) {
+	if where == nil {
 		return filters.Where().WithPath([]string{s.nameSpaceKey}).WithOperator(filters.Equal).WithValueString(namespace), nil
 	}
 
-	whereFilter, ok := filter.(*filters.WhereBuilder)
-	if !ok {
+	var whereFilter *filters.WhereBuilder
+	switch where := where.(type) {
+	case *filters.WhereBuilder:
+		whereFilter = where
+	case filter.Filter:
+		if err := where.Validate(); err != nil {
+			return nil, err
+		}
+		whereFilter = createWhereFilter(where)
+	default:
 		return nil, ErrInvalidFilter
 	}
 	return filters.Where().WithOperator(filters.And).WithOperands([]*filters.WhereBuilder{
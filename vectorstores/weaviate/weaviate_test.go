@@ -783,3 +783,53 @@ func TestWeaviateWithOptionEmbedder(t *testing.T) {
 	require.Equal(t, "tokyo", docs[0].PageContent)
 	require.Equal(t, "japan", docs[0].Metadata["country"])
 }
+
+func TestWeaviateStoreMutations(t *testing.T) {
+	t.Parallel()
+
+	scheme, host := getValues(t)
+
+	llm, err := openai.New()
+	require.NoError(t, err)
+	e, err := embeddings.NewEmbedder(llm)
+	require.NoError(t, err)
+
+	store, err := New(
+		WithScheme(scheme),
+		WithHost(host),
+		WithEmbedder(e),
+		WithNameSpace(uuid.New().String()),
+		WithIndexName(randomizedCamelCaseClass()),
+		WithQueryAttrs([]string{"type"}),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	err = createTestClass(ctx, store)
+	require.NoError(t, err)
+
+	ids, err := store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"type": "city"}},
+		{PageContent: "potato", Metadata: map[string]any{"type": "vegetable"}},
+		{PageContent: "paris", Metadata: map[string]any{"type": "city"}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Upsert(ctx, ids[:1], []schema.Document{
+		{PageContent: "kyoto", Metadata: map[string]any{"type": "city"}},
+	}))
+	docs, err := store.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+
+	require.NoError(t, store.Delete(ctx, ids[2:]))
+	require.NoError(t, store.DeleteByFilter(ctx,
+		filters.Where().WithPath([]string{"type"}).WithOperator(filters.Equal).WithValueString("vegetable")))
+	require.ErrorIs(t, store.DeleteByFilter(ctx, nil), vectorstores.ErrEmptyFilter)
+
+	docs, err = store.GetByIDs(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "kyoto", docs[ids[0]].PageContent)
+}